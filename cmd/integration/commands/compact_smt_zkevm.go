@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/spf13/cobra"
+
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/smt"
+	zkStages "github.com/ledgerwatch/erigon/zk/stages"
+)
+
+var cmdCompactSmt = &cobra.Command{
+	Use:     "compact-smt",
+	Short:   "Delete the SMT nodes which can't be reached from the state root of any recent block",
+	Example: "go run ./cmd/integration compact-smt --datadir=/datadirs/hermez-mainnet --retain-blocks=128",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, _ := common.RootContext()
+		db := openDB(dbCfg(kv.ChainDB, chaindata), true)
+		defer db.Close()
+
+		if err := compactSmt(ctx, db); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Error(err.Error())
+			}
+			return
+		}
+	},
+}
+
+func init() {
+	withDataDir(cmdCompactSmt)
+	withSmtCompact(cmdCompactSmt)
+
+	rootCmd.AddCommand(cmdCompactSmt)
+}
+
+// compactSmt runs whole garbage collection passes over the SMT, committing after every batch of work
+func compactSmt(ctx context.Context, db kv.RwDB) error {
+	if err := db.Update(ctx, db2.CreateEriDbBuckets); err != nil {
+		return err
+	}
+
+	var marked, swept int
+	for {
+		var finished bool
+		err := db.Update(ctx, func(tx kv.RwTx) error {
+			progress, err := stages.GetStageProgress(tx, stages.IntermediateHashes)
+			if err != nil {
+				return err
+			}
+			roots, err := zkStages.SmtRetainedRoots(tx, progress, smtRetainBlocks)
+			if err != nil {
+				return err
+			}
+
+			result, err := smt.NewSMT(db2.NewEriDb(tx)).CollectGarbage(ctx, roots, int(smtGcBatchSize))
+			if err != nil {
+				return err
+			}
+			marked += result.Marked
+			swept += result.Swept
+			finished = result.Finished
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		log.Info("Compacting SMT", "marked", marked, "swept", swept)
+		if finished {
+			break
+		}
+	}
+
+	log.Info("SMT compacted, the freed space is reused by the db. Copy the db to shrink the file", "swept", swept)
+	return nil
+}
@@ -1,11 +1,74 @@
 package commands
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ledgerwatch/erigon/zk/datastream/client"
+	"github.com/ledgerwatch/erigon/zk/state_snapshot"
+)
 
 var (
-	unwindBatchNo uint64
+	unwindBatchNo   uint64
+	smtRetainBlocks uint64
+	smtGcBatchSize  uint64
+
+	snapshotOutput    string
+	snapshotLocation  string
+	snapshotChunkSize int
+
+	snapshotTrustedRoot              string
+	snapshotTrustedDatastream        string
+	snapshotTrustedDatastreamVersion int
+
+	diffFromBlock uint64
+	diffToBlock   uint64
+
+	dumpBlock  uint64
+	dumpFormat string
+	dumpOutput string
+
+	verifyMaxDivergences int
+	verifyRepair         bool
 )
 
 func withUnwindBatchNo(cmd *cobra.Command) {
 	cmd.Flags().Uint64Var(&unwindBatchNo, "unwind-batch-no", 0, "batch number to unwind to (this batch number will be the tip after unwind)")
 }
+
+func withSmtCompact(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(&smtRetainBlocks, "retain-blocks", 128, "number of recent blocks whose SMT nodes are kept")
+	cmd.Flags().Uint64Var(&smtGcBatchSize, "gc-batch-size", 1_000_000, "number of SMT nodes marked or swept in each db transaction")
+}
+
+func withStateSnapshotExport(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&snapshotOutput, "output", "", "directory the state snapshot is written to")
+	must(cmd.MarkFlagRequired("output"))
+	cmd.Flags().IntVar(&snapshotChunkSize, "chunk-size", state_snapshot.DefaultChunkSize, "uncompressed size in bytes of each snapshot chunk")
+}
+
+func withStateSnapshotImport(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&snapshotLocation, "snapshot", "", "directory or http(s) url of the state snapshot to import")
+	must(cmd.MarkFlagRequired("snapshot"))
+	cmd.Flags().StringVar(&snapshotTrustedRoot, "trusted-root", "", "state root of the snapshot block obtained independently of the snapshot")
+	cmd.Flags().StringVar(&snapshotTrustedDatastream, "trusted-datastream", "", "datastream the state root of the snapshot block is read from, instead of --trusted-root")
+	cmd.Flags().IntVar(&snapshotTrustedDatastreamVersion, "trusted-datastream-version", client.BigEndianVersion, "version of the datastream at --trusted-datastream")
+	cmd.MarkFlagsMutuallyExclusive("trusted-root", "trusted-datastream")
+}
+
+func withSmtStateDiff(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(&diffFromBlock, "from-block", 0, "block the diff starts from")
+	must(cmd.MarkFlagRequired("from-block"))
+	cmd.Flags().Uint64Var(&diffToBlock, "to-block", 0, "block the diff ends at")
+	must(cmd.MarkFlagRequired("to-block"))
+}
+
+func withSmtStateDump(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(&dumpBlock, "block", 0, "block to dump the state at, defaults to the last hashed block")
+	cmd.Flags().StringVar(&dumpFormat, "format", "json", "output format, json (one leaf per line) or csv")
+	cmd.Flags().StringVar(&dumpOutput, "output", "", "file the dump is written to, defaults to stdout")
+}
+
+func withSmtVerify(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&verifyMaxDivergences, "max-divergences", 100, "stop after finding this many diverging leaves, 0 for no limit. --repair refuses to run when the limit is reached")
+	cmd.Flags().BoolVar(&verifyRepair, "repair", false, "write the plain state values of the diverging leaves into the SMT if the repaired root matches the header")
+}
@@ -88,6 +88,11 @@ func newSyncZk(ctx context.Context, db kv.RwDB) (consensus.Engine, *vm.Config, *
 			nil,
 			nil,
 			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		)
 	} else {
 		stages = stages2.NewDefaultZkStages(
@@ -103,6 +108,7 @@ func newSyncZk(ctx context.Context, db kv.RwDB) (consensus.Engine, *vm.Config, *
 			engine,
 			nil,
 			nil,
+			nil,
 			nil)
 	}
 
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/spf13/cobra"
+
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/smt"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+)
+
+var cmdSmtStateDiff = &cobra.Command{
+	Use:     "smt-state-diff",
+	Short:   "Print the balances, nonces, code and storage that differ between the SMTs of two blocks",
+	Example: "go run ./cmd/integration smt-state-diff --datadir=/datadirs/hermez-mainnet --from-block=100 --to-block=110",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, _ := common.RootContext()
+		db := openDB(dbCfg(kv.ChainDB, chaindata), true)
+		defer db.Close()
+
+		if err := smtStateDiff(ctx, db); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Error(err.Error())
+			}
+			return
+		}
+	},
+}
+
+func init() {
+	withDataDir(cmdSmtStateDiff)
+	withSmtStateDiff(cmdSmtStateDiff)
+
+	rootCmd.AddCommand(cmdSmtStateDiff)
+}
+
+func smtStateDiff(ctx context.Context, db kv.RwDB) error {
+	// the smt db wants a read-write tx, it is rolled back
+	tx, err := db.BeginRw(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	dbSmt := smt.NewSMT(db2.NewEriDb(tx))
+	hashed, err := stages.GetStageProgress(tx, stages.IntermediateHashes)
+	if err != nil {
+		return err
+	}
+	fromRoot, err := dbSmt.RootAtOrLast(diffFromBlock, hashed)
+	if err != nil {
+		return err
+	}
+	toRoot, err := dbSmt.RootAtOrLast(diffToBlock, hashed)
+	if err != nil {
+		return err
+	}
+
+	diff, err := dbSmt.StateDiff(ctx, fromRoot, toRoot, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	printChange := func(name string, change *smt.LeafChange) {
+		if change != nil {
+			fmt.Printf("  %s: %#x -> %#x\n", name, change.From, change.To)
+		}
+	}
+	for addr, account := range diff.Accounts {
+		fmt.Println(addr.Hex())
+		printChange("balance", account.Balance)
+		printChange("nonce", account.Nonce)
+		printChange("codeHash", account.CodeHash)
+		printChange("codeLength", account.CodeLength)
+		for slot, change := range account.Storage {
+			printChange(slot.Hex(), change)
+		}
+	}
+	if len(diff.Unresolved) > 0 {
+		fmt.Println("unresolved")
+	}
+	for _, change := range diff.Unresolved {
+		printChange(utils.ConvertBigIntToHex(change.Key.ToBigInt()), change)
+	}
+
+	log.Info("SMT state diff", "from", diffFromBlock, "to", diffToBlock, "accounts", len(diff.Accounts), "unresolved", len(diff.Unresolved))
+	return nil
+}
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/spf13/cobra"
+
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/smt"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+)
+
+var cmdDumpSmtState = &cobra.Command{
+	Use:     "dump-smt-state",
+	Short:   "Write every leaf of the SMT at a block with the account field it holds, as JSON lines or CSV",
+	Example: "go run ./cmd/integration dump-smt-state --datadir=/datadirs/hermez-mainnet --block=1000 --format=csv --output=state.csv",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, _ := common.RootContext()
+		db := openDB(dbCfg(kv.ChainDB, chaindata), true)
+		defer db.Close()
+
+		if err := dumpSmtState(ctx, db, cmd.Flags().Changed("block")); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Error(err.Error())
+			}
+			return
+		}
+	},
+}
+
+func init() {
+	withDataDir(cmdDumpSmtState)
+	withSmtStateDump(cmdDumpSmtState)
+
+	rootCmd.AddCommand(cmdDumpSmtState)
+}
+
+type stateDumpLeaf struct {
+	Key        string `json:"key"`
+	Address    string `json:"address,omitempty"`
+	Field      string `json:"field"`
+	StorageKey string `json:"storageKey,omitempty"`
+	Value      string `json:"value"`
+}
+
+func dumpSmtState(ctx context.Context, db kv.RwDB, blockSet bool) error {
+	var write func(*stateDumpLeaf) error
+	var flush func() error
+
+	var out io.Writer = os.Stdout
+	if dumpOutput != "" {
+		f, err := os.Create(dumpOutput)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	buffered := bufio.NewWriter(out)
+
+	switch dumpFormat {
+	case "json":
+		encoder := json.NewEncoder(buffered)
+		write = func(leaf *stateDumpLeaf) error { return encoder.Encode(leaf) }
+		flush = buffered.Flush
+	case "csv":
+		w := csv.NewWriter(buffered)
+		if err := w.Write([]string{"key", "address", "field", "storageKey", "value"}); err != nil {
+			return err
+		}
+		write = func(leaf *stateDumpLeaf) error {
+			return w.Write([]string{leaf.Key, leaf.Address, leaf.Field, leaf.StorageKey, leaf.Value})
+		}
+		flush = func() error {
+			w.Flush()
+			if err := w.Error(); err != nil {
+				return err
+			}
+			return buffered.Flush()
+		}
+	default:
+		return fmt.Errorf("unknown format %q, expected json or csv", dumpFormat)
+	}
+
+	// the smt db wants a read-write tx, it is rolled back
+	tx, err := db.BeginRw(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	blockNr := dumpBlock
+	if !blockSet {
+		if blockNr, err = stages.GetStageProgress(tx, stages.IntermediateHashes); err != nil {
+			return err
+		}
+	}
+
+	dbSmt := smt.NewSMT(db2.NewEriDb(tx))
+	hashed, err := stages.GetStageProgress(tx, stages.IntermediateHashes)
+	if err != nil {
+		return err
+	}
+	root, err := dbSmt.RootAtOrLast(blockNr, hashed)
+	if err != nil {
+		return err
+	}
+
+	var count int
+	err = dbSmt.IterateState(ctx, root, nil, func(leaf *smt.StateLeaf) (bool, error) {
+		row := &stateDumpLeaf{
+			Key:   utils.ConvertBigIntToHex(leaf.Key.ToBigInt()),
+			Field: leaf.Field(),
+			Value: utils.ConvertBigIntToHex(leaf.Value),
+		}
+		if leaf.Resolved {
+			row.Address = leaf.Address.Hex()
+			if leaf.Type == utils.SC_STORAGE {
+				row.StorageKey = leaf.StorageKey.Hex()
+			}
+		}
+		count++
+		return true, write(row)
+	})
+	if err != nil {
+		return err
+	}
+	if err = flush(); err != nil {
+		return err
+	}
+
+	log.Info("Dumped SMT state", "block", blockNr, "root", utils.ConvertBigIntToHex(root.ToBigInt()), "leaves", count)
+	return nil
+}
@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/datadir"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/spf13/cobra"
+
+	"github.com/ledgerwatch/erigon/zk/state_snapshot"
+)
+
+var cmdExportStateSnapshot = &cobra.Command{
+	Use:     "export-state-snapshot",
+	Short:   "Write the SMT and plain state at the current block into a chunked snapshot",
+	Example: "go run ./cmd/integration export-state-snapshot --datadir=/datadirs/hermez-mainnet --output=/snapshots/hermez-mainnet",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, _ := common.RootContext()
+		db := openDB(dbCfg(kv.ChainDB, chaindata).Readonly(), false)
+		defer db.Close()
+
+		if err := db.View(ctx, func(tx kv.Tx) error {
+			manifest, err := state_snapshot.Export(ctx, tx, snapshotOutput, snapshotChunkSize)
+			if err != nil {
+				return err
+			}
+			log.Info("Exported state snapshot", "batch", manifest.BatchNumber, "block", manifest.BlockNumber, "root", manifest.StateRoot, "chunks", len(manifest.Chunks))
+			return nil
+		}); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Error(err.Error())
+			}
+			return
+		}
+	},
+}
+
+var cmdImportStateSnapshot = &cobra.Command{
+	Use:     "import-state-snapshot",
+	Short:   "Bootstrap an empty datadir from a state snapshot, syncing then continues from the snapshot batch",
+	Example: "go run ./cmd/integration import-state-snapshot --datadir=/datadirs/hermez-mainnet --snapshot=https://snapshots.example.com/hermez-mainnet --trusted-datastream=stream.zkevm-rpc.com:6900",
+	Run: func(cmd *cobra.Command, args []string) {
+		var trusted state_snapshot.TrustedRoot
+		switch {
+		case snapshotTrustedRoot != "":
+			trusted = state_snapshot.FixedRoot(common.HexToHash(snapshotTrustedRoot))
+		case snapshotTrustedDatastream != "":
+			trusted = state_snapshot.DatastreamRoot{Url: snapshotTrustedDatastream, Version: snapshotTrustedDatastreamVersion, Timeout: 10 * time.Second}
+		default:
+			log.Error("the snapshot root has to be checked against --trusted-root or --trusted-datastream")
+			return
+		}
+
+		ctx, _ := common.RootContext()
+		db := openDB(dbCfg(kv.ChainDB, chaindata), true)
+		defer db.Close()
+
+		manifest, err := state_snapshot.Import(ctx, db, state_snapshot.NewSource(snapshotLocation), datadir.New(datadirCli).Tmp, trusted)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Error(err.Error())
+			}
+			return
+		}
+		log.Info("Imported state snapshot", "batch", manifest.BatchNumber, "block", manifest.BlockNumber, "root", manifest.StateRoot)
+	},
+}
+
+func init() {
+	withDataDir(cmdExportStateSnapshot)
+	withStateSnapshotExport(cmdExportStateSnapshot)
+	rootCmd.AddCommand(cmdExportStateSnapshot)
+
+	withDataDir(cmdImportStateSnapshot)
+	withStateSnapshotImport(cmdImportStateSnapshot)
+	rootCmd.AddCommand(cmdImportStateSnapshot)
+}
@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/spf13/cobra"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	zkStages "github.com/ledgerwatch/erigon/zk/stages"
+)
+
+var cmdVerifySmt = &cobra.Command{
+	Use:   "verify-smt",
+	Short: "Cross-check every SMT leaf against the plain state and optionally repair the diverging leaves",
+	Long: `Cross-check every leaf of the latest SMT against the plain state and optionally repair the diverging leaves.
+The plain state is only kept for the latest block, so only the SMT of that block can be checked.`,
+	Example: "go run ./cmd/integration verify-smt --datadir=/datadirs/hermez-mainnet --repair",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, _ := common.RootContext()
+		db := openDB(dbCfg(kv.ChainDB, chaindata), true)
+		defer db.Close()
+
+		if err := verifySmt(ctx, db); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Error(err.Error())
+			}
+			return
+		}
+	},
+}
+
+func init() {
+	withDataDir(cmdVerifySmt)
+	withSmtVerify(cmdVerifySmt)
+
+	rootCmd.AddCommand(cmdVerifySmt)
+}
+
+func verifySmt(ctx context.Context, db kv.RwDB) error {
+	const logPrefix = "verify-smt"
+
+	tx, err := db.BeginRw(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// the plain state is only kept for the latest block, the SMT has to be hashed up to it
+	executed, err := stages.GetStageProgress(tx, stages.Execution)
+	if err != nil {
+		return err
+	}
+	hashed, err := stages.GetStageProgress(tx, stages.IntermediateHashes)
+	if err != nil {
+		return err
+	}
+	if executed != hashed {
+		return fmt.Errorf("the plain state is at block %d but the smt is at block %d, sync or unwind until they match", executed, hashed)
+	}
+
+	result, err := zkStages.VerifySmt(ctx, logPrefix, tx, verifyMaxDivergences)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range result.Divergences {
+		if !d.Resolved {
+			continue
+		}
+		fmt.Printf("%s %s %s expected=%v actual=%v subtree=%s\n", d.Address.Hex(), d.Field(), d.StorageKey.Hex(), d.Expected, d.Actual, d.Subtree)
+	}
+	// leaves without a key source that no account has the key of, a repair removes them
+	for _, d := range result.Divergences {
+		if d.Resolved {
+			continue
+		}
+		fmt.Printf("unresolved key=%x actual=%v subtree=%s\n", d.Key.ToBigInt(), d.Actual, d.Subtree)
+	}
+	log.Info(fmt.Sprintf("[%s] SMT verified", logPrefix), "block", hashed, "root", result.Root.Hex(), "leaves", result.Leaves, "accounts", result.Accounts,
+		"divergences", len(result.Divergences), "unresolved", result.Unresolved(), "truncated", result.Truncated)
+
+	if !verifyRepair || len(result.Divergences) == 0 {
+		return nil
+	}
+
+	root, err := zkStages.RepairSmt(logPrefix, tx, result)
+	if err != nil {
+		return err
+	}
+	header := rawdb.ReadHeaderByNumber(tx, hashed)
+	if header == nil {
+		return fmt.Errorf("no header found for block %d", hashed)
+	}
+	if root != header.Root {
+		return fmt.Errorf("repaired smt root %x does not match the header root %x, run again to find the remaining divergences", root, header.Root)
+	}
+
+	// a root recorded by the smt history for the block is replaced by the repaired one
+	eridb := db2.NewEriDb(tx)
+	recorded, err := eridb.GetRootAt(hashed)
+	if err != nil {
+		return err
+	}
+	if recorded != nil {
+		if err = eridb.SetRootAt(hashed, root.Big()); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("[%s] SMT repaired", logPrefix), "block", hashed, "root", root.Hex())
+	return nil
+}
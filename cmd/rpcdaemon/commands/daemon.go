@@ -12,15 +12,18 @@ import (
 	"github.com/ledgerwatch/erigon/rpc"
 	"github.com/ledgerwatch/erigon/turbo/rpchelper"
 	"github.com/ledgerwatch/erigon/turbo/services"
+	"github.com/ledgerwatch/erigon/zk/events"
+	"github.com/ledgerwatch/erigon/zk/pending"
 	"github.com/ledgerwatch/erigon/zk/sequencer"
 	"github.com/ledgerwatch/erigon/zk/syncer"
+	zktxpool "github.com/ledgerwatch/erigon/zk/txpool"
 )
 
 // APIList describes the list of available RPC apis
 func APIList(db kv.RoDB, borDb kv.RoDB, eth rpchelper.ApiBackend, txPool txpool.TxpoolClient, mining txpool.MiningClient,
 	filters *rpchelper.Filters, stateCache kvcache.Cache,
 	blockReader services.FullBlockReader, agg *libstate.AggregatorV3, cfg httpcfg.HttpCfg, engine consensus.EngineReader,
-	ethCfg *ethconfig.Config, l1Syncer *syncer.L1Syncer,
+	ethCfg *ethconfig.Config, l1Syncer *syncer.L1Syncer, pendingStore *pending.Store, zkTxPool *zktxpool.TxPool, zkTxPoolDB kv.RoDB, zkEvents *events.Events,
 ) (list []rpc.API) {
 
 	// non-sequencer nodes should forward on requests to the sequencer
@@ -32,9 +35,10 @@ func APIList(db kv.RoDB, borDb kv.RoDB, eth rpchelper.ApiBackend, txPool txpool.
 	base := NewBaseApi(filters, stateCache, blockReader, agg, cfg.WithDatadir, cfg.EvmCallTimeout, engine, cfg.Dirs)
 	base.SetL2RpcUrl(ethCfg.L2RpcUrl)
 	base.SetGasless(ethCfg.Gasless)
+	base.SetPendingStore(pendingStore)
 	ethImpl := NewEthAPI(base, db, eth, txPool, mining, cfg.Gascap, cfg.ReturnDataLimit, ethCfg)
 	erigonImpl := NewErigonAPI(base, db, eth)
-	txpoolImpl := NewTxPoolAPI(base, db, txPool, rpcUrl)
+	txpoolImpl := NewTxPoolAPI(base, db, txPool, rpcUrl, zkTxPool, zkTxPoolDB)
 	netImpl := NewNetAPIImpl(eth)
 	debugImpl := NewPrivateDebugAPI(base, db, cfg.Gascap)
 	traceImpl := NewTraceAPI(base, db, &cfg)
@@ -45,7 +49,7 @@ func APIList(db kv.RoDB, borDb kv.RoDB, eth rpchelper.ApiBackend, txPool txpool.
 	borImpl := NewBorAPI(base, db, borDb) // bor (consensus) specific
 	otsImpl := NewOtterscanAPI(base, db)
 	gqlImpl := NewGraphQLAPI(base, db)
-	zkEvmImpl := NewZkEvmAPI(ethImpl, db, cfg.ReturnDataLimit, ethCfg, l1Syncer)
+	zkEvmImpl := NewZkEvmAPI(ethImpl, db, cfg.ReturnDataLimit, ethCfg, l1Syncer, zkTxPool, zkTxPoolDB, zkEvents)
 
 	if cfg.GraphQLEnabled {
 		list = append(list, rpc.API{
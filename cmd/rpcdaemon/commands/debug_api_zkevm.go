@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/memdb"
+
+	"github.com/ledgerwatch/erigon/common/hexutil"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/rpc"
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/smt"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+	"github.com/ledgerwatch/erigon/turbo/rpchelper"
+)
+
+// DumpZkStateMaxResults is the maximum number of SMT leaves returned per call
+const DumpZkStateMaxResults = 1024
+
+// ZkStateDump is a page of the leaves of the SMT at a block, Next is the key to carry on from
+type ZkStateDump struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	StateRoot   common.Hash    `json:"stateRoot"`
+	Leaves      []*ZkStateLeaf `json:"leaves"`
+	Next        *common.Hash   `json:"next,omitempty"`
+}
+
+// ZkStateLeaf is an SMT leaf and the account field it holds, the address is left out when the node doesn't know which
+// account the key belongs to
+type ZkStateLeaf struct {
+	Key        common.Hash     `json:"key"`
+	Address    *common.Address `json:"address,omitempty"`
+	Field      string          `json:"field"`
+	StorageKey *common.Hash    `json:"storageKey,omitempty"`
+	Value      *hexutil.Big    `json:"value"`
+}
+
+// DumpZkState implements debug_dumpZkState.  Returns the leaves of the SMT at the block in the order of their path
+// through the tree, starting at the leaf with the start key.
+func (api *PrivateDebugAPIImpl) DumpZkState(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, start *common.Hash, maxResults int) (*ZkStateDump, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNr, _, _, err := rpchelper.GetCanonicalBlockNumber(blockNrOrHash, tx, api.filters)
+	if err != nil {
+		return nil, err
+	}
+
+	// the smt db wants a read-write tx, nothing is written to the batch
+	batch := memdb.NewMemoryBatch(tx, api.dirs.Tmp)
+	defer batch.Rollback()
+	dbSmt := smt.NewSMT(db2.NewEriDb(batch))
+
+	hashed, err := stages.GetStageProgress(tx, stages.IntermediateHashes)
+	if err != nil {
+		return nil, err
+	}
+	root, err := dbSmt.RootAtOrLast(blockNr, hashed)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxResults > DumpZkStateMaxResults || maxResults <= 0 {
+		maxResults = DumpZkStateMaxResults
+	}
+
+	var startKey *utils.NodeKey
+	if start != nil {
+		key := utils.ScalarToRoot(start.Big())
+		startKey = &key
+	}
+
+	result := &ZkStateDump{
+		BlockNumber: hexutil.Uint64(blockNr),
+		StateRoot:   nodeKeyToHash(root),
+		Leaves:      make([]*ZkStateLeaf, 0, maxResults),
+	}
+	err = dbSmt.IterateState(ctx, root, startKey, func(leaf *smt.StateLeaf) (bool, error) {
+		if len(result.Leaves) == maxResults {
+			next := nodeKeyToHash(leaf.Key)
+			result.Next = &next
+			return false, nil
+		}
+		result.Leaves = append(result.Leaves, zkStateLeaf(leaf))
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func zkStateLeaf(leaf *smt.StateLeaf) *ZkStateLeaf {
+	result := &ZkStateLeaf{
+		Key:   nodeKeyToHash(leaf.Key),
+		Field: leaf.Field(),
+		Value: (*hexutil.Big)(leaf.Value),
+	}
+	if leaf.Resolved {
+		addr := leaf.Address
+		result.Address = &addr
+		if leaf.Type == utils.SC_STORAGE {
+			storageKey := leaf.StorageKey
+			result.StorageKey = &storageKey
+		}
+	}
+	return result
+}
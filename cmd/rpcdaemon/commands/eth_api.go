@@ -24,7 +24,11 @@ import (
 	types2 "github.com/gateway-fm/cdk-erigon-lib/types"
 
 	"github.com/ledgerwatch/erigon/chain"
+	"github.com/ledgerwatch/erigon/zk/acl"
+	"github.com/ledgerwatch/erigon/zk/gasless"
 	"github.com/ledgerwatch/erigon/zk/hermez_db"
+	"github.com/ledgerwatch/erigon/zk/pending"
+	"github.com/ledgerwatch/erigon/zk/ratelimit"
 	"github.com/ledgerwatch/erigon/zk/utils"
 
 	"github.com/ledgerwatch/erigon/common/hexutil"
@@ -90,7 +94,7 @@ type EthAPI interface {
 	Syncing(ctx context.Context) (interface{}, error)
 	ChainId(ctx context.Context) (hexutil.Uint64, error) /* called eth_protocolVersion elsewhere */
 	ProtocolVersion(_ context.Context) (hexutil.Uint, error)
-	GasPrice(_ context.Context) (*hexutil.Big, error)
+	GasPrice(_ context.Context, argsOrNil *ethapi2.CallArgs) (*hexutil.Big, error)
 
 	// Sending related (see ./eth_call.go)
 	Call(ctx context.Context, args ethapi2.CallArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *ethapi2.StateOverrides) (hexutility.Bytes, error)
@@ -129,6 +133,7 @@ type BaseAPI struct {
 	dirs           datadir.Dirs
 	l2RpcUrl       string
 	gasless        bool
+	pendingStore   *pending.Store
 }
 
 func NewBaseApi(f *rpchelper.Filters, stateCache kvcache.Cache, blockReader services.FullBlockReader, agg *libstate.AggregatorV3, singleNodeMode bool, evmCallTimeout time.Duration, engine consensus.EngineReader, dirs datadir.Dirs) *BaseAPI {
@@ -352,6 +357,11 @@ type APIImpl struct {
 	MaxGasPrice                uint64
 	GasPriceFactor             float64
 	L1GasPrice                 L1GasPrice
+	PreValidateTxs             bool
+	SimulateTxs                bool
+	senderRateLimiter          *ratelimit.Limiter[common.Address]
+	senderACL                  *acl.ACL
+	GaslessPolicy              *gasless.Policy
 }
 
 // NewEthAPI returns APIImpl instance
@@ -359,7 +369,6 @@ func NewEthAPI(base *BaseAPI, db kv.RoDB, eth rpchelper.ApiBackend, txPool txpoo
 	if gascap == 0 {
 		gascap = uint64(math.MaxUint64 / 2)
 	}
-
 	return &APIImpl{
 		BaseAPI:                    base,
 		db:                         db,
@@ -378,6 +387,11 @@ func NewEthAPI(base *BaseAPI, db kv.RoDB, eth rpchelper.ApiBackend, txPool txpoo
 		MaxGasPrice:                ethCfg.MaxGasPrice,
 		GasPriceFactor:             ethCfg.GasPriceFactor,
 		L1GasPrice:                 L1GasPrice{},
+		PreValidateTxs:             ethCfg.RpcTxPreValidation,
+		SimulateTxs:                ethCfg.RpcTxSimulation,
+		senderRateLimiter:          ethCfg.RpcTxSenderRateLimiter,
+		senderACL:                  ethCfg.RpcTxACL,
+		GaslessPolicy:              ethCfg.GaslessPolicy,
 	}
 }
 
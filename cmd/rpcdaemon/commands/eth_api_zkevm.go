@@ -1,5 +1,7 @@
 package commands
 
+import "github.com/ledgerwatch/erigon/zk/pending"
+
 func (api *BaseAPI) SetL2RpcUrl(url string) {
 	api.l2RpcUrl = url
 }
@@ -10,3 +12,7 @@ func (api *BaseAPI) GetL2RpcUrl() string {
 	}
 	return api.l2RpcUrl
 }
+
+func (api *BaseAPI) SetPendingStore(store *pending.Store) {
+	api.pendingStore = store
+}
@@ -396,6 +396,14 @@ func (api *APIImpl) blockByNumber(ctx context.Context, number rpc.BlockNumber, t
 		return api.blockByRPCNumber(number, tx)
 	}
 
+	zkBlock, err := api.zkPendingBlock(tx)
+	if err != nil {
+		return nil, err
+	}
+	if zkBlock != nil {
+		return zkBlock, nil
+	}
+
 	if block := api.pendingBlock(); block != nil {
 		return block, nil
 	}
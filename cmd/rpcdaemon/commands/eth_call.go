@@ -55,6 +55,17 @@ func (api *APIImpl) Call(ctx context.Context, args ethapi2.CallArgs, blockNrOrHa
 		args.Gas = (*hexutil.Uint64)(&api.GasCap)
 	}
 
+	// pre-confirmed transactions from the sequencer are applied on top of the latest state
+	if isPendingTag(blockNrOrHash) {
+		pendingBlocks, err := api.zkPendingBlocks(tx)
+		if err != nil {
+			return nil, err
+		}
+		if len(pendingBlocks) > 0 {
+			return api.callPending(ctx, tx, engine, chainConfig, args, pendingBlocks, overrides)
+		}
+	}
+
 	blockNumber, hash, _, err := rpchelper.GetCanonicalBlockNumber(blockNrOrHash, tx, api.filters) // DoCall cannot be executed on non-canonical blocks
 	if err != nil {
 		return nil, err
@@ -137,6 +148,12 @@ func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs
 		args.From = new(libcommon.Address)
 	}
 
+	// A call sponsored by the gasless policy is estimated at a zero price, the sender doesn't need funds for the gas
+	sponsoredBy := api.sponsoredBy(&args)
+	if sponsoredBy != nil {
+		args.GasPrice, args.MaxFeePerGas, args.MaxPriorityFeePerGas = nil, nil, nil
+	}
+
 	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
 	if blockNrOrHash != nil {
 		bNrOrHash = *blockNrOrHash
@@ -218,6 +235,10 @@ func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs
 		log.Warn("Caller gas above allowance, capping", "requested", hi, "cap", api.GasCap)
 		hi = api.GasCap
 	}
+	// Recap with the daily quota of the rule sponsoring the call, the pool won't accept more
+	if sponsoredBy != nil && sponsoredBy.DailyGasQuota > 0 && hi > sponsoredBy.DailyGasQuota {
+		hi = sponsoredBy.DailyGasQuota
+	}
 	gasCap = hi
 
 	chainConfig, err := api.chainConfig(dbtx)
@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/hexutility"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/holiman/uint256"
+
+	"github.com/ledgerwatch/erigon/chain"
+	"github.com/ledgerwatch/erigon/consensus"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/core/vm/evmtypes"
+	"github.com/ledgerwatch/erigon/rpc"
+	ethapi2 "github.com/ledgerwatch/erigon/turbo/adapter/ethapi"
+	"github.com/ledgerwatch/erigon/turbo/rpchelper"
+	"github.com/ledgerwatch/erigon/turbo/transactions"
+	"github.com/ledgerwatch/erigon/zk/pending"
+)
+
+// zkPendingBlocks returns the pre-confirmed blocks from the sequencer that are ahead of the local chain
+func (api *BaseAPI) zkPendingBlocks(tx kv.Tx) ([]*pending.Block, error) {
+	if api.pendingStore == nil {
+		return nil, nil
+	}
+	latest, err := rpchelper.GetLatestBlockNumber(tx)
+	if err != nil {
+		return nil, err
+	}
+	return api.pendingStore.Blocks(latest), nil
+}
+
+func (api *BaseAPI) zkPendingBlock(tx kv.Tx) (*types.Block, error) {
+	blocks, err := api.zkPendingBlocks(tx)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+	return blocks[len(blocks)-1].ToBlock(), nil
+}
+
+// pendingTransactionReceipt returns the receipt of a transaction the sequencer has executed but not yet streamed
+func (api *APIImpl) pendingTransactionReceipt(tx kv.Tx, cc *chain.Config, txnHash common.Hash) (map[string]interface{}, error) {
+	if api.pendingStore == nil {
+		return nil, nil
+	}
+	latest, err := rpchelper.GetLatestBlockNumber(tx)
+	if err != nil {
+		return nil, err
+	}
+	block, idx, ok := api.pendingStore.Transaction(txnHash, latest)
+	if !ok {
+		return nil, nil
+	}
+
+	// the same hash the block has in eth_getBlockByNumber("pending"), it is not final while the block is still open
+	blockHash := block.ToBlock().Hash()
+	receipt := *block.Receipts[idx]
+	receipt.BlockHash = blockHash
+	receipt.Logs = make([]*types.Log, len(block.Receipts[idx].Logs))
+	for i, l := range block.Receipts[idx].Logs {
+		lc := *l
+		lc.BlockHash = blockHash
+		receipt.Logs[i] = &lc
+	}
+
+	txn := block.Transactions[idx]
+	fields := marshalReceipt(&receipt, txn, cc, block.Header, txnHash, true)
+	if idx < len(block.EffectiveGasPricePercentages) {
+		fields["effectiveGasPrice"] = core.CalculateEffectiveGas(txn.GetPrice(), block.EffectiveGasPricePercentages[idx])
+	}
+	return fields, nil
+}
+
+// callPending executes the call on top of the latest state with the pre-confirmed transactions applied
+func (api *APIImpl) callPending(ctx context.Context, tx kv.Tx, engine consensus.EngineReader, chainConfig *chain.Config, args ethapi2.CallArgs, blocks []*pending.Block, overrides *ethapi2.StateOverrides) (hexutility.Bytes, error) {
+	stateReader, err := rpchelper.CreateStateReader(ctx, tx, latestNumOrHash, 0, api.filters, api.stateCache, api.historyV3(tx), chainConfig.ChainName)
+	if err != nil {
+		return nil, err
+	}
+	ibs := state.New(stateReader)
+
+	var cancel context.CancelFunc
+	if api.evmCallTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, api.evmCallTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	getHash := transactions.MakeHeaderGetter(true, tx, api._blockReader)
+	var blockCtx evmtypes.BlockContext
+	for _, block := range blocks {
+		header := block.Header
+		blockCtx = core.NewEVMBlockContext(header, getHash, engine, nil, nil)
+		signer := types.MakeSigner(chainConfig, header.Number.Uint64())
+		rules := chainConfig.Rules(header.Number.Uint64(), header.Time)
+
+		for idx, txn := range block.Transactions {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("execution aborted (timeout = %v)", api.evmCallTimeout)
+			}
+			ibs.Prepare(txn.Hash(), common.Hash{}, idx)
+			msg, err := txn.AsMessage(*signer, header.BaseFee, rules)
+			if err != nil {
+				return nil, err
+			}
+			if idx < len(block.EffectiveGasPricePercentages) {
+				msg.SetEffectiveGasPricePercentage(block.EffectiveGasPricePercentages[idx])
+			}
+			evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), ibs, chainConfig, vm.Config{})
+			gp := new(core.GasPool).AddGas(msg.Gas())
+			if _, err = core.ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */); err != nil {
+				return nil, fmt.Errorf("replay pending transaction %s: %w", txn.Hash(), err)
+			}
+			if err = ibs.FinalizeTx(rules, state.NewNoopWriter()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if overrides != nil {
+		if err = overrides.Override(ibs); err != nil {
+			return nil, err
+		}
+	}
+
+	var baseFee *uint256.Int
+	if header := blocks[len(blocks)-1].Header; header.BaseFee != nil {
+		var overflow bool
+		baseFee, overflow = uint256.FromBig(header.BaseFee)
+		if overflow {
+			return nil, fmt.Errorf("header.BaseFee uint256 overflow")
+		}
+	}
+	msg, err := args.ToMessage(api.GasCap, baseFee)
+	if err != nil {
+		return nil, err
+	}
+
+	evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), ibs, chainConfig, vm.Config{NoBaseFee: true})
+	go func() {
+		<-ctx.Done()
+		evm.Cancel()
+	}()
+
+	gp := new(core.GasPool).AddGas(msg.Gas())
+	result, err := core.ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */)
+	if err != nil {
+		return nil, err
+	}
+	if evm.Cancelled() {
+		return nil, fmt.Errorf("execution aborted (timeout = %v)", api.evmCallTimeout)
+	}
+
+	if len(result.ReturnData) > api.ReturnDataLimit {
+		return nil, fmt.Errorf("call returned result on length %d exceeding --rpc.returndata.limit %d", len(result.ReturnData), api.ReturnDataLimit)
+	}
+	if len(result.Revert()) > 0 {
+		return nil, ethapi2.NewRevertError(result)
+	}
+	return result.Return(), result.Err
+}
+
+// isPendingTag reports whether the block parameter asks for the pending state
+func isPendingTag(blockNrOrHash rpc.BlockNumberOrHash) bool {
+	return blockNrOrHash.BlockNumber != nil && *blockNrOrHash.BlockNumber == rpc.PendingBlockNumber
+}
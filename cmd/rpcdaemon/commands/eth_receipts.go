@@ -613,7 +613,7 @@ func (api *APIImpl) GetTransactionReceipt(ctx context.Context, txnHash common.Ha
 	}
 
 	if !ok && cc.Bor == nil {
-		return nil, nil
+		return api.pendingTransactionReceipt(tx, cc, txnHash)
 	}
 
 	// if not ok and cc.Bor != nil then we might have a bor transaction.
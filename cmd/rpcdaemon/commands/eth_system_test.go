@@ -51,7 +51,7 @@ func TestGasPrice(t *testing.T) {
 			eth := NewEthAPI(base, m.DB, nil, nil, nil, 5000000, 100_000, ethconfig.DefaultZkConfig)
 
 			ctx := context.Background()
-			result, err := eth.GasPrice(ctx)
+			result, err := eth.GasPrice(ctx, nil)
 			if err != nil {
 				t.Fatalf("error getting gas price: %s", err)
 			}
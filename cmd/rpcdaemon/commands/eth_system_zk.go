@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/ledgerwatch/erigon/common/hexutil"
+	ethapi2 "github.com/ledgerwatch/erigon/turbo/adapter/ethapi"
+	"github.com/ledgerwatch/erigon/zk/gasless"
 	"github.com/ledgerwatch/erigon/zkevm/encoding"
 	"github.com/ledgerwatch/erigon/zkevm/jsonrpc/client"
 	"github.com/ledgerwatch/log/v3"
@@ -19,8 +21,10 @@ type L1GasPrice struct {
 	gasPrice  *big.Int
 }
 
-func (api *APIImpl) GasPrice(ctx context.Context) (*hexutil.Big, error) {
-	if api.BaseAPI.gasless {
+// GasPrice returns the gas price for a new transaction.  When the transaction is passed and the gasless policy
+// sponsors it the price is zero.
+func (api *APIImpl) GasPrice(ctx context.Context, argsOrNil *ethapi2.CallArgs) (*hexutil.Big, error) {
+	if api.BaseAPI.gasless || api.sponsoredBy(argsOrNil) != nil {
 		var price hexutil.Big
 		return &price, nil
 	}
@@ -100,3 +104,18 @@ func (api *APIImpl) l1GasPrice() (*big.Int, error) {
 
 	return price, nil
 }
+
+// sponsoredBy returns the gasless policy rule that sponsors a call, nil when there is none.  The daily quota of the
+// rule is only checked by the pool, the gas already sponsored for the sender isn't known here.
+func (api *APIImpl) sponsoredBy(args *ethapi2.CallArgs) *gasless.Rule {
+	if args == nil || args.From == nil || !api.GaslessPolicy.Enabled() {
+		return nil
+	}
+	var data []byte
+	if args.Input != nil {
+		data = *args.Input
+	} else if args.Data != nil {
+		data = *args.Data
+	}
+	return api.GaslessPolicy.Match(*args.From, args.To, data)
+}
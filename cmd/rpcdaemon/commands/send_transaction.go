@@ -35,6 +35,10 @@ func (api *APIImpl) SendRawTransaction(ctx context.Context, encodedTx hexutility
 
 	// [zkevm] - proxy the request if the chainID is ZK and not a sequencer
 	if api.isZkNonSequencer(chainId) {
+		if err := api.preValidateTxZk(ctx, tx, cc, encodedTx); err != nil {
+			return common.Hash{}, err
+		}
+
 		// [zkevm] - proxy the request to the pool manager if the pool manager is set
 		if api.isPoolManagerAddressSet() {
 			return api.sendTxZk(api.PoolManagerUrl, encodedTx, chainId.Uint64())
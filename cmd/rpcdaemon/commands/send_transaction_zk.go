@@ -1,14 +1,35 @@
 package commands
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
-	"strings"
-
 	"math/big"
+	"strings"
+	"time"
 
 	"github.com/gateway-fm/cdk-erigon-lib/common"
 	"github.com/gateway-fm/cdk-erigon-lib/common/hexutility"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/memdb"
+
+	"github.com/ledgerwatch/erigon/chain"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/eth/ethconfig"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/rpc"
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/smt"
+	"github.com/ledgerwatch/erigon/turbo/rpchelper"
+	"github.com/ledgerwatch/erigon/zk/hermez_db"
 	"github.com/ledgerwatch/erigon/zk/sequencer"
+	zktx "github.com/ledgerwatch/erigon/zk/tx"
+	zktxpool "github.com/ledgerwatch/erigon/zk/txpool"
 	"github.com/ledgerwatch/erigon/zk/zkchainconfig"
 	"github.com/ledgerwatch/erigon/zkevm/jsonrpc/client"
 )
@@ -37,3 +58,139 @@ func (api *APIImpl) sendTxZk(rpcUrl string, encodedTx hexutility.Bytes, chainId
 
 	return common.HexToHash(hashHex), nil
 }
+
+// preValidateTxZk runs the checks of the pool on a transaction before it is forwarded to the sequencer so the rpc
+// node can reject it straight away, nothing is checked unless pre-validation, the sender acl or the sender rate limit
+// is enabled
+func (api *APIImpl) preValidateTxZk(ctx context.Context, tx kv.Tx, cc *chain.Config, encodedTx hexutility.Bytes) error {
+	if !api.PreValidateTxs && api.senderRateLimiter == nil && api.senderACL == nil {
+		return nil
+	}
+
+	txn, err := types.DecodeTransaction(rlp.NewStream(bytes.NewReader(encodedTx), uint64(len(encodedTx))))
+	if err != nil {
+		return err
+	}
+
+	if api.PreValidateTxs {
+		if err := checkTxFee(txn.GetPrice().ToBig(), txn.GetGas(), ethconfig.Defaults.RPCTxFeeCap); err != nil {
+			return err
+		}
+		if !api.AllowPreEIP155Transactions && !txn.Protected() {
+			return errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+		}
+		if txn.Protected() && cc.ChainID.Cmp(txn.GetChainID().ToBig()) != 0 {
+			return fmt.Errorf("invalid chain id, expected: %d got: %d", cc.ChainID, txn.GetChainID())
+		}
+	}
+
+	from, err := txn.Sender(*types.LatestSignerForChainID(cc.ChainID))
+	if err != nil {
+		return err
+	}
+	if !api.senderACL.AllowsSender(from) {
+		return fmt.Errorf("sender not allowed: %s", from)
+	}
+	if !api.senderRateLimiter.Allow(from) {
+		return fmt.Errorf("too many transactions from %s, limit is %d per second", from, api.senderRateLimiter.PerSec())
+	}
+
+	if !api.PreValidateTxs {
+		return nil
+	}
+	header := rawdb.ReadCurrentHeader(tx)
+	if header == nil {
+		return errors.New("current header not found")
+	}
+	reason, err := api.validateTxZk(ctx, tx, cc, header, txn, from)
+	if err != nil {
+		return err
+	}
+	if reason != zktxpool.Success {
+		return errors.New(reason.String())
+	}
+
+	if api.SimulateTxs {
+		return api.simulateTxZk(ctx, tx, cc, header, txn)
+	}
+	return nil
+}
+
+// validateTxZk mirrors TxPool.validateTx with the state of the rpc node
+func (api *APIImpl) validateTxZk(ctx context.Context, tx kv.Tx, cc *chain.Config, header *types.Header, txn types.Transaction, from common.Address) (zktxpool.DiscardReason, error) {
+	if reason := zktxpool.ValidateTxStateless(txn, cc.IsShanghai(header.Time), cc.IsLondon(header.Number.Uint64())); reason != zktxpool.Success {
+		return reason, nil
+	}
+
+	stateReader, err := rpchelper.CreateStateReader(ctx, tx, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), 0, api.filters, api.stateCache, api.historyV3(tx), cc.ChainName)
+	if err != nil {
+		return zktxpool.Success, fmt.Errorf("pre-validation could not read the state: %w", err)
+	}
+	ibs := state.New(stateReader)
+	return zktxpool.ValidateTxFunds(txn, ibs.GetNonce(from), ibs.GetBalance(from)), nil
+}
+
+// simulateTxZk executes the transaction on top of the latest block and checks it fits in the counters of a batch on
+// its own.  A transaction that reverts is still valid so only errors applying it are returned.
+func (api *APIImpl) simulateTxZk(ctx context.Context, tx kv.Tx, cc *chain.Config, parent *types.Header, txn types.Transaction) error {
+	stateReader, err := rpchelper.CreateStateReader(ctx, tx, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), 0, api.filters, api.stateCache, api.historyV3(tx), cc.ChainName)
+	if err != nil {
+		return err
+	}
+	ibs := state.New(stateReader)
+
+	hermezDb := hermez_db.NewHermezDbReader(tx)
+	batchNo, err := hermezDb.GetBatchNoByL2Block(parent.Number.Uint64())
+	if err != nil {
+		return err
+	}
+	forkId, err := hermezDb.GetForkId(batchNo)
+	if err != nil {
+		return err
+	}
+
+	// the smt db wants a read-write tx, nothing is written to the batch
+	batch := memdb.NewMemoryBatch(tx, api.dirs.Tmp)
+	defer batch.Rollback()
+	smtDepth := smt.NewSMT(db2.NewEriDb(batch)).GetDepth()
+
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Coinbase:   parent.Coinbase,
+		Difficulty: new(big.Int),
+		Number:     new(big.Int).Add(parent.Number, big.NewInt(1)),
+		GasLimit:   parent.GasLimit,
+		Time:       uint64(time.Now().Unix()),
+	}
+	getHeader := func(hash common.Hash, number uint64) *types.Header { return rawdb.ReadHeader(tx, hash, number) }
+
+	ibs.Prepare(txn.Hash(), common.Hash{}, 0)
+	_, _, txCounters, err := core.ApplyTransactionWithCounters_zkevm(
+		cc,
+		core.GetHashFn(header, getHeader),
+		api.engine(),
+		&header.Coinbase,
+		ibs,
+		header,
+		txn,
+		vm.NewZkConfig(vm.Config{}, nil),
+		parent.ExcessDataGas,
+		zktx.MaxEffectivePercentage,
+		smtDepth)
+	if err != nil {
+		return err
+	}
+
+	batchCounters := vm.NewBatchCounterCollector(smtDepth, uint16(forkId))
+	if _, err = batchCounters.StartNewBlock(); err != nil {
+		return err
+	}
+	overflow, err := batchCounters.AddNewTransactionCounters(txCounters)
+	if err != nil {
+		return err
+	}
+	if overflow {
+		return errors.New(zktxpool.OverflowZkCounters.String())
+	}
+	return nil
+}
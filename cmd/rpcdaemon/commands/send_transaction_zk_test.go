@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/datadir"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/kvcache"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/memdb"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/consensus/ethash"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/eth/ethconfig"
+	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/rpc/rpccfg"
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/zk/acl"
+	"github.com/ledgerwatch/erigon/zk/hermez_db"
+	"github.com/ledgerwatch/erigon/zk/ratelimit"
+	zktxpool "github.com/ledgerwatch/erigon/zk/txpool"
+)
+
+var revertingContract = common.HexToAddress("0xcc")
+
+type preValidateFixture struct {
+	t    *testing.T
+	db   kv.RwDB
+	api  *APIImpl
+	key  *ecdsa.PrivateKey
+	from common.Address
+}
+
+// newPreValidateFixture has a chain at genesis where the sender has a balance and has already sent a transaction,
+// and a contract that always reverts
+func newPreValidateFixture(t *testing.T, zkCfg *ethconfig.Zk) *preValidateFixture {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	db := memdb.NewTestDB(t)
+	_, _, err = core.CommitGenesisBlock(db, &types.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			from:              {Balance: big.NewInt(params.Ether), Nonce: 1},
+			revertingContract: {Balance: new(big.Int), Code: common.FromHex("0x60006000fd")}, // revert(0, 0)
+		},
+	}, t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		if err := hermez_db.CreateHermezBuckets(tx); err != nil {
+			return err
+		}
+		if err := db2.CreateEriDbBuckets(tx); err != nil {
+			return err
+		}
+		hermezDb := hermez_db.NewHermezDb(tx)
+		if err := hermezDb.WriteBlockBatch(0, 0); err != nil {
+			return err
+		}
+		return hermezDb.WriteForkId(0, 7)
+	}))
+
+	base := NewBaseApi(nil, kvcache.NewDummy(), nil, nil, false, rpccfg.DefaultEvmCallTimeout, ethash.NewFaker(), datadir.New(t.TempDir()))
+	return &preValidateFixture{t: t, db: db, api: NewEthAPI(base, db, nil, nil, nil, 5000000, 100_000, &ethconfig.Config{Zk: zkCfg}), key: key, from: from}
+}
+
+func (f *preValidateFixture) sign(txn types.Transaction) []byte {
+	signed, err := types.SignTx(txn, *types.LatestSignerForChainID(params.TestChainConfig.ChainID), f.key)
+	require.NoError(f.t, err)
+	encoded, err := types.MarshalTransactionsBinary(types.Transactions{signed})
+	require.NoError(f.t, err)
+	return encoded[0]
+}
+
+func (f *preValidateFixture) preValidate(encodedTx []byte) error {
+	var err error
+	require.NoError(f.t, f.db.View(context.Background(), func(tx kv.Tx) error {
+		err = f.api.preValidateTxZk(context.Background(), tx, params.TestChainConfig, encodedTx)
+		return nil
+	}))
+	return err
+}
+
+func transfer(nonce uint64, gas, gasPrice uint64) types.Transaction {
+	return types.NewTransaction(nonce, common.HexToAddress("0xaa"), uint256.NewInt(1), gas, uint256.NewInt(gasPrice), nil)
+}
+
+func TestPreValidateTxZk_Disabled(t *testing.T) {
+	f := newPreValidateFixture(t, &ethconfig.Zk{})
+
+	// none of the checks run when the flags are off, the sequencer has the last word
+	require.NoError(t, f.preValidate([]byte{0x01, 0x02}))
+	require.NoError(t, f.preValidate(f.sign(transfer(0, 21_000, 1e9))))
+	unprotected, err := types.SignTx(transfer(1, 21_000, 1e9), *types.MakeFrontierSigner(), f.key)
+	require.NoError(t, err)
+	encoded, err := types.MarshalTransactionsBinary(types.Transactions{unprotected})
+	require.NoError(t, err)
+	require.NoError(t, f.preValidate(encoded[0]))
+}
+
+func TestPreValidateTxZk(t *testing.T) {
+	f := newPreValidateFixture(t, &ethconfig.Zk{RpcTxPreValidation: true})
+
+	otherChain, err := types.SignTx(transfer(1, 21_000, 1e9), *types.LatestSignerForChainID(big.NewInt(1234)), f.key)
+	require.NoError(t, err)
+	encodedOtherChain, err := types.MarshalTransactionsBinary(types.Transactions{otherChain})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		tx   []byte
+		err  string
+	}{
+		{"valid", f.sign(transfer(1, 21_000, 1e9)), ""},
+		{"other chain", encodedOtherChain[0], "invalid chain id"},
+		{"intrinsic gas", f.sign(transfer(1, 20_000, 1e9)), zktxpool.IntrinsicGas.String()},
+		{"nonce too low", f.sign(transfer(0, 21_000, 1e9)), zktxpool.NonceTooLow.String()},
+		{"insufficient funds", f.sign(transfer(1, 1_000_000, 1e12)), zktxpool.InsufficientFunds.String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := f.preValidate(tt.tx)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+
+	unprotected, err := types.SignTx(transfer(1, 21_000, 1e9), *types.MakeFrontierSigner(), f.key)
+	require.NoError(t, err)
+	encoded, err := types.MarshalTransactionsBinary(types.Transactions{unprotected})
+	require.NoError(t, err)
+	require.ErrorContains(t, f.preValidate(encoded[0]), "EIP-155")
+	f.api.AllowPreEIP155Transactions = true
+	require.NoError(t, f.preValidate(encoded[0]))
+}
+
+func TestPreValidateTxZk_NoCurrentHeader(t *testing.T) {
+	f := newPreValidateFixture(t, &ethconfig.Zk{RpcTxPreValidation: true})
+	require.NoError(t, f.db.Update(context.Background(), func(tx kv.RwTx) error {
+		return tx.Delete(kv.HeadHeaderKey, []byte(kv.HeadHeaderKey))
+	}))
+	require.ErrorContains(t, f.preValidate(f.sign(transfer(1, 21_000, 1e9))), "current header not found")
+}
+
+func TestSimulateTxZk(t *testing.T) {
+	f := newPreValidateFixture(t, &ethconfig.Zk{RpcTxPreValidation: true, RpcTxSimulation: true})
+
+	require.NoError(t, f.preValidate(f.sign(transfer(1, 21_000, 1e9))))
+
+	// a transaction that reverts is still sequenced
+	revert := types.NewTransaction(1, revertingContract, uint256.NewInt(0), 100_000, uint256.NewInt(1e9), nil)
+	require.NoError(t, f.preValidate(f.sign(revert)))
+
+	// one that can't be applied isn't
+	require.ErrorContains(t, f.preValidate(f.sign(transfer(2, 21_000, 1e9))), "nonce too high")
+}
+
+func TestPreValidateTxZk_RateLimitOnly(t *testing.T) {
+	limiter, err := ratelimit.New[common.Address](1, 10)
+	require.NoError(t, err)
+	f := newPreValidateFixture(t, &ethconfig.Zk{RpcTxSenderRateLimiter: limiter})
+
+	// only the rate limit applies, a transaction the pre-validation would reject gets through
+	require.NoError(t, f.preValidate(f.sign(transfer(0, 21_000, 1e9))))
+	require.ErrorContains(t, f.preValidate(f.sign(transfer(1, 21_000, 1e9))), "too many transactions from "+f.from.String())
+}
+
+func TestPreValidateTxZk_ACL(t *testing.T) {
+	denied, err := acl.Parse([]byte(`{"deniedSenders": ["0x00000000000000000000000000000000000000bb"]}`))
+	require.NoError(t, err)
+	f := newPreValidateFixture(t, &ethconfig.Zk{RpcTxACL: denied})
+	require.NoError(t, f.preValidate(f.sign(transfer(1, 21_000, 1e9))))
+
+	allowed, err := acl.Parse([]byte(`{"allowedSenders": ["0x00000000000000000000000000000000000000bb"]}`))
+	require.NoError(t, err)
+	f = newPreValidateFixture(t, &ethconfig.Zk{RpcTxACL: allowed})
+	require.ErrorContains(t, f.preValidate(f.sign(transfer(1, 21_000, 1e9))), "sender not allowed: "+f.from.String())
+}
@@ -14,12 +14,15 @@ import (
 	"github.com/ledgerwatch/erigon/core/rawdb"
 	"github.com/ledgerwatch/erigon/core/types"
 	"github.com/ledgerwatch/erigon/rlp"
+	zktxpool "github.com/ledgerwatch/erigon/zk/txpool"
 	"github.com/ledgerwatch/erigon/zkevm/jsonrpc/client"
 )
 
 // NetAPI the interface for the net_ RPC commands
 type TxPoolAPI interface {
 	Content(ctx context.Context) (interface{}, error)
+	SenderUsage(ctx context.Context, address libcommon.Address) (*SenderUsage, error)
+	InspectZk(ctx context.Context, filter *InspectZkFilter) ([]*ZkTxInspection, error)
 }
 
 // TxPoolAPIImpl data structure to store things needed for net_ commands
@@ -28,15 +31,19 @@ type TxPoolAPIImpl struct {
 	pool     proto_txpool.TxpoolClient
 	db       kv.RoDB
 	l2RPCUrl string
+	zkPool   *zktxpool.TxPool
+	zkPoolDB kv.RoDB
 }
 
 // NewTxPoolAPI returns NetAPIImplImpl instance
-func NewTxPoolAPI(base *BaseAPI, db kv.RoDB, pool proto_txpool.TxpoolClient, l2RPCUrl string) *TxPoolAPIImpl {
+func NewTxPoolAPI(base *BaseAPI, db kv.RoDB, pool proto_txpool.TxpoolClient, l2RPCUrl string, zkPool *zktxpool.TxPool, zkPoolDB kv.RoDB) *TxPoolAPIImpl {
 	return &TxPoolAPIImpl{
 		BaseAPI:  base,
 		pool:     pool,
 		db:       db,
 		l2RPCUrl: l2RPCUrl,
+		zkPool:   zkPool,
+		zkPoolDB: zkPoolDB,
 	}
 }
 
@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+
+	"github.com/ledgerwatch/erigon/common/hexutil"
+	zktxpool "github.com/ledgerwatch/erigon/zk/txpool"
+	"github.com/ledgerwatch/erigon/zkevm/jsonrpc/client"
+)
+
+// SenderUsage is how much of the pool a sender takes up, the limits are 0 when they are not set
+type SenderUsage struct {
+	Address  libcommon.Address `json:"address"`
+	Slots    hexutil.Uint64    `json:"slots"`
+	Bytes    hexutil.Uint64    `json:"bytes"`
+	Pending  hexutil.Uint      `json:"pending"`
+	BaseFee  hexutil.Uint      `json:"baseFee"`
+	Queued   hexutil.Uint      `json:"queued"`
+	MaxSlots hexutil.Uint64    `json:"maxSlots"`
+	MaxBytes hexutil.Uint64    `json:"maxBytes"`
+
+	GaslessGasUsed hexutil.Uint64 `json:"gaslessGasUsed"`
+}
+
+// SenderUsage returns the transactions a sender has in the pool against the per sender limits
+func (api *TxPoolAPIImpl) SenderUsage(ctx context.Context, address libcommon.Address) (*SenderUsage, error) {
+	if api.l2RPCUrl != "" {
+		res, err := client.JSONRPCCall(api.l2RPCUrl, "txpool_senderUsage", address)
+		if err != nil {
+			return nil, err
+		}
+		if res.Error != nil {
+			return nil, fmt.Errorf("RPC error response: %s", res.Error.Message)
+		}
+		var usage SenderUsage
+		if err = json.Unmarshal(res.Result, &usage); err != nil {
+			return nil, err
+		}
+		return &usage, nil
+	}
+
+	if api.zkPool == nil {
+		return nil, errors.New("sender usage is not available without the zk txpool")
+	}
+
+	usage := api.zkPool.SenderUsage(address)
+	return &SenderUsage{
+		Address:  address,
+		Slots:    hexutil.Uint64(usage.Slots),
+		Bytes:    hexutil.Uint64(usage.Bytes),
+		Pending:  hexutil.Uint(usage.Pending),
+		BaseFee:  hexutil.Uint(usage.BaseFee),
+		Queued:   hexutil.Uint(usage.Queued),
+		MaxSlots: hexutil.Uint64(usage.MaxSlots),
+		MaxBytes: hexutil.Uint64(usage.MaxBytes),
+
+		GaslessGasUsed: hexutil.Uint64(usage.GaslessGasUsed),
+	}, nil
+}
+
+// InspectZkFilter narrows txpool_inspectZk down to a sender or a transaction
+type InspectZkFilter struct {
+	Sender *libcommon.Address `json:"sender"`
+	Hash   *libcommon.Hash    `json:"hash"`
+}
+
+// ZkTxInspection is what the pool knows about a transaction and why it is not being included
+type ZkTxInspection struct {
+	Hash                        libcommon.Hash    `json:"hash"`
+	From                        libcommon.Address `json:"from"`
+	Nonce                       hexutil.Uint64    `json:"nonce"`
+	SubPool                     string            `json:"subPool"`
+	Local                       bool              `json:"local"`
+	Arrived                     time.Time         `json:"arrived"`
+	FeeCap                      *hexutil.Big      `json:"maxFeePerGas"`
+	Tip                         *hexutil.Big      `json:"maxPriorityFeePerGas"`
+	Gas                         hexutil.Uint64    `json:"gas"`
+	EffectiveGasPricePercentage hexutil.Uint      `json:"effectiveGasPricePercentage"`
+	EstimatedCounters           map[string]int    `json:"estimatedCounters,omitempty"`
+	EstimatedGasUsed            *hexutil.Uint64   `json:"estimatedGasUsed,omitempty"`
+	Yielded                     hexutil.Uint64    `json:"yielded"`
+	Skipped                     hexutil.Uint64    `json:"skipped"`
+	LastSkipReason              string            `json:"lastSkipReason,omitempty"`
+	Blockers                    []string          `json:"blockers"`
+}
+
+// InspectZk returns the transactions in the pool with the zk metadata of the pool and the reasons they are not being
+// included
+func (api *TxPoolAPIImpl) InspectZk(ctx context.Context, filter *InspectZkFilter) ([]*ZkTxInspection, error) {
+	if api.l2RPCUrl != "" {
+		res, err := client.JSONRPCCall(api.l2RPCUrl, "txpool_inspectZk", filter)
+		if err != nil {
+			return nil, err
+		}
+		if res.Error != nil {
+			return nil, fmt.Errorf("RPC error response: %s", res.Error.Message)
+		}
+		var inspections []*ZkTxInspection
+		if err = json.Unmarshal(res.Result, &inspections); err != nil {
+			return nil, err
+		}
+		return inspections, nil
+	}
+
+	if api.zkPool == nil || api.zkPoolDB == nil {
+		return nil, errors.New("pool inspection is not available without the zk txpool")
+	}
+
+	var poolFilter zktxpool.InspectFilter
+	if filter != nil {
+		poolFilter = zktxpool.InspectFilter{Sender: filter.Sender, Hash: filter.Hash}
+	}
+	var inspections []zktxpool.TxInspection
+	if err := api.zkPoolDB.View(ctx, func(tx kv.Tx) (err error) {
+		inspections, err = api.zkPool.Inspect(tx, poolFilter)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	result := make([]*ZkTxInspection, 0, len(inspections))
+	for _, inspection := range inspections {
+		result = append(result, newZkTxInspection(inspection))
+	}
+	return result, nil
+}
+
+func newZkTxInspection(inspection zktxpool.TxInspection) *ZkTxInspection {
+	rpcInspection := &ZkTxInspection{
+		Hash:                        inspection.Hash,
+		From:                        inspection.Sender,
+		Nonce:                       hexutil.Uint64(inspection.Nonce),
+		SubPool:                     inspection.SubPool.String(),
+		Local:                       inspection.IsLocal,
+		Arrived:                     inspection.Arrived,
+		FeeCap:                      (*hexutil.Big)(inspection.FeeCap.ToBig()),
+		Tip:                         (*hexutil.Big)(inspection.Tip.ToBig()),
+		Gas:                         hexutil.Uint64(inspection.Gas),
+		EffectiveGasPricePercentage: hexutil.Uint(inspection.EffectiveGasPricePercentage),
+		Yielded:                     hexutil.Uint64(inspection.Yielded),
+		Skipped:                     hexutil.Uint64(inspection.Skipped),
+		LastSkipReason:              inspection.LastSkipReason,
+		Blockers:                    inspection.Blockers,
+	}
+	if rpcInspection.Blockers == nil {
+		rpcInspection.Blockers = []string{}
+	}
+	if inspection.CounterEstimate != nil {
+		rpcInspection.EstimatedCounters = inspection.CounterEstimate.Counters
+		gasUsed := hexutil.Uint64(inspection.CounterEstimate.GasUsed)
+		rpcInspection.EstimatedGasUsed = &gasUsed
+	}
+	return rpcInspection
+}
@@ -21,14 +21,16 @@ import (
 	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
 	"github.com/ledgerwatch/erigon/rpc"
 	"github.com/ledgerwatch/erigon/turbo/rpchelper"
+	"github.com/ledgerwatch/erigon/zk/events"
 	"github.com/ledgerwatch/erigon/zk/hermez_db"
 	"github.com/ledgerwatch/erigon/zk/legacy_executor_verifier"
+	"github.com/ledgerwatch/erigon/zk/pending"
 	types "github.com/ledgerwatch/erigon/zk/rpcdaemon"
 	"github.com/ledgerwatch/erigon/zk/sequencer"
 	"github.com/ledgerwatch/erigon/zk/syncer"
+	"github.com/ledgerwatch/erigon/zk/txpool"
 	"github.com/ledgerwatch/erigon/zk/witness"
 	"github.com/ledgerwatch/erigon/zkevm/hex"
-	"github.com/ledgerwatch/erigon/zkevm/jsonrpc/client"
 )
 
 var sha3UncleHash = common.HexToHash("0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347")
@@ -44,7 +46,7 @@ type ZkEvmAPI interface {
 	BatchNumber(ctx context.Context) (hexutil.Uint64, error)
 	VirtualBatchNumber(ctx context.Context) (hexutil.Uint64, error)
 	VerifiedBatchNumber(ctx context.Context) (hexutil.Uint64, error)
-	GetBatchByNumber(ctx context.Context, batchNumber rpc.BlockNumber, fullTx *bool) (json.RawMessage, error)
+	GetBatchByNumber(ctx context.Context, batchNumber rpc.BlockNumber, fullTx *bool, l1Data *bool, includeBatchL2Data *bool) (json.RawMessage, error)
 	GetFullBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (types.Block, error)
 	GetFullBlockByHash(ctx context.Context, hash common.Hash, fullTx bool) (types.Block, error)
 	// GetBroadcastURI(ctx context.Context) (string, error)
@@ -54,6 +56,20 @@ type ZkEvmAPI interface {
 	GetProverInput(ctx context.Context, batchNumber uint64, mode *WitnessMode, debug *bool) (*legacy_executor_verifier.RpcPayload, error)
 	GetLatestGlobalExitRoot(ctx context.Context) (common.Hash, error)
 	GetExitRootsByGER(ctx context.Context, globalExitRoot common.Hash) (*ZkExitRoots, error)
+	GetPendingBlocks(ctx context.Context) ([]*pending.RpcBlock, error)
+	GetTransactionLifecycle(ctx context.Context, hash common.Hash) (*TxLifecycle, error)
+	SendBundle(ctx context.Context, args SendBundleArgs) (common.Hash, error)
+	SendPrivateRawTransaction(ctx context.Context, encodedTx hexutility.Bytes) (common.Hash, error)
+	CancelTransaction(ctx context.Context, encodedTx hexutility.Bytes) (*CancelTransactionResult, error)
+	GetProof(ctx context.Context, address common.Address, storageKeys []common.Hash, blockNrOrHash rpc.BlockNumberOrHash) (*SmtProof, error)
+	GetStateDiff(ctx context.Context, fromBlock rpc.BlockNumberOrHash, toBlock rpc.BlockNumberOrHash, start *common.Hash, maxResults int) (*SmtStateDiff, error)
+	GetBlockInfoRoot(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (common.Hash, error)
+	GetBlockInfoTree(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*BlockInfoTreeLeaves, error)
+	GetBlockInfoProof(ctx context.Context, txHash common.Hash, fields []string) (*BlockInfoTxProof, error)
+	BatchClosed(ctx context.Context) (*rpc.Subscription, error)
+	BatchVirtualized(ctx context.Context) (*rpc.Subscription, error)
+	BatchVerified(ctx context.Context) (*rpc.Subscription, error)
+	L1InfoTreeUpdate(ctx context.Context) (*rpc.Subscription, error)
 }
 
 // APIImpl is implementation of the ZkEvmAPI interface based on remote Db access
@@ -64,6 +80,9 @@ type ZkEvmAPIImpl struct {
 	ReturnDataLimit int
 	config          *ethconfig.Config
 	l1Syncer        *syncer.L1Syncer
+	txPool          *txpool.TxPool
+	txPoolDB        kv.RoDB
+	events          *events.Events
 }
 
 // NewEthAPI returns ZkEvmAPIImpl instance
@@ -73,6 +92,9 @@ func NewZkEvmAPI(
 	returnDataLimit int,
 	zkConfig *ethconfig.Config,
 	l1Syncer *syncer.L1Syncer,
+	txPool *txpool.TxPool,
+	txPoolDB kv.RoDB,
+	events *events.Events,
 ) *ZkEvmAPIImpl {
 	return &ZkEvmAPIImpl{
 		ethApi:          base,
@@ -80,6 +102,9 @@ func NewZkEvmAPI(
 		ReturnDataLimit: returnDataLimit,
 		config:          zkConfig,
 		l1Syncer:        l1Syncer,
+		txPool:          txPool,
+		txPoolDB:        txPoolDB,
+		events:          events,
 	}
 }
 
@@ -219,19 +244,6 @@ func (api *ZkEvmAPIImpl) VerifiedBatchNumber(ctx context.Context) (hexutil.Uint6
 	return hexutil.Uint64(highestVerifiedBatchNo), nil
 }
 
-// GetBatchByNumber returns a batch from the current canonical chain. If number is nil, the
-// latest known batch is returned.
-func (api *ZkEvmAPIImpl) GetBatchByNumber(ctx context.Context, batchNumber rpc.BlockNumber, fullTx *bool) (json.RawMessage, error) {
-	res, err := client.JSONRPCCall(api.ethApi.ZkRpcUrl, "zkevm_getBatchByNumber", batchNumber, fullTx)
-	if err != nil {
-		return nil, err
-	}
-
-	return res.Result, nil
-
-	// TODO: implement this when we get to sequencer activities in erigon
-}
-
 // GetFullBlockByNumber returns a full block from the current canonical chain. If number is nil, the
 // latest known block is returned.
 func (api *ZkEvmAPIImpl) GetFullBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (types.Block, error) {
@@ -293,6 +305,26 @@ func (api *ZkEvmAPIImpl) GetExitRootsByGER(ctx context.Context, globalExitRoot c
 	}, nil
 }
 
+// GetPendingBlocks returns the blocks the sequencer has executed but not yet written to the datastream, rpc nodes poll
+// this to serve the pending tag.  It is only served when enabled with zkevm.pending-blocks-api.
+func (api *ZkEvmAPIImpl) GetPendingBlocks(ctx context.Context) ([]*pending.RpcBlock, error) {
+	if api.config == nil || !api.config.PendingBlocksApi {
+		return nil, errors.New("pending blocks are not served by this node, enable them with --zkevm.pending-blocks-api")
+	}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blocks, err := api.ethApi.zkPendingBlocks(tx)
+	if err != nil {
+		return nil, err
+	}
+	return pending.ToRpcBlocks(blocks)
+}
+
 func (api *ZkEvmAPIImpl) populateBlockDetail(
 	tx kv.Tx,
 	ctx context.Context,
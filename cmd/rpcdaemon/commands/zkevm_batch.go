@@ -0,0 +1,337 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+
+	"github.com/ledgerwatch/erigon/rpc"
+	"github.com/ledgerwatch/erigon/zk/constants"
+	"github.com/ledgerwatch/erigon/zk/hermez_db"
+	"github.com/ledgerwatch/erigon/zk/l1_data"
+	types "github.com/ledgerwatch/erigon/zk/rpcdaemon"
+	"github.com/ledgerwatch/erigon/zk/sequencer"
+	zktx "github.com/ledgerwatch/erigon/zk/tx"
+	"github.com/ledgerwatch/erigon/zk/utils"
+	"github.com/ledgerwatch/erigon/zkevm/jsonrpc/client"
+)
+
+// GetBatchByNumber returns a batch from the current canonical chain. If number is nil, the
+// latest known batch is returned.  With l1Data set a sequenced batch also carries what is needed to reconcile it with
+// the L1: the l1 info root and timestamp limit used, whether it was forced and the accumulated input hash chained
+// locally and checked against the rollup contract.  The batchL2Data is only encoded when includeBatchL2Data is set, it
+// means re-encoding every transaction of the batch.
+func (api *ZkEvmAPIImpl) GetBatchByNumber(ctx context.Context, batchNumber rpc.BlockNumber, fullTx *bool, l1Data *bool, includeBatchL2Data *bool) (json.RawMessage, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var batchNo uint64
+	switch batchNumber {
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber:
+		if batchNo, err = getLatestBatchNumber(tx); err != nil {
+			return nil, err
+		}
+	case rpc.EarliestBlockNumber:
+		batchNo = 0
+	default:
+		if batchNumber < 0 {
+			return nil, fmt.Errorf("unsupported batch number %d", batchNumber)
+		}
+		batchNo = uint64(batchNumber)
+	}
+
+	hermezDb := hermez_db.NewHermezDbReader(tx)
+	blockNos, err := hermezDb.GetL2BlockNosByBatch(batchNo)
+	if err != nil {
+		return nil, err
+	}
+	if len(blockNos) == 0 {
+		// rpc nodes can be behind the sequencer so let it answer for batches we don't have yet
+		if !sequencer.IsSequencer() && api.ethApi.ZkRpcUrl != "" {
+			return api.remoteBatchByNumber(batchNumber, fullTx, l1Data, includeBatchL2Data)
+		}
+		return json.Marshal(nil)
+	}
+
+	batch, err := api.buildBatch(ctx, tx, hermezDb, batchNo, blockNos, fullTx != nil && *fullTx)
+	if err != nil {
+		return nil, err
+	}
+
+	if includeBatchL2Data != nil && *includeBatchL2Data {
+		if batch.BatchL2Data, err = api.batchL2Data(ctx, tx, hermezDb, batchNo); err != nil {
+			return nil, err
+		}
+	}
+
+	if l1Data != nil && *l1Data {
+		if err = api.populateBatchL1Data(ctx, tx, hermezDb, batch); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(batch)
+}
+
+func (api *ZkEvmAPIImpl) remoteBatchByNumber(batchNumber rpc.BlockNumber, fullTx *bool, l1Data *bool, includeBatchL2Data *bool) (json.RawMessage, error) {
+	res, err := client.JSONRPCCall(api.ethApi.ZkRpcUrl, "zkevm_getBatchByNumber", batchNumber, fullTx, l1Data, includeBatchL2Data)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error != nil {
+		return nil, fmt.Errorf("RPC error response: %s", res.Error.Message)
+	}
+	return res.Result, nil
+}
+
+func (api *ZkEvmAPIImpl) buildBatch(ctx context.Context, tx kv.Tx, hermezDb *hermez_db.HermezDbReader, batchNo uint64, blockNos []uint64, fullTx bool) (*types.Batch, error) {
+	batch := &types.Batch{
+		Number:       types.ArgUint64(batchNo),
+		Blocks:       []interface{}{},
+		Transactions: []interface{}{},
+	}
+
+	for _, blockNo := range blockNos {
+		block, err := api.ethApi.BaseAPI.blockByNumberWithSenders(tx, blockNo)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			return nil, fmt.Errorf("block %d in batch %d not found", blockNo, batchNo)
+		}
+
+		if fullTx {
+			rpcBlock, err := api.populateBlockDetail(tx, ctx, block, true)
+			if err != nil {
+				return nil, err
+			}
+			batch.Blocks = append(batch.Blocks, rpcBlock)
+			for _, txn := range rpcBlock.Transactions {
+				batch.Transactions = append(batch.Transactions, txn)
+			}
+		} else {
+			batch.Blocks = append(batch.Blocks, block.Hash())
+			for _, txn := range block.Transactions() {
+				batch.Transactions = append(batch.Transactions, txn.Hash())
+			}
+		}
+
+		batch.Coinbase = block.Coinbase()
+		batch.StateRoot = block.Root()
+		batch.Timestamp = types.ArgUint64(block.Time())
+
+		ger, err := hermezDb.GetBlockGlobalExitRoot(blockNo)
+		if err != nil {
+			return nil, err
+		}
+		if ger != (common.Hash{}) {
+			batch.GlobalExitRoot = ger
+		}
+	}
+
+	if batch.GlobalExitRoot != (common.Hash{}) {
+		infoTreeUpdate, err := hermezDb.GetL1InfoTreeUpdateByGer(batch.GlobalExitRoot)
+		if err != nil {
+			return nil, err
+		}
+		if infoTreeUpdate != nil {
+			batch.MainnetExitRoot = infoTreeUpdate.MainnetExitRoot
+			batch.RollupExitRoot = infoTreeUpdate.RollupExitRoot
+		}
+	}
+
+	sequence, err := hermezDb.GetSequenceCoveringBatchNo(batchNo)
+	if err != nil {
+		return nil, err
+	}
+	if sequence != nil {
+		batch.SendSequencesTxHash = &sequence.L1TxHash
+	}
+	verification, err := hermezDb.GetVerificationCoveringBatchNo(batchNo)
+	if err != nil {
+		return nil, err
+	}
+	if verification != nil {
+		batch.VerifyBatchTxHash = &verification.L1TxHash
+	}
+
+	latestBatch, err := getLatestBatchNumber(tx)
+	if err != nil {
+		return nil, err
+	}
+	batch.Closed = batchNo < latestBatch || sequence != nil
+
+	return batch, nil
+}
+
+// batchL2Data encodes the batch as it is posted to the L1
+func (api *ZkEvmAPIImpl) batchL2Data(ctx context.Context, tx kv.Tx, hermezDb *hermez_db.HermezDbReader, batchNo uint64) ([]byte, error) {
+	forkId, err := hermezDb.GetForkId(batchNo)
+	if err != nil {
+		return nil, err
+	}
+	blockNos, err := hermezDb.GetL2BlockNosByBatch(batchNo)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []byte
+	for _, blockNo := range blockNos {
+		block, err := api.ethApi.BaseAPI.blockByNumberWithSenders(tx, blockNo)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			return nil, fmt.Errorf("block %d in batch %d not found", blockNo, batchNo)
+		}
+
+		transactions := block.Transactions()
+		effectivePercentages := make([]uint8, len(transactions))
+		for i, txn := range transactions {
+			if effectivePercentages[i], err = api.ethApi.getEffectiveGasPricePercentage(tx, txn.Hash()); err != nil {
+				return nil, err
+			}
+		}
+
+		// before etrog there are no block boundaries in the batch data
+		if forkId < uint64(constants.ForkID7Etrog) {
+			for i, txn := range transactions {
+				encoded, err := zktx.TransactionToL2Data(txn, uint16(forkId), effectivePercentages[i])
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, encoded...)
+			}
+			continue
+		}
+
+		var parentTime uint64
+		if blockNo > 0 {
+			parent, err := api.ethApi._blockReader.HeaderByNumber(ctx, tx, blockNo-1)
+			if err != nil {
+				return nil, err
+			}
+			if parent == nil {
+				return nil, fmt.Errorf("header %d not found", blockNo-1)
+			}
+			parentTime = parent.Time
+		}
+		l1InfoTreeIndex, err := hermezDb.GetBlockL1InfoTreeIndex(blockNo)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded, err := zktx.GenerateBlockBatchL2Data(uint16(forkId), uint32(block.Time()-parentTime), uint32(l1InfoTreeIndex), transactions, effectivePercentages)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, encoded...)
+	}
+
+	return result, nil
+}
+
+// populateBatchL1Data chains the accumulated input hash over the whole sequence the batch was submitted in, using the
+// batch data encoded locally and the sequence parameters from the L1 transaction, and compares the result with the
+// hash the rollup contract stored for the sequence.
+func (api *ZkEvmAPIImpl) populateBatchL1Data(ctx context.Context, tx kv.Tx, hermezDb *hermez_db.HermezDbReader, batch *types.Batch) error {
+	if api.l1Syncer == nil {
+		return errors.New("l1 data requires a connection to the L1")
+	}
+	batchNo := uint64(batch.Number)
+
+	forkId, err := hermezDb.GetForkId(batchNo)
+	if err != nil {
+		return err
+	}
+	if forkId < uint64(constants.ForkID7Etrog) {
+		return fmt.Errorf("l1 data is only available for batches from fork %d", constants.ForkID7Etrog)
+	}
+
+	sequenceInfo, err := hermezDb.GetSequenceCoveringBatchNo(batchNo)
+	if err != nil {
+		return err
+	}
+	if sequenceInfo == nil {
+		// not sequenced yet so there is nothing on the L1 to reconcile with
+		return nil
+	}
+
+	l1Tx, _, err := api.l1Syncer.GetTransaction(sequenceInfo.L1TxHash)
+	if err != nil {
+		return err
+	}
+	sequence, err := l1_data.DecodeL1Sequence(l1Tx.GetData())
+	if err != nil {
+		return err
+	}
+	timestampLimit := sequence.MaxSequenceTimestamp
+	if sequence.Etrog {
+		// the etrog contract used the time of the L1 block and the sequence follows on from the one before it
+		if uint64(len(sequence.Batches)) > sequenceInfo.BatchNo {
+			return fmt.Errorf("sequence in l1 tx %s has more batches than batch %d", sequenceInfo.L1TxHash, sequenceInfo.BatchNo)
+		}
+		sequence.InitSequencedBatch = sequenceInfo.BatchNo - uint64(len(sequence.Batches))
+		l1Block, err := api.l1Syncer.GetBlock(sequenceInfo.L1BlockNo)
+		if err != nil {
+			return err
+		}
+		timestampLimit = l1Block.Time()
+	}
+	if lastBatch := sequence.InitSequencedBatch + uint64(len(sequence.Batches)); lastBatch != sequenceInfo.BatchNo {
+		return fmt.Errorf("sequence in l1 tx %s ends at batch %d, expected %d", sequenceInfo.L1TxHash, lastBatch, sequenceInfo.BatchNo)
+	}
+
+	accInputHash, err := api.l1Syncer.GetOldAccInputHash(ctx, &api.config.AddressRollup, ApiRollupId, sequence.InitSequencedBatch)
+	if err != nil {
+		return err
+	}
+	l1SequenceAccInputHash, err := api.l1Syncer.GetOldAccInputHash(ctx, &api.config.AddressRollup, ApiRollupId, sequenceInfo.BatchNo)
+	if err != nil {
+		return err
+	}
+
+	for idx, l1Batch := range sequence.Batches {
+		number := sequence.InitSequencedBatch + 1 + uint64(idx)
+
+		batchL2Data := []byte(batch.BatchL2Data)
+		if number != batchNo || batchL2Data == nil {
+			if batchL2Data, err = api.batchL2Data(ctx, tx, hermezDb, number); err != nil {
+				return err
+			}
+		}
+
+		l1InfoRoot, batchTimestampLimit, forced := sequenceInfo.L1InfoRoot, timestampLimit, l1Batch.ForcedTimestamp > 0
+		if forced {
+			l1InfoRoot, batchTimestampLimit = l1Batch.ForcedGlobalExitRoot, l1Batch.ForcedTimestamp
+		}
+
+		oldAccInputHash := accInputHash
+		accInputHash = utils.CalculateEtrogAccInputHash(oldAccInputHash, batchL2Data, l1InfoRoot, batchTimestampLimit, sequence.Coinbase, l1Batch.ForcedBlockHashL1)
+
+		if number == batchNo {
+			timestamp := types.ArgUint64(batchTimestampLimit)
+			dataMatches := bytes.Equal(batchL2Data, l1Batch.Transactions)
+			batch.L1InfoRoot = &l1InfoRoot
+			batch.TimestampLimit = &timestamp
+			batch.Forced = &forced
+			batch.OldAccInputHash = &oldAccInputHash
+			batch.AccInputHash = accInputHash
+			batch.BatchL2DataMatchesL1 = &dataMatches
+		}
+	}
+
+	accInputHashMatches := accInputHash == l1SequenceAccInputHash
+	batch.L1SequenceAccInputHash = &l1SequenceAccInputHash
+	batch.AccInputHashMatchesL1 = &accInputHashMatches
+
+	return nil
+}
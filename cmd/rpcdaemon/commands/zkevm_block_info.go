@@ -0,0 +1,290 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+
+	"github.com/ledgerwatch/erigon/common/hexutil"
+	"github.com/ledgerwatch/erigon/rpc"
+	"github.com/ledgerwatch/erigon/smt/pkg/blockinfo"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+	"github.com/ledgerwatch/erigon/turbo/rpchelper"
+	"github.com/ledgerwatch/erigon/zk/hermez_db"
+	zktypes "github.com/ledgerwatch/erigon/zk/types"
+)
+
+// BlockInfoTreeLeaves holds the leaf values of the block info tree of a block
+type BlockInfoTreeLeaves struct {
+	BlockNumber hexutil.Uint64       `json:"blockNumber"`
+	Root        common.Hash          `json:"root"`
+	ParentHash  common.Hash          `json:"parentHash"`
+	Coinbase    common.Address       `json:"coinbase"`
+	GasLimit    hexutil.Uint64       `json:"gasLimit"`
+	Timestamp   hexutil.Uint64       `json:"timestamp"`
+	Ger         common.Hash          `json:"globalExitRoot"`
+	L1BlockHash common.Hash          `json:"l1BlockHash"`
+	GasUsed     hexutil.Uint64       `json:"gasUsed"`
+	Txs         []*BlockInfoTxLeaves `json:"transactions"`
+}
+
+type BlockInfoTxLeaves struct {
+	Index               hexutil.Uint64      `json:"index"`
+	L2TxHash            common.Hash         `json:"l2TxHash"`
+	Status              hexutil.Uint64      `json:"status"`
+	CumulativeGasUsed   hexutil.Uint64      `json:"cumulativeGasUsed"`
+	EffectivePercentage hexutil.Uint64      `json:"effectivePercentage"`
+	Logs                []*BlockInfoLogLeaf `json:"logs"`
+}
+
+type BlockInfoLogLeaf struct {
+	Index hexutil.Uint64 `json:"index"`
+	Hash  common.Hash    `json:"hash"`
+}
+
+// BlockInfoTxProof holds proofs of the receipt leaves of a transaction against the block info root of its block,
+// fields which weren't asked for are left out
+type BlockInfoTxProof struct {
+	BlockNumber         hexutil.Uint64 `json:"blockNumber"`
+	Root                common.Hash    `json:"root"`
+	TxHash              common.Hash    `json:"txHash"`
+	TxIndex             hexutil.Uint64 `json:"txIndex"`
+	L2TxHash            *SmtKeyProof   `json:"l2TxHash,omitempty"`
+	Status              *SmtKeyProof   `json:"status,omitempty"`
+	CumulativeGasUsed   *SmtKeyProof   `json:"cumulativeGasUsed,omitempty"`
+	EffectivePercentage *SmtKeyProof   `json:"effectivePercentage,omitempty"`
+	Logs                []*SmtKeyProof `json:"logs,omitempty"`
+}
+
+var blockInfoProofFields = map[string]bool{
+	"l2TxHash":            true,
+	"status":              true,
+	"cumulativeGasUsed":   true,
+	"effectivePercentage": true,
+	"logs":                true,
+}
+
+// GetBlockInfoRoot returns the root of the block info tree of the block, blocks before etrog don't have one
+func (api *ZkEvmAPIImpl) GetBlockInfoRoot(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (common.Hash, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer tx.Rollback()
+
+	blockNr, _, _, err := rpchelper.GetCanonicalBlockNumber(blockNrOrHash, tx, api.ethApi.filters)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	root, err := hermez_db.NewHermezDbReader(tx).GetBlockInfoRoot(blockNr)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if root == (common.Hash{}) {
+		return common.Hash{}, fmt.Errorf("no block info root for block %d", blockNr)
+	}
+	return root, nil
+}
+
+// GetBlockInfoTree returns the leaf values of the block info tree of the block
+func (api *ZkEvmAPIImpl) GetBlockInfoTree(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*BlockInfoTreeLeaves, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNr, _, _, err := rpchelper.GetCanonicalBlockNumber(blockNrOrHash, tx, api.ethApi.filters)
+	if err != nil {
+		return nil, err
+	}
+
+	info, tree, err := blockInfoTreeAt(tx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BlockInfoTreeLeaves{
+		BlockNumber: hexutil.Uint64(blockNr),
+		Root:        common.BigToHash(tree.GetRoot()),
+		ParentHash:  info.ParentHash,
+		Coinbase:    info.Coinbase,
+		GasLimit:    hexutil.Uint64(info.GasLimit),
+		Timestamp:   hexutil.Uint64(info.Timestamp),
+		Ger:         info.Ger,
+		L1BlockHash: info.L1BlockHash,
+		GasUsed:     hexutil.Uint64(info.GasUsed),
+		Txs:         make([]*BlockInfoTxLeaves, 0, len(info.Txs)),
+	}
+	for _, t := range info.Txs {
+		leaves := &BlockInfoTxLeaves{
+			Index:               hexutil.Uint64(t.Index),
+			L2TxHash:            t.L2TxHash,
+			Status:              hexutil.Uint64(t.Status),
+			CumulativeGasUsed:   hexutil.Uint64(t.CumulativeGasUsed),
+			EffectivePercentage: hexutil.Uint64(t.EffectivePercentage),
+			Logs:                make([]*BlockInfoLogLeaf, 0, len(t.Logs)),
+		}
+		for _, l := range t.Logs {
+			leaves.Logs = append(leaves.Logs, &BlockInfoLogLeaf{Index: hexutil.Uint64(l.Index), Hash: l.Hash})
+		}
+		result.Txs = append(result.Txs, leaves)
+	}
+
+	return result, nil
+}
+
+// GetBlockInfoProof returns proofs of the block info tree leaves of a transaction.  fields picks the leaves to prove
+// out of l2TxHash, status, cumulativeGasUsed, effectivePercentage and logs, all of them when it is empty.
+func (api *ZkEvmAPIImpl) GetBlockInfoProof(ctx context.Context, txHash common.Hash, fields []string) (*BlockInfoTxProof, error) {
+	for _, f := range fields {
+		if !blockInfoProofFields[f] {
+			return nil, fmt.Errorf("unknown block info field %q", f)
+		}
+	}
+	wanted := func(field string) bool {
+		if len(fields) == 0 {
+			return true
+		}
+		for _, f := range fields {
+			if f == field {
+				return true
+			}
+		}
+		return false
+	}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNr, ok, err := api.ethApi.txnLookup(ctx, tx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("transaction %s not found", txHash.Hex())
+	}
+	block, err := api.ethApi.blockByNumberWithSenders(tx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", blockNr)
+	}
+	txIndex := -1
+	for i, t := range block.Transactions() {
+		if t.Hash() == txHash {
+			txIndex = i
+			break
+		}
+	}
+	if txIndex < 0 {
+		return nil, fmt.Errorf("transaction %s not found in block %d", txHash.Hex(), blockNr)
+	}
+
+	info, tree, err := blockInfoTreeAt(tx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	var txInfo *zktypes.BlockInfoTx
+	for i := range info.Txs {
+		if info.Txs[i].Index == txIndex {
+			txInfo = &info.Txs[i]
+			break
+		}
+	}
+	if txInfo == nil {
+		return nil, fmt.Errorf("transaction %s is not in the block info tree of block %d", txHash.Hex(), blockNr)
+	}
+
+	result := &BlockInfoTxProof{
+		BlockNumber: hexutil.Uint64(blockNr),
+		Root:        common.BigToHash(tree.GetRoot()),
+		TxHash:      txHash,
+		TxIndex:     hexutil.Uint64(txIndex),
+	}
+
+	txIndexBig := big.NewInt(int64(txIndex))
+	txLeaves := []struct {
+		field string
+		key   func(*big.Int) (utils.NodeKey, error)
+		proof **SmtKeyProof
+	}{
+		{"l2TxHash", blockinfo.KeyTxHash, &result.L2TxHash},
+		{"status", blockinfo.KeyTxStatus, &result.Status},
+		{"cumulativeGasUsed", blockinfo.KeyCumulativeGasUsed, &result.CumulativeGasUsed},
+		{"effectivePercentage", blockinfo.KeyEffectivePercentage, &result.EffectivePercentage},
+	}
+	for _, leaf := range txLeaves {
+		if !wanted(leaf.field) {
+			continue
+		}
+		key, err := leaf.key(txIndexBig)
+		if err != nil {
+			return nil, err
+		}
+		if *leaf.proof, err = blockInfoKeyProof(tree, key); err != nil {
+			return nil, err
+		}
+	}
+
+	if wanted("logs") {
+		result.Logs = make([]*SmtKeyProof, 0, len(txInfo.Logs))
+		for _, l := range txInfo.Logs {
+			key, err := blockinfo.KeyTxLogs(txIndexBig, big.NewInt(l.Index))
+			if err != nil {
+				return nil, err
+			}
+			proof, err := blockInfoKeyProof(tree, key)
+			if err != nil {
+				return nil, err
+			}
+			result.Logs = append(result.Logs, proof)
+		}
+	}
+
+	return result, nil
+}
+
+// blockInfoTreeAt rebuilds the block info tree of the block from the values stored when it was executed and checks
+// it against the stored root
+func blockInfoTreeAt(tx kv.Tx, blockNr uint64) (*zktypes.BlockInfo, *blockinfo.BlockInfoTree, error) {
+	hermezDb := hermez_db.NewHermezDbReader(tx)
+	info, err := hermezDb.GetBlockInfoTree(blockNr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if info == nil {
+		return nil, nil, fmt.Errorf("no block info tree stored for block %d, blocks before etrog have none and blocks executed by an older version of the node only have the root", blockNr)
+	}
+
+	tree, err := blockinfo.BuildBlockInfoTree(info)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stored, err := hermezDb.GetBlockInfoRoot(blockNr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if root := common.BigToHash(tree.GetRoot()); root != stored {
+		return nil, nil, fmt.Errorf("rebuilt block info root %s of block %d does not match the stored root %s", root.Hex(), blockNr, stored.Hex())
+	}
+
+	return info, tree, nil
+}
+
+func blockInfoKeyProof(tree *blockinfo.BlockInfoTree, key utils.NodeKey) (*SmtKeyProof, error) {
+	proof, err := tree.GetProof(key)
+	if err != nil {
+		return nil, err
+	}
+	return toSmtKeyProof(key, proof), nil
+}
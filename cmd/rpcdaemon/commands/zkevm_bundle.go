@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/ethconfig"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/zk/txpool"
+	"github.com/ledgerwatch/erigon/zkevm/jsonrpc/client"
+)
+
+// SendBundle queues transactions that have to be sequenced one after the other in the same block, or not at all.  A
+// bundle is dropped when any of its transactions fails or reverts, or once the chain is past its max block number.
+// RPC nodes forward the bundle to the sequencer.
+func (api *ZkEvmAPIImpl) SendBundle(ctx context.Context, args SendBundleArgs) (common.Hash, error) {
+	if len(args.Txs) == 0 {
+		return common.Hash{}, txpool.ErrBundleEmpty
+	}
+	if len(args.Txs) > txpool.MaxBundleSize {
+		return common.Hash{}, fmt.Errorf("%w, the limit is %d", txpool.ErrBundleTooLarge, txpool.MaxBundleSize)
+	}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer tx.Rollback()
+	cc, err := api.ethApi.chainConfig(tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	txHashes := make([]common.Hash, 0, len(args.Txs))
+	rlpTxs := make([][]byte, 0, len(args.Txs))
+	for i, encodedTx := range args.Txs {
+		txn, err := types.DecodeTransaction(rlp.NewStream(bytes.NewReader(encodedTx), uint64(len(encodedTx))))
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		if err := checkTxFee(txn.GetPrice().ToBig(), txn.GetGas(), ethconfig.Defaults.RPCTxFeeCap); err != nil {
+			return common.Hash{}, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		if !api.ethApi.AllowPreEIP155Transactions && !txn.Protected() {
+			return common.Hash{}, fmt.Errorf("transaction %d: only replay-protected (EIP-155) transactions allowed over RPC", i)
+		}
+		if txn.Protected() && cc.ChainID.Cmp(txn.GetChainID().ToBig()) != 0 {
+			return common.Hash{}, fmt.Errorf("transaction %d: invalid chain id, expected: %d got: %d", i, cc.ChainID, txn.GetChainID())
+		}
+		txHashes = append(txHashes, txn.Hash())
+		rlpTxs = append(rlpTxs, encodedTx)
+	}
+
+	if api.ethApi.isZkNonSequencer(cc.ChainID) {
+		return api.sendBundleZk(api.ethApi.l2RpcUrl, args)
+	}
+
+	if api.txPool == nil {
+		return common.Hash{}, errors.New("bundles are not supported without the zk txpool")
+	}
+
+	var maxBlockNumber uint64
+	if args.MaxBlockNumber != nil {
+		maxBlockNumber = uint64(*args.MaxBlockNumber)
+	}
+	return api.txPool.AddBundle(ctx, txHashes, rlpTxs, maxBlockNumber)
+}
+
+func (api *ZkEvmAPIImpl) sendBundleZk(rpcUrl string, args SendBundleArgs) (common.Hash, error) {
+	res, err := client.JSONRPCCall(rpcUrl, "zkevm_sendBundle", args)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if res.Error != nil {
+		return common.Hash{}, fmt.Errorf("RPC error response: %s", res.Error.Message)
+	}
+
+	var hash common.Hash
+	if err = json.Unmarshal(res.Result, &hash); err != nil {
+		return common.Hash{}, err
+	}
+	return hash, nil
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/hexutility"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+
+	"github.com/ledgerwatch/erigon/common/hexutil"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/ethconfig"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/zk/txpool"
+	"github.com/ledgerwatch/erigon/zkevm/jsonrpc/client"
+)
+
+// CancelTransactionResult is how the replacement sent to zkevm_cancelTransaction went.  The status is replaced,
+// rejected or not-found, a rejected replacement comes with the reason and the least fee cap and tip it would have
+// needed.
+type CancelTransactionResult struct {
+	Hash      common.Hash  `json:"hash"`
+	Replaced  *common.Hash `json:"replaced,omitempty"`
+	Status    string       `json:"status"`
+	Reason    string       `json:"reason,omitempty"`
+	MinFeeCap *hexutil.Big `json:"minMaxFeePerGas,omitempty"`
+	MinTip    *hexutil.Big `json:"minMaxPriorityFeePerGas,omitempty"`
+}
+
+// CancelTransaction sends a signed transaction to replace the one of the pool with the same sender and nonce, usually a
+// transfer of nothing to the sender itself to cancel it.  Nothing is added when the pool has no transaction to replace.
+// RPC nodes forward it to the sequencer.
+func (api *ZkEvmAPIImpl) CancelTransaction(ctx context.Context, encodedTx hexutility.Bytes) (*CancelTransactionResult, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	cc, err := api.ethApi.chainConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	txn, err := types.DecodeTransaction(rlp.NewStream(bytes.NewReader(encodedTx), uint64(len(encodedTx))))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTxFee(txn.GetPrice().ToBig(), txn.GetGas(), ethconfig.Defaults.RPCTxFeeCap); err != nil {
+		return nil, err
+	}
+	if !api.ethApi.AllowPreEIP155Transactions && !txn.Protected() {
+		return nil, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+	}
+	if txn.Protected() && cc.ChainID.Cmp(txn.GetChainID().ToBig()) != 0 {
+		return nil, fmt.Errorf("invalid chain id, expected: %d got: %d", cc.ChainID, txn.GetChainID())
+	}
+
+	if api.ethApi.isZkNonSequencer(cc.ChainID) {
+		return api.cancelTransactionZk(api.ethApi.l2RpcUrl, encodedTx)
+	}
+
+	if api.txPool == nil || api.txPoolDB == nil {
+		return nil, errors.New("cancelling transactions is not supported without the zk txpool")
+	}
+
+	var replacement *txpool.Replacement
+	if err := api.txPoolDB.View(ctx, func(poolTx kv.Tx) (err error) {
+		replacement, err = api.txPool.ReplaceTx(ctx, poolTx, encodedTx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	result := &CancelTransactionResult{Hash: replacement.Hash, Status: replacement.Status}
+	if replacement.Replaced != (common.Hash{}) {
+		result.Replaced = &replacement.Replaced
+	}
+	if replacement.Status == txpool.ReplacementRejected {
+		result.Reason = replacement.Reason.String()
+		if !replacement.MinFeeCap.IsZero() {
+			result.MinFeeCap = (*hexutil.Big)(replacement.MinFeeCap.ToBig())
+			result.MinTip = (*hexutil.Big)(replacement.MinTip.ToBig())
+		}
+	}
+	return result, nil
+}
+
+func (api *ZkEvmAPIImpl) cancelTransactionZk(rpcUrl string, encodedTx hexutility.Bytes) (*CancelTransactionResult, error) {
+	res, err := client.JSONRPCCall(rpcUrl, "zkevm_cancelTransaction", encodedTx)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error != nil {
+		return nil, fmt.Errorf("RPC error response: %s", res.Error.Message)
+	}
+
+	var result CancelTransactionResult
+	if err = json.Unmarshal(res.Result, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
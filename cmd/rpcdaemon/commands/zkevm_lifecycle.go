@@ -0,0 +1,190 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/turbo/rpchelper"
+	"github.com/ledgerwatch/erigon/zk/hermez_db"
+	types "github.com/ledgerwatch/erigon/zk/rpcdaemon"
+	"github.com/ledgerwatch/erigon/zk/sequencer"
+	"github.com/ledgerwatch/erigon/zk/txpool"
+	"github.com/ledgerwatch/erigon/zkevm/jsonrpc/client"
+)
+
+// GetTransactionLifecycle reports how far a transaction has got on its way from the pool to being verified on the L1,
+// along with the time of every transition.  Transition timestamps are 0 when the time is not known, e.g. the L1 block
+// time when the node has no L1 rpc.
+func (api *ZkEvmAPIImpl) GetTransactionLifecycle(ctx context.Context, hash common.Hash) (*TxLifecycle, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	lifecycle := &TxLifecycle{
+		Hash:        hash,
+		Status:      TxLifecycleUnknown,
+		Transitions: []TxLifecycleTransition{},
+	}
+
+	// the pool only keeps its history for a while so a mined transaction may already be forgotten here
+	var poolStatus txpool.TxStatus
+	if api.txPool != nil {
+		poolStatus = api.txPool.TxStatus(hash)
+	}
+	if !poolStatus.Added.IsZero() {
+		// the pool only keeps the time a transaction arrived, not the sub pool it was put in, so whether it went
+		// straight to pending or not its arrival is reported as queued
+		lifecycle.transition(TxLifecycleQueued, uint64(poolStatus.Added.Unix()))
+	}
+
+	blockNum, ok, err := api.ethApi.txnLookup(ctx, tx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if err = api.chainLifecycle(ctx, tx, lifecycle, blockNum); err != nil {
+			return nil, err
+		}
+		return lifecycle, nil
+	}
+
+	if api.ethApi.pendingStore != nil {
+		latest, err := rpchelper.GetLatestBlockNumber(tx)
+		if err != nil {
+			return nil, err
+		}
+		if block, _, ok := api.ethApi.pendingStore.Transaction(hash, latest); ok {
+			blockNumber := types.ArgUint64(block.Number())
+			lifecycle.BlockNumber = &blockNumber
+			lifecycle.transition(TxLifecyclePreconfirmed, block.Header.Time)
+			return lifecycle, nil
+		}
+	}
+
+	switch {
+	case poolStatus.SubPool == txpool.PendingSubPool:
+		lifecycle.Status = TxLifecyclePending
+		return lifecycle, nil
+	case poolStatus.SubPool != 0:
+		lifecycle.Status = TxLifecycleQueued
+		return lifecycle, nil
+	case poolStatus.Known && poolStatus.DiscardReason != txpool.Mined:
+		lifecycle.DropReason = poolStatus.DiscardReason.String()
+		lifecycle.transition(TxLifecycleDropped, uint64(poolStatus.Discarded.Unix()))
+		return lifecycle, nil
+	}
+
+	// rpc nodes forward transactions so only the sequencer pool knows about them until they are in a block
+	if !sequencer.IsSequencer() && api.ethApi.ZkRpcUrl != "" {
+		return api.remoteTransactionLifecycle(hash)
+	}
+
+	return lifecycle, nil
+}
+
+func (api *ZkEvmAPIImpl) chainLifecycle(ctx context.Context, tx kv.Tx, lifecycle *TxLifecycle, blockNum uint64) error {
+	header, err := api.ethApi._blockReader.HeaderByNumber(ctx, tx, blockNum)
+	if err != nil {
+		return err
+	}
+	if header == nil {
+		return fmt.Errorf("header not found for block %d", blockNum)
+	}
+	blockNumber := types.ArgUint64(blockNum)
+	blockHash := header.Hash()
+	lifecycle.BlockNumber = &blockNumber
+	lifecycle.BlockHash = &blockHash
+	lifecycle.transition(TxLifecycleIncluded, header.Time)
+
+	batchNo, err := getBatchNoByL2Block(tx, blockNum)
+	if err != nil {
+		return err
+	}
+	batchNumber := types.ArgUint64(batchNo)
+	lifecycle.BatchNumber = &batchNumber
+
+	hermezDb := hermez_db.NewHermezDbReader(tx)
+	sequence, err := hermezDb.GetSequenceCoveringBatchNo(batchNo)
+	if err != nil {
+		return err
+	}
+	latestBatch, err := getLatestBatchNumber(tx)
+	if err != nil {
+		return err
+	}
+
+	// a batch is closed once a later batch has been started or it has been sequenced
+	if batchNo >= latestBatch && sequence == nil {
+		return nil
+	}
+	closedAt := header.Time
+	lastBlock, err := hermezDb.GetHighestBlockInBatch(batchNo)
+	if err != nil {
+		return err
+	}
+	lastHeader, err := api.ethApi._blockReader.HeaderByNumber(ctx, tx, lastBlock)
+	if err != nil {
+		return err
+	}
+	if lastHeader != nil {
+		closedAt = lastHeader.Time
+	}
+	lifecycle.transition(TxLifecycleBatchClosed, closedAt)
+
+	if sequence == nil {
+		return nil
+	}
+	sequenceL1Block := types.ArgUint64(sequence.L1BlockNo)
+	lifecycle.SequenceL1Block = &sequenceL1Block
+	lifecycle.SequenceL1TxHash = &sequence.L1TxHash
+	lifecycle.transition(TxLifecycleVirtualized, api.l1BlockTime(sequence.L1BlockNo))
+
+	verification, err := hermezDb.GetVerificationCoveringBatchNo(batchNo)
+	if err != nil {
+		return err
+	}
+	if verification == nil {
+		return nil
+	}
+	verifyL1Block := types.ArgUint64(verification.L1BlockNo)
+	lifecycle.VerifyL1Block = &verifyL1Block
+	lifecycle.VerifyL1TxHash = &verification.L1TxHash
+	lifecycle.transition(TxLifecycleVerified, api.l1BlockTime(verification.L1BlockNo))
+
+	return nil
+}
+
+func (api *ZkEvmAPIImpl) l1BlockTime(l1BlockNo uint64) uint64 {
+	if api.l1Syncer == nil {
+		return 0
+	}
+	block, err := api.l1Syncer.GetBlock(l1BlockNo)
+	if err != nil {
+		log.Debug("[zkevm] could not fetch l1 block for the transaction lifecycle", "block", l1BlockNo, "err", err)
+		return 0
+	}
+	return block.Time()
+}
+
+func (api *ZkEvmAPIImpl) remoteTransactionLifecycle(hash common.Hash) (*TxLifecycle, error) {
+	res, err := client.JSONRPCCall(api.ethApi.ZkRpcUrl, "zkevm_getTransactionLifecycle", hash)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error != nil {
+		return nil, fmt.Errorf("RPC error response: %s", res.Error.Message)
+	}
+
+	var lifecycle TxLifecycle
+	if err = json.Unmarshal(res.Result, &lifecycle); err != nil {
+		return nil, err
+	}
+	return &lifecycle, nil
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/hexutility"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/ethconfig"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/zk/txpool"
+	"github.com/ledgerwatch/erigon/zkevm/jsonrpc/client"
+)
+
+// SendPrivateRawTransaction sends a signed transaction to the private lane of the sequencer pool.  It is sequenced like
+// any other but it isn't gossiped nor returned by the txpool and pending transaction APIs, it is only seen once it is
+// mined.  RPC nodes forward it straight to the sequencer, never to a pool manager.
+func (api *ZkEvmAPIImpl) SendPrivateRawTransaction(ctx context.Context, encodedTx hexutility.Bytes) (common.Hash, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer tx.Rollback()
+	cc, err := api.ethApi.chainConfig(tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if api.ethApi.isZkNonSequencer(cc.ChainID) {
+		if err := api.ethApi.preValidateTxZk(ctx, tx, cc, encodedTx); err != nil {
+			return common.Hash{}, err
+		}
+		return api.sendPrivateTxZk(api.ethApi.l2RpcUrl, encodedTx)
+	}
+
+	txn, err := types.DecodeTransaction(rlp.NewStream(bytes.NewReader(encodedTx), uint64(len(encodedTx))))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := checkTxFee(txn.GetPrice().ToBig(), txn.GetGas(), ethconfig.Defaults.RPCTxFeeCap); err != nil {
+		return common.Hash{}, err
+	}
+	if !api.ethApi.AllowPreEIP155Transactions && !txn.Protected() {
+		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+	}
+	if txn.Protected() && cc.ChainID.Cmp(txn.GetChainID().ToBig()) != 0 {
+		return common.Hash{}, fmt.Errorf("invalid chain id, expected: %d got: %d", cc.ChainID, txn.GetChainID())
+	}
+
+	if api.txPool == nil || api.txPoolDB == nil {
+		return common.Hash{}, errors.New("private transactions are not supported without the zk txpool")
+	}
+
+	var hash common.Hash
+	var reason txpool.DiscardReason
+	if err := api.txPoolDB.View(ctx, func(poolTx kv.Tx) (err error) {
+		hash, reason, err = api.txPool.AddPrivateTx(ctx, poolTx, encodedTx)
+		return err
+	}); err != nil {
+		return common.Hash{}, err
+	}
+	if reason != txpool.Success {
+		return hash, fmt.Errorf("transaction rejected: %s", reason)
+	}
+	return hash, nil
+}
+
+func (api *ZkEvmAPIImpl) sendPrivateTxZk(rpcUrl string, encodedTx hexutility.Bytes) (common.Hash, error) {
+	res, err := client.JSONRPCCall(rpcUrl, "zkevm_sendPrivateRawTransaction", encodedTx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if res.Error != nil {
+		return common.Hash{}, fmt.Errorf("RPC error response: %s", res.Error.Message)
+	}
+
+	var hash common.Hash
+	if err = json.Unmarshal(res.Result, &hash); err != nil {
+		return common.Hash{}, err
+	}
+	return hash, nil
+}
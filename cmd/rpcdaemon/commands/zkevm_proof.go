@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/memdb"
+
+	"github.com/ledgerwatch/erigon/common/hexutil"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/rpc"
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/smt"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+	"github.com/ledgerwatch/erigon/turbo/rpchelper"
+)
+
+// SmtProof holds the SMT proofs for the leaves of an account at a block
+type SmtProof struct {
+	Address      common.Address     `json:"address"`
+	BlockNumber  hexutil.Uint64     `json:"blockNumber"`
+	StateRoot    common.Hash        `json:"stateRoot"`
+	Balance      *SmtKeyProof       `json:"balance"`
+	Nonce        *SmtKeyProof       `json:"nonce"`
+	CodeHash     *SmtKeyProof       `json:"codeHash"`
+	CodeLength   *SmtKeyProof       `json:"codeLength"`
+	StorageProof []*SmtStorageProof `json:"storageProof"`
+}
+
+// SmtKeyProof is the path from the root to an SMT key.  For a key that isn't in the tree the leaf fields describe the
+// leaf of another key the path ended at, they are left out when it ended at an empty branch.
+type SmtKeyProof struct {
+	Key              common.Hash   `json:"key"`
+	Value            *hexutil.Big  `json:"value"`
+	Siblings         []common.Hash `json:"siblings"`
+	LeafRemainingKey *common.Hash  `json:"leafRemainingKey,omitempty"`
+	LeafValueHash    *common.Hash  `json:"leafValueHash,omitempty"`
+}
+
+type SmtStorageProof struct {
+	StorageKey common.Hash `json:"storageKey"`
+	*SmtKeyProof
+}
+
+// GetProof returns SMT proofs for an account and its storage.  The latest block can always be proven, older blocks
+// need the node to keep SMT history (zkevm.smt-history-blocks) covering them.
+func (api *ZkEvmAPIImpl) GetProof(ctx context.Context, address common.Address, storageKeys []common.Hash, blockNrOrHash rpc.BlockNumberOrHash) (*SmtProof, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNr, _, _, err := rpchelper.GetCanonicalBlockNumber(blockNrOrHash, tx, api.ethApi.filters)
+	if err != nil {
+		return nil, err
+	}
+
+	// the smt db wants a read-write tx, nothing is written to the batch
+	batch := memdb.NewMemoryBatch(tx, api.ethApi.dirs.Tmp)
+	defer batch.Rollback()
+	dbSmt := smt.NewSMT(db2.NewEriDb(batch))
+
+	hashed, err := stages.GetStageProgress(tx, stages.IntermediateHashes)
+	if err != nil {
+		return nil, err
+	}
+	root, err := dbSmt.RootAtOrLast(blockNr, hashed)
+	if err != nil {
+		return nil, err
+	}
+
+	ethAddr := address.String()
+	result := &SmtProof{
+		Address:      address,
+		BlockNumber:  hexutil.Uint64(blockNr),
+		StateRoot:    common.BigToHash(root.ToBigInt()),
+		StorageProof: make([]*SmtStorageProof, 0, len(storageKeys)),
+	}
+
+	accountKeys := []struct {
+		key   func(string) (utils.NodeKey, error)
+		proof **SmtKeyProof
+	}{
+		{utils.KeyEthAddrBalance, &result.Balance},
+		{utils.KeyEthAddrNonce, &result.Nonce},
+		{utils.KeyContractCode, &result.CodeHash},
+		{utils.KeyContractLength, &result.CodeLength},
+	}
+	for _, ak := range accountKeys {
+		key, err := ak.key(ethAddr)
+		if err != nil {
+			return nil, err
+		}
+		if *ak.proof, err = smtKeyProof(dbSmt, key, root); err != nil {
+			return nil, err
+		}
+	}
+
+	ethAddrArray := utils.ScalarToArrayBig(utils.ConvertHexToBigInt(ethAddr))
+	for _, storageKey := range storageKeys {
+		key, err := utils.KeyContractStorage(ethAddrArray, storageKey.Hex())
+		if err != nil {
+			return nil, err
+		}
+		proof, err := smtKeyProof(dbSmt, key, root)
+		if err != nil {
+			return nil, err
+		}
+		result.StorageProof = append(result.StorageProof, &SmtStorageProof{StorageKey: storageKey, SmtKeyProof: proof})
+	}
+
+	return result, nil
+}
+
+func smtKeyProof(dbSmt *smt.SMT, key utils.NodeKey, root utils.NodeKey) (*SmtKeyProof, error) {
+	proof, err := dbSmt.GetProof(key, root)
+	if err != nil {
+		return nil, err
+	}
+	return toSmtKeyProof(key, proof), nil
+}
+
+func toSmtKeyProof(key utils.NodeKey, proof *smt.Proof) *SmtKeyProof {
+	result := &SmtKeyProof{
+		Key:      nodeKeyToHash(key),
+		Value:    (*hexutil.Big)(utils.ArrayBigToScalar(utils.BigIntArrayFromNodeValue8(&proof.Value))),
+		Siblings: make([]common.Hash, 0, len(proof.Siblings)),
+	}
+	for _, sibling := range proof.Siblings {
+		result.Siblings = append(result.Siblings, nodeKeyToHash(sibling))
+	}
+	if proof.LeafRemainingKey != nil {
+		remainingKey := nodeKeyToHash(*proof.LeafRemainingKey)
+		valueHash := nodeKeyToHash(*proof.LeafValueHash)
+		result.LeafRemainingKey = &remainingKey
+		result.LeafValueHash = &valueHash
+	}
+
+	return result
+}
+
+func nodeKeyToHash(k utils.NodeKey) common.Hash {
+	return common.BigToHash(k.ToBigInt())
+}
@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/memdb"
+
+	"github.com/ledgerwatch/erigon/common/hexutil"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/rpc"
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/smt"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+	"github.com/ledgerwatch/erigon/turbo/rpchelper"
+)
+
+const (
+	// GetStateDiffMaxResults is the maximum number of changed SMT leaves returned per call
+	GetStateDiffMaxResults = 1024
+	// GetStateDiffMaxBlockRange is the furthest apart the two blocks of a state diff can be
+	GetStateDiffMaxBlockRange = 10_000
+)
+
+// SmtStateDiff holds a page of the state changed between two blocks, as found by comparing their SMTs.  Next is the
+// key to carry on from.
+type SmtStateDiff struct {
+	FromBlock  hexutil.Uint64                     `json:"fromBlock"`
+	ToBlock    hexutil.Uint64                     `json:"toBlock"`
+	FromRoot   common.Hash                        `json:"fromRoot"`
+	ToRoot     common.Hash                        `json:"toRoot"`
+	Accounts   map[common.Address]*SmtAccountDiff `json:"accounts"`
+	Unresolved []*SmtValueChange                  `json:"unresolved"`
+	Next       *common.Hash                       `json:"next,omitempty"`
+}
+
+// SmtAccountDiff holds the changed leaves of an account, unchanged ones are left out
+type SmtAccountDiff struct {
+	Balance    *SmtValueChange                 `json:"balance,omitempty"`
+	Nonce      *SmtValueChange                 `json:"nonce,omitempty"`
+	CodeHash   *SmtValueChange                 `json:"codeHash,omitempty"`
+	CodeLength *SmtValueChange                 `json:"codeLength,omitempty"`
+	Storage    map[common.Hash]*SmtValueChange `json:"storage,omitempty"`
+}
+
+// SmtValueChange is a leaf value before and after, a value is zero when the leaf doesn't exist.  The key is only set
+// for changes that couldn't be mapped back to an account.
+type SmtValueChange struct {
+	Key  *common.Hash `json:"key,omitempty"`
+	From *hexutil.Big `json:"from"`
+	To   *hexutil.Big `json:"to"`
+}
+
+// GetStateDiff returns the balances, nonces, code and storage that differ between the two blocks.  Both blocks need
+// to be readable from the SMT, the latest block or blocks inside the SMT history (zkevm.smt-history-blocks).  The
+// changed leaves are returned in the order of their path through the tree up to maxResults at a time, starting at the
+// leaf with the start key.
+func (api *ZkEvmAPIImpl) GetStateDiff(ctx context.Context, fromBlock rpc.BlockNumberOrHash, toBlock rpc.BlockNumberOrHash, start *common.Hash, maxResults int) (*SmtStateDiff, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	fromNr, _, _, err := rpchelper.GetCanonicalBlockNumber(fromBlock, tx, api.ethApi.filters)
+	if err != nil {
+		return nil, err
+	}
+	toNr, _, _, err := rpchelper.GetCanonicalBlockNumber(toBlock, tx, api.ethApi.filters)
+	if err != nil {
+		return nil, err
+	}
+	if blockRange := int64(toNr) - int64(fromNr); blockRange > GetStateDiffMaxBlockRange || blockRange < -GetStateDiffMaxBlockRange {
+		return nil, fmt.Errorf("blocks %d and %d are more than %d blocks apart", fromNr, toNr, GetStateDiffMaxBlockRange)
+	}
+	if maxResults > GetStateDiffMaxResults || maxResults <= 0 {
+		maxResults = GetStateDiffMaxResults
+	}
+
+	// the smt db wants a read-write tx, nothing is written to the batch
+	batch := memdb.NewMemoryBatch(tx, api.ethApi.dirs.Tmp)
+	defer batch.Rollback()
+	dbSmt := smt.NewSMT(db2.NewEriDb(batch))
+
+	hashed, err := stages.GetStageProgress(tx, stages.IntermediateHashes)
+	if err != nil {
+		return nil, err
+	}
+	fromRoot, err := dbSmt.RootAtOrLast(fromNr, hashed)
+	if err != nil {
+		return nil, err
+	}
+	toRoot, err := dbSmt.RootAtOrLast(toNr, hashed)
+	if err != nil {
+		return nil, err
+	}
+
+	var startKey *utils.NodeKey
+	if start != nil {
+		key := utils.ScalarToRoot(start.Big())
+		startKey = &key
+	}
+	diff, err := dbSmt.StateDiff(ctx, fromRoot, toRoot, startKey, maxResults)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SmtStateDiff{
+		FromBlock:  hexutil.Uint64(fromNr),
+		ToBlock:    hexutil.Uint64(toNr),
+		FromRoot:   nodeKeyToHash(fromRoot),
+		ToRoot:     nodeKeyToHash(toRoot),
+		Accounts:   make(map[common.Address]*SmtAccountDiff, len(diff.Accounts)),
+		Unresolved: make([]*SmtValueChange, 0, len(diff.Unresolved)),
+	}
+	for addr, account := range diff.Accounts {
+		accountDiff := &SmtAccountDiff{
+			Balance:    smtValueChange(account.Balance, false),
+			Nonce:      smtValueChange(account.Nonce, false),
+			CodeHash:   smtValueChange(account.CodeHash, false),
+			CodeLength: smtValueChange(account.CodeLength, false),
+		}
+		if len(account.Storage) > 0 {
+			accountDiff.Storage = make(map[common.Hash]*SmtValueChange, len(account.Storage))
+			for slot, change := range account.Storage {
+				accountDiff.Storage[slot] = smtValueChange(change, false)
+			}
+		}
+		result.Accounts[addr] = accountDiff
+	}
+	for _, change := range diff.Unresolved {
+		result.Unresolved = append(result.Unresolved, smtValueChange(change, true))
+	}
+	if diff.Next != nil {
+		next := nodeKeyToHash(*diff.Next)
+		result.Next = &next
+	}
+
+	return result, nil
+}
+
+func smtValueChange(change *smt.LeafChange, withKey bool) *SmtValueChange {
+	if change == nil {
+		return nil
+	}
+	result := &SmtValueChange{
+		From: (*hexutil.Big)(change.From),
+		To:   (*hexutil.Big)(change.To),
+	}
+	if withKey {
+		key := nodeKeyToHash(change.Key)
+		result.Key = &key
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/common/debug"
+	"github.com/ledgerwatch/erigon/rpc"
+	"github.com/ledgerwatch/erigon/zk/events"
+	types "github.com/ledgerwatch/erigon/zk/rpcdaemon"
+	zktypes "github.com/ledgerwatch/erigon/zk/types"
+)
+
+// BatchClosed sends a notification each time a batch is closed and no more blocks will be added to it
+func (api *ZkEvmAPIImpl) BatchClosed(ctx context.Context) (*rpc.Subscription, error) {
+	return subscribeZkEvents(ctx, api.events.AddBatchClosedSubscription, func(e events.BatchClosed) interface{} {
+		return &BatchClosedNotification{
+			BatchNumber:     types.ArgUint64(e.BatchNumber),
+			LastBlockNumber: types.ArgUint64(e.LastBlockNumber),
+		}
+	})
+}
+
+// BatchVirtualized sends a notification each time a sequence of batches is seen on the L1
+func (api *ZkEvmAPIImpl) BatchVirtualized(ctx context.Context) (*rpc.Subscription, error) {
+	return subscribeZkEvents(ctx, api.events.AddBatchVirtualizedSubscription, toL1BatchNotification)
+}
+
+// BatchVerified sends a notification each time a verification of batches is seen on the L1
+func (api *ZkEvmAPIImpl) BatchVerified(ctx context.Context) (*rpc.Subscription, error) {
+	return subscribeZkEvents(ctx, api.events.AddBatchVerifiedSubscription, toL1BatchNotification)
+}
+
+// L1InfoTreeUpdate sends a notification each time a new global exit root is added to the L1 info tree
+func (api *ZkEvmAPIImpl) L1InfoTreeUpdate(ctx context.Context) (*rpc.Subscription, error) {
+	return subscribeZkEvents(ctx, api.events.AddL1InfoTreeUpdateSubscription, func(u zktypes.L1InfoTreeUpdate) interface{} {
+		return &L1InfoTreeUpdateNotification{
+			Index:           types.ArgUint64(u.Index),
+			GlobalExitRoot:  u.GER,
+			MainnetExitRoot: u.MainnetExitRoot,
+			RollupExitRoot:  u.RollupExitRoot,
+			L1BlockNumber:   types.ArgUint64(u.BlockNumber),
+			Timestamp:       types.ArgUint64(u.Timestamp),
+		}
+	})
+}
+
+func toL1BatchNotification(e events.L1Batch) interface{} {
+	return &L1BatchNotification{
+		BatchNumber:   types.ArgUint64(e.BatchNumber),
+		L1BlockNumber: types.ArgUint64(e.L1BlockNumber),
+		L1TxHash:      e.L1TxHash,
+		StateRoot:     e.StateRoot,
+	}
+}
+
+// subscribeZkEvents forwards the events from the stages to an rpc subscription.  The events are only produced inside
+// the node process so a standalone rpcdaemon does not support these subscriptions.
+func subscribeZkEvents[T any](ctx context.Context, subscribe func() (chan T, func()), convert func(T) interface{}) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	ch, unsubscribe := subscribe()
+	if ch == nil {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		defer debug.LogPanic()
+		defer unsubscribe()
+		for {
+			select {
+			case e, ok := <-ch:
+				if !ok {
+					log.Warn("zkevm events channel was closed")
+					return
+				}
+				if err := notifier.Notify(rpcSub.ID, convert(e)); err != nil {
+					log.Warn("error while notifying subscription", "err", err)
+					return
+				}
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
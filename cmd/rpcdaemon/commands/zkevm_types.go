@@ -3,6 +3,7 @@ package commands
 import (
 	types "github.com/ledgerwatch/erigon/zk/rpcdaemon"
 	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/hexutility"
 )
 
 type ZkExitRoots struct {
@@ -11,3 +12,72 @@ type ZkExitRoots struct {
 	MainnetExitRoot common.Hash     `json:"mainnetExitRoot"`
 	RollupExitRoot  common.Hash     `json:"rollupExitRoot"`
 }
+
+// transaction lifecycle states, a transaction moves through them in this order unless it is dropped from the pool
+const (
+	TxLifecycleUnknown      = "unknown"
+	TxLifecycleQueued       = "queued"
+	TxLifecyclePending      = "pending"
+	TxLifecycleDropped      = "dropped"
+	TxLifecyclePreconfirmed = "preconfirmed"
+	TxLifecycleIncluded     = "included"
+	TxLifecycleBatchClosed  = "batchClosed"
+	TxLifecycleVirtualized  = "virtualized"
+	TxLifecycleVerified     = "verified"
+)
+
+type TxLifecycleTransition struct {
+	Status    string          `json:"status"`
+	Timestamp types.ArgUint64 `json:"timestamp"`
+}
+
+type TxLifecycle struct {
+	Hash             common.Hash             `json:"hash"`
+	Status           string                  `json:"status"`
+	DropReason       string                  `json:"dropReason,omitempty"`
+	BlockNumber      *types.ArgUint64        `json:"blockNumber,omitempty"`
+	BlockHash        *common.Hash            `json:"blockHash,omitempty"`
+	BatchNumber      *types.ArgUint64        `json:"batchNumber,omitempty"`
+	SequenceL1Block  *types.ArgUint64        `json:"sequenceL1Block,omitempty"`
+	SequenceL1TxHash *common.Hash            `json:"sequenceL1TxHash,omitempty"`
+	VerifyL1Block    *types.ArgUint64        `json:"verifyL1Block,omitempty"`
+	VerifyL1TxHash   *common.Hash            `json:"verifyL1TxHash,omitempty"`
+	Transitions      []TxLifecycleTransition `json:"transitions"`
+}
+
+func (l *TxLifecycle) transition(status string, timestamp uint64) {
+	l.Status = status
+	l.Transitions = append(l.Transitions, TxLifecycleTransition{Status: status, Timestamp: types.ArgUint64(timestamp)})
+}
+
+// BatchClosedNotification is sent to zkevm_subscribe("batchClosed") subscribers
+type BatchClosedNotification struct {
+	BatchNumber     types.ArgUint64 `json:"batchNumber"`
+	LastBlockNumber types.ArgUint64 `json:"lastBlockNumber"`
+}
+
+// L1BatchNotification is sent to zkevm_subscribe("batchVirtualized") and zkevm_subscribe("batchVerified")
+// subscribers, the L1 transaction covers every batch up to and including BatchNumber
+type L1BatchNotification struct {
+	BatchNumber   types.ArgUint64 `json:"batchNumber"`
+	L1BlockNumber types.ArgUint64 `json:"l1BlockNumber"`
+	L1TxHash      common.Hash     `json:"l1TxHash"`
+	StateRoot     common.Hash     `json:"stateRoot"`
+}
+
+// L1InfoTreeUpdateNotification is sent to zkevm_subscribe("l1InfoTreeUpdate") subscribers
+type L1InfoTreeUpdateNotification struct {
+	Index           types.ArgUint64 `json:"index"`
+	GlobalExitRoot  common.Hash     `json:"globalExitRoot"`
+	MainnetExitRoot common.Hash     `json:"mainnetExitRoot"`
+	RollupExitRoot  common.Hash     `json:"rollupExitRoot"`
+	L1BlockNumber   types.ArgUint64 `json:"l1BlockNumber"`
+	Timestamp       types.ArgUint64 `json:"timestamp"`
+}
+
+// SendBundleArgs are the transactions of a bundle for zkevm_sendBundle, signed and rlp encoded like for
+// eth_sendRawTransaction
+type SendBundleArgs struct {
+	Txs            []hexutility.Bytes `json:"txs"`
+	MaxBlockNumber *types.ArgUint64   `json:"maxBlockNumber,omitempty"`
+}
@@ -461,6 +461,11 @@ var (
 		Usage: "Batch seal time. Defaults to 3s",
 		Value: "3s",
 	}
+	SequencerCounterAwareSelection = cli.BoolFlag{
+		Name:  "zkevm.sequencer-counter-aware-selection",
+		Usage: "Pre-simulate pending transactions to estimate their virtual counters and pack batches by fee per counter",
+		Value: false,
+	}
 	ExecutorUrls = cli.StringFlag{
 		Name:  "zkevm.executor-urls",
 		Usage: "A comma separated list of grpc addresses that host executors",
@@ -556,11 +561,121 @@ var (
 		Usage: "The URL of the pool manager. If set, eth_sendRawTransaction will be redirected there.",
 		Value: "",
 	}
+	RpcTxPreValidation = cli.BoolFlag{
+		Name:  "zkevm.rpc-tx-prevalidation",
+		Usage: "Check the nonce, balance, intrinsic gas and chain id of transactions on an RPC node before forwarding them to the sequencer",
+		Value: true,
+	}
+	RpcTxSimulation = cli.BoolFlag{
+		Name:  "zkevm.rpc-tx-simulation",
+		Usage: "Execute transactions on an RPC node on top of the latest block and reject the ones that fail or overflow the zk counters before forwarding them to the sequencer",
+		Value: false,
+	}
+	RpcTxSenderRateLimit = cli.IntFlag{
+		Name:  "zkevm.rpc-tx-sender-ratelimit",
+		Usage: "Maximum number of transactions per second a sender can send through an RPC node. 0 disables the limit",
+		Value: 0,
+	}
+	RpcTxACLFile = cli.StringFlag{
+		Name:  "zkevm.rpc-tx-acl-file",
+		Usage: "Path to a json file with the allowedSenders and deniedSenders whose transactions an RPC node forwards, in the format of pool-manager.acl-file. Leave empty to forward the transactions of every sender",
+		Value: "",
+	}
+	TxPoolQueuedTTL = cli.StringFlag{
+		Name:  "zkevm.txpool-queued-ttl",
+		Usage: "How long a transaction can wait in the queued and base fee sub pools before it is dropped. 0 keeps them until the pool is full",
+		Value: "0s",
+	}
+	TxPoolPendingTTL = cli.StringFlag{
+		Name:  "zkevm.txpool-pending-ttl",
+		Usage: "How long a transaction can wait in the pending sub pool before it is dropped. 0 keeps them until the pool is full",
+		Value: "0s",
+	}
+	TxPoolSenderMaxSlots = cli.Uint64Flag{
+		Name:  "zkevm.txpool-sender-max-slots",
+		Usage: "Maximum number of transactions a sender can have in the pool, local ones included. 0 disables the limit",
+		Value: 0,
+	}
+	TxPoolSenderMaxBytes = cli.Uint64Flag{
+		Name:  "zkevm.txpool-sender-max-bytes",
+		Usage: "Maximum size in bytes of the transactions a sender can have in the pool, local ones included. 0 disables the limit",
+		Value: 0,
+	}
+	TxPoolHidePending = cli.BoolFlag{
+		Name:  "zkevm.txpool-hide-pending",
+		Usage: "Hide the transactions in the pool from the txpool and pending transaction APIs and from peers, as if every transaction was sent privately",
+		Value: false,
+	}
+	TxPoolPriceBump = cli.Uint64Flag{
+		Name:  "zkevm.txpool-price-bump",
+		Usage: "Minimum percentage the effective gas price of a replacement must be above the one of the transaction it replaces, 0 uses txpool.pricebump",
+		Value: 0,
+	}
+	GaslessPolicyFile = cli.StringFlag{
+		Name:  "zkevm.gasless-policy-file",
+		Usage: "Path to a json file with the rules zero priced transactions have to match to be accepted by the pool. Leave empty to accept any zero priced transaction the global gasless settings allow",
+		Value: "",
+	}
 	DisableVirtualCounters = cli.BoolFlag{
 		Name:  "zkevm.disable-virtual-counters",
 		Usage: "Disable the virtual counters. This has an effect on on sequencer node and when external executor is not enabled.",
 		Value: false,
 	}
+	SequencerHABackend = cli.StringFlag{
+		Name:  "zkevm.sequencer-ha-backend",
+		Usage: "Enable sequencer high availability using the given lease backend (file). Standby nodes follow the leader through zkevm.l2-datastreamer-url",
+		Value: "",
+	}
+	SequencerHALeasePath = cli.StringFlag{
+		Name:  "zkevm.sequencer-ha-lease-path",
+		Usage: "Location of the sequencer lease shared by all the sequencers taking part in the election",
+		Value: "",
+	}
+	SequencerHALeaseTTL = cli.StringFlag{
+		Name:  "zkevm.sequencer-ha-lease-ttl",
+		Usage: "How long the sequencer lease is valid for without being renewed. Defaults to 15s",
+		Value: "15s",
+	}
+	SequencerHANodeId = cli.StringFlag{
+		Name:  "zkevm.sequencer-ha-node-id",
+		Usage: "Unique id of this node in the sequencer election. Defaults to the hostname",
+		Value: "",
+	}
+	SequencerHAStreamUrl = cli.StringFlag{
+		Name:  "zkevm.sequencer-ha-stream-url",
+		Usage: "Address the other sequencers reach the datastream of this node at, kept in the lease while it leads so the standbys follow it. Standbys follow zkevm.l2-datastreamer-url until a leader sets it",
+		Value: "",
+	}
+	PendingBlockPollInterval = cli.StringFlag{
+		Name:  "zkevm.pending-block-poll-interval",
+		Usage: "How often an RPC node fetches the pending blocks from the sequencer at zkevm.l2-sequencer-rpc-url to serve the pending tag. 0 disables pending state",
+		Value: "0s",
+	}
+	PendingBlocksApi = cli.BoolFlag{
+		Name:  "zkevm.pending-blocks-api",
+		Usage: "Serve zkevm_getPendingBlocks on the sequencer for the RPC nodes to poll. The blocks are not final, only enable it where the rpc port is reachable by the RPC nodes alone",
+		Value: false,
+	}
+	SmtGcBatchSize = cli.Uint64Flag{
+		Name:  "zkevm.smt-gc-batch-size",
+		Usage: "Number of SMT nodes the garbage collector marks or sweeps each sync cycle, it has to be larger than the nodes written in a cycle for a pass to finish. 0 disables garbage collection",
+		Value: 0,
+	}
+	SmtGcRetainBlocks = cli.Uint64Flag{
+		Name:  "zkevm.smt-gc-retain-blocks",
+		Usage: "Number of recent blocks whose SMT nodes are kept by the garbage collector",
+		Value: 128,
+	}
+	SmtHistoryBlocks = cli.Uint64Flag{
+		Name:  "zkevm.smt-history-blocks",
+		Usage: "Number of recent blocks the SMT can be read at, used by zkevm_getProof and the witness RPCs. Replaced nodes are kept until garbage collection removes them so zkevm.smt-gc-batch-size must be set. 0 disables SMT history",
+		Value: 0,
+	}
+	SmtCacheSize = cli.IntFlag{
+		Name:  "zkevm.smt-cache-size",
+		Usage: "Number of SMT nodes the intermediate hashes stage keeps in memory between blocks, roughly 1KB each. 0 disables the cache",
+		Value: 0,
+	}
 	SupportGasless = cli.BoolFlag{
 		Name:  "zkevm.gasless",
 		Usage: "Support gasless transactions",
@@ -73,6 +73,8 @@ type EphemeralExecResult struct {
 	Difficulty       *math.HexOrDecimal256 `json:"currentDifficulty" gencodec:"required"`
 	GasUsed          math.HexOrDecimal64   `json:"gasUsed"`
 	StateSyncReceipt *types.Receipt        `json:"-"`
+	BlockInfoRoot    libcommon.Hash        `json:"-"` // [zkevm] only set from etrog
+	BlockInfo        *zktypes.BlockInfo    `json:"-"` // [zkevm] the values the block info tree was built from
 }
 
 // ExecuteBlockEphemerally runs a block from provided stateReader and
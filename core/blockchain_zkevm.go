@@ -34,6 +34,7 @@ import (
 	"github.com/ledgerwatch/erigon/core/vm"
 	"github.com/ledgerwatch/erigon/smt/pkg/blockinfo"
 	txTypes "github.com/ledgerwatch/erigon/zk/tx"
+	zktypes "github.com/ledgerwatch/erigon/zk/types"
 )
 
 // ExecuteBlockEphemerally runs a block from provided stateReader and
@@ -204,6 +205,7 @@ func ExecuteBlockEphemerallyZk(
 	}
 
 	var l2InfoRoot common.Hash
+	var blockInfo *zktypes.BlockInfo
 	if chainConfig.IsForkID7Etrog(blockNum) {
 		// [zkevm] - set the block info tree root
 		root, err := blockInfoTree.SetBlockGasUsed(*usedGas)
@@ -211,6 +213,7 @@ func ExecuteBlockEphemerallyZk(
 			return nil, err
 		}
 		l2InfoRoot = common.BigToHash(root)
+		blockInfo = blockInfoTree.Info()
 	}
 
 	ibs.PostExecuteStateSet(chainConfig, block.NumberU64(), &l2InfoRoot)
@@ -250,6 +253,9 @@ func ExecuteBlockEphemerallyZk(
 		Difficulty:  (*math.HexOrDecimal256)(header.Difficulty),
 		GasUsed:     math.HexOrDecimal64(*usedGas),
 		Rejected:    rejectedTxs,
+
+		BlockInfoRoot: l2InfoRoot,
+		BlockInfo:     blockInfo,
 	}
 
 	return execRs, nil
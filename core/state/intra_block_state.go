@@ -85,6 +85,9 @@ type IntraBlockState struct {
 	trace          bool
 	accessList     *accessList
 	balanceInc     map[libcommon.Address]*BalanceIncrease // Map of balance increases (without first reading the account)
+
+	// [zkevm] changes of the transactions of an open bundle
+	bundle *bundleJournal
 }
 
 // Create a new state from a given trie
@@ -134,6 +137,7 @@ func (sdb *IntraBlockState) Reset() {
 	sdb.bhash = libcommon.Hash{}
 	sdb.txIndex = 0
 	sdb.logSize = 0
+	sdb.bundle = nil
 }
 
 func (sdb *IntraBlockState) AddLog(log2 *types.Log) {
@@ -643,6 +647,7 @@ func (sdb *IntraBlockState) FinalizeTx(chainRules *chain.Rules, stateWriter Stat
 			sdb.getStateObject(addr)
 		}
 	}
+	var finalized []journalEntry
 	for addr := range sdb.journal.dirties {
 		so, exist := sdb.stateObjects[addr]
 		if !exist {
@@ -655,6 +660,9 @@ func (sdb *IntraBlockState) FinalizeTx(chainRules *chain.Rules, stateWriter Stat
 			continue
 		}
 
+		if sdb.bundle != nil {
+			finalized = append(finalized, newTxFinalizeChange(sdb, addr, so))
+		}
 		if err := updateAccount(chainRules.IsSpuriousDragon, chainRules.IsAura, stateWriter, addr, so, true); err != nil {
 			return err
 		}
@@ -662,6 +670,10 @@ func (sdb *IntraBlockState) FinalizeTx(chainRules *chain.Rules, stateWriter Stat
 		sdb.stateObjectsDirty[addr] = struct{}{}
 	}
 
+	if sdb.bundle != nil {
+		sdb.finalizeBundleTx(finalized)
+	}
+
 	// Invalidate journal because reverting across transactions is not allowed.
 	sdb.clearJournalAndRefund()
 	return nil
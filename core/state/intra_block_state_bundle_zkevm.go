@@ -0,0 +1,114 @@
+package state
+
+import (
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/holiman/uint256"
+
+	"github.com/ledgerwatch/erigon/chain"
+)
+
+// bundleJournal keeps the changes of the transactions of a bundle once they are finalized so the whole bundle can be
+// reverted.  The journal of the intra block state is cleared after every transaction.
+type bundleJournal struct {
+	entries []journalEntry
+}
+
+// keep takes the entries of a finalized transaction.  The access list and refund only live for a transaction so their
+// entries are dropped, reverting them against the state of a later transaction would be wrong.
+func (b *bundleJournal) keep(entries []journalEntry) {
+	for _, entry := range entries {
+		switch entry.(type) {
+		case accessListAddAccountChange, accessListAddSlotChange, refundChange:
+			continue
+		}
+		b.entries = append(b.entries, entry)
+	}
+}
+
+// txFinalizeChange undoes what FinalizeTx does to an account outside of the journal, it is only recorded while a
+// bundle is open
+type txFinalizeChange struct {
+	account     *libcommon.Address
+	wasDirty    bool
+	prevDeleted bool
+	prevOrigin  map[libcommon.Hash]*uint256.Int // nil when the key was not in the origin storage
+}
+
+func newTxFinalizeChange(sdb *IntraBlockState, addr libcommon.Address, so *stateObject) txFinalizeChange {
+	_, wasDirty := sdb.stateObjectsDirty[addr]
+	ch := txFinalizeChange{
+		account:     &addr,
+		wasDirty:    wasDirty,
+		prevDeleted: so.deleted,
+		prevOrigin:  make(map[libcommon.Hash]*uint256.Int, len(so.dirtyStorage)),
+	}
+	for key := range so.dirtyStorage {
+		if value, ok := so.originStorage[key]; ok {
+			ch.prevOrigin[key] = &value
+		} else {
+			ch.prevOrigin[key] = nil
+		}
+	}
+	return ch
+}
+
+func (ch txFinalizeChange) revert(s *IntraBlockState) {
+	so, ok := s.stateObjects[*ch.account]
+	if !ok {
+		return
+	}
+	so.deleted = ch.prevDeleted
+	for key, value := range ch.prevOrigin {
+		if value == nil {
+			delete(so.originStorage, key)
+		} else {
+			so.originStorage[key] = *value
+		}
+	}
+	if !ch.wasDirty {
+		delete(s.stateObjectsDirty, *ch.account)
+	}
+}
+
+func (ch txFinalizeChange) dirtied() *libcommon.Address {
+	return nil
+}
+
+// StartBundle opens a bundle, the transactions applied until EndBundle or RevertBundle can be reverted together.
+// Changes not yet finalized, like those made when a block starts, are finalized first so they are kept if the bundle
+// is reverted.
+func (sdb *IntraBlockState) StartBundle(chainRules *chain.Rules, stateWriter StateWriter) error {
+	if sdb.journal.length() > 0 {
+		if err := sdb.FinalizeTx(chainRules, stateWriter); err != nil {
+			return err
+		}
+	}
+	sdb.bundle = &bundleJournal{}
+	return nil
+}
+
+// EndBundle closes the bundle keeping its transactions
+func (sdb *IntraBlockState) EndBundle() {
+	sdb.bundle = nil
+}
+
+// RevertBundle reverts every transaction applied since StartBundle, including one that failed part way through, and
+// closes the bundle
+func (sdb *IntraBlockState) RevertBundle() {
+	if sdb.bundle == nil {
+		return
+	}
+	sdb.journal.revert(sdb, 0)
+	for i := len(sdb.bundle.entries) - 1; i >= 0; i-- {
+		sdb.bundle.entries[i].revert(sdb)
+	}
+	sdb.bundle = nil
+	sdb.clearJournalAndRefund()
+	sdb.accessList = newAccessList()
+}
+
+// finalizeBundleTx records the changes of a transaction applied within a bundle before the journal is cleared
+func (sdb *IntraBlockState) finalizeBundleTx(finalized []journalEntry) {
+	sdb.bundle.keep(sdb.journal.entries)
+	sdb.bundle.entries = append(sdb.bundle.entries, finalized...)
+}
@@ -0,0 +1,102 @@
+package state
+
+import (
+	"testing"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/holiman/uint256"
+
+	"github.com/ledgerwatch/erigon/chain"
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+)
+
+type emptyStateReader struct{}
+
+func (emptyStateReader) ReadAccountData(libcommon.Address) (*accounts.Account, error) {
+	return nil, nil
+}
+func (emptyStateReader) ReadAccountStorage(libcommon.Address, uint64, *libcommon.Hash) ([]byte, error) {
+	return nil, nil
+}
+func (emptyStateReader) ReadAccountCode(libcommon.Address, uint64, libcommon.Hash) ([]byte, error) {
+	return nil, nil
+}
+func (emptyStateReader) ReadAccountCodeSize(libcommon.Address, uint64, libcommon.Hash) (int, error) {
+	return 0, nil
+}
+func (emptyStateReader) ReadAccountIncarnation(libcommon.Address) (uint64, error) { return 0, nil }
+
+func TestRevertBundle(t *testing.T) {
+	rules := &chain.Rules{IsSpuriousDragon: true}
+	a := libcommon.HexToAddress("0xa")
+	b := libcommon.HexToAddress("0xb")
+	key := libcommon.HexToHash("0x1")
+
+	ibs := New(emptyStateReader{})
+	ibs.SetBalance(a, uint256.NewInt(100))
+	ibs.SetState(a, &key, *uint256.NewInt(1))
+
+	if err := ibs.StartBundle(rules, NewNoopWriter()); err != nil {
+		t.Fatal(err)
+	}
+
+	// a finalized transaction
+	ibs.Prepare(libcommon.HexToHash("0x01"), libcommon.Hash{}, 0)
+	ibs.AddAddressToAccessList(a)
+	ibs.AddSlotToAccessList(a, key)
+	ibs.SubBalance(a, uint256.NewInt(40))
+	ibs.AddBalance(b, uint256.NewInt(40))
+	ibs.SetState(a, &key, *uint256.NewInt(2))
+	if err := ibs.FinalizeTx(rules, NewNoopWriter()); err != nil {
+		t.Fatal(err)
+	}
+
+	// a transaction that fails part way through
+	ibs.Prepare(libcommon.HexToHash("0x02"), libcommon.Hash{}, 1)
+	ibs.SetNonce(a, 1)
+
+	ibs.RevertBundle()
+
+	if balance := ibs.GetBalance(a); balance.Uint64() != 100 {
+		t.Fatalf("expected balance 100, got %d", balance.Uint64())
+	}
+	if ibs.GetNonce(a) != 0 {
+		t.Fatalf("expected nonce 0, got %d", ibs.GetNonce(a))
+	}
+	if ibs.Exist(b) {
+		t.Fatal("expected account created by the bundle to be gone")
+	}
+	var value uint256.Int
+	ibs.GetState(a, &key, &value)
+	if value.Uint64() != 1 {
+		t.Fatalf("expected storage value 1, got %d", value.Uint64())
+	}
+	ibs.GetCommittedState(a, &key, &value)
+	if value.Uint64() != 1 {
+		t.Fatalf("expected committed storage value 1, got %d", value.Uint64())
+	}
+	if _, ok := ibs.stateObjectsDirty[b]; ok {
+		t.Fatal("expected account created by the bundle not to be dirty")
+	}
+}
+
+func TestEndBundle(t *testing.T) {
+	rules := &chain.Rules{IsSpuriousDragon: true}
+	a := libcommon.HexToAddress("0xa")
+
+	ibs := New(emptyStateReader{})
+	if err := ibs.StartBundle(rules, NewNoopWriter()); err != nil {
+		t.Fatal(err)
+	}
+	ibs.AddBalance(a, uint256.NewInt(10))
+	if err := ibs.FinalizeTx(rules, NewNoopWriter()); err != nil {
+		t.Fatal(err)
+	}
+	ibs.EndBundle()
+
+	// reverting once the bundle is closed does nothing
+	ibs.RevertBundle()
+	if balance := ibs.GetBalance(a); balance.Uint64() != 10 {
+		t.Fatalf("expected balance 10, got %d", balance.Uint64())
+	}
+}
@@ -145,3 +145,41 @@ func ApplyTransaction_zkevm(
 
 	return applyTransaction_zkevm(config, engine, gp, ibs, stateWriter, header, tx, usedGas, vmenv, cfg.Config, effectiveGasPricePercentage)
 }
+
+// ApplyTransactionWithCounters_zkevm is ApplyTransaction_zkevm collecting the virtual counters the transaction uses,
+// the state is reverted when the transaction can't be applied.  Whether the counters overflow is up to the caller,
+// usually by adding them to a vm.BatchCounterCollector.
+func ApplyTransactionWithCounters_zkevm(
+	config *chain.Config,
+	blockHashFunc func(n uint64) libcommon.Hash,
+	engine consensus.EngineReader,
+	author *libcommon.Address,
+	ibs *state.IntraBlockState,
+	header *types.Header,
+	tx types.Transaction,
+	cfg vm.ZkConfig,
+	excessDataGas *big.Int,
+	effectiveGasPricePercentage uint8,
+	smtDepth int,
+) (*types.Receipt, *ExecutionResult, *vm.TransactionCounter, error) {
+	txCounters := vm.NewTransactionCounter(tx, smtDepth, false)
+	if err := txCounters.CalculateRlp(); err != nil {
+		return nil, nil, nil, err
+	}
+	cfg.CounterCollector = txCounters.ExecutionCounters()
+
+	snapshot := ibs.Snapshot()
+	var usedGas uint64
+	gp := new(GasPool).AddGas(tx.GetGas())
+	receipt, result, err := ApplyTransaction_zkevm(config, blockHashFunc, engine, author, gp, ibs, state.NewNoopWriter(), header, tx, &usedGas, cfg, excessDataGas, effectiveGasPricePercentage)
+	if err != nil {
+		ibs.RevertToSnapshot(snapshot)
+		return nil, nil, nil, err
+	}
+	if err = txCounters.ProcessTx(ibs, result.ReturnData); err != nil {
+		ibs.RevertToSnapshot(snapshot)
+		return nil, nil, nil, err
+	}
+
+	return receipt, result, txCounters, nil
+}
@@ -86,6 +86,20 @@ func (c Counters) UsedAsMap() map[string]int {
 	}
 }
 
+// RemainingAsMap is UsedAsMap for the amounts left before the counters overflow
+func (c Counters) RemainingAsMap() map[string]int {
+	return map[string]int{
+		"SHA": c[SHA].remaining,
+		"A":   c[A].remaining,
+		"B":   c[B].remaining,
+		"K":   c[K].remaining,
+		"M":   c[M].remaining,
+		"P":   c[P].remaining,
+		"S":   c[S].remaining,
+		"D":   c[D].remaining,
+	}
+}
+
 type CounterKey string
 
 var (
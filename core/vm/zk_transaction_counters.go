@@ -175,3 +175,14 @@ func (tc *TransactionCounter) ExecutionCounters() *CounterCollector {
 func (tc *TransactionCounter) ProcessingCounters() *CounterCollector {
 	return tc.processingCounters
 }
+
+// UsedAsMap sums the rlp, execution and processing counters of the transaction, keyed like Counters.UsedAsMap
+func (tc *TransactionCounter) UsedAsMap() map[string]int {
+	used := map[string]int{}
+	for _, collector := range []*CounterCollector{tc.rlpCounters, tc.executionCounters, tc.processingCounters} {
+		for k, v := range collector.counters {
+			used[string(k)] += v.used
+		}
+	}
+	return used
+}
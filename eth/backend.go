@@ -33,6 +33,8 @@ import (
 
 	erigonchain "github.com/gateway-fm/cdk-erigon-lib/chain"
 	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/erigon/zk/events"
+	"github.com/ledgerwatch/erigon/zk/pending"
 	"github.com/ledgerwatch/erigon/zk/sequencer"
 	"github.com/ledgerwatch/log/v3"
 	"golang.org/x/exp/slices"
@@ -192,9 +194,13 @@ type Ethereum struct {
 	kvRPC          *remotedbserver.KvServer
 
 	// zk
-	dataStream *datastreamer.StreamServer
-	l1Syncer   *syncer.L1Syncer
-	etherMan   *etherman.Client
+	dataStream       *datastreamer.StreamServer
+	l1Syncer         *syncer.L1Syncer
+	etherMan         *etherman.Client
+	sequencerElector *sequencer.Elector
+	pendingStore     *pending.Store
+	pendingPoller    *pending.Poller
+	zkEvents         *events.Events
 
 	preStartTasks *PreStartTasks
 }
@@ -785,6 +791,17 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			cfg.L1QueryBlocksThreads,
 		)
 
+		// batch and l1 events from the stages for zkevm_subscribe
+		backend.zkEvents = events.NewEvents()
+		backend.notifications.ZkEvents = backend.zkEvents
+
+		// decoded smt nodes kept across stage loop cycles
+		if cfg.SmtCacheSize > 0 {
+			if backend.notifications.SmtCache, err = db.NewNodeCache(cfg.SmtCacheSize); err != nil {
+				return nil, err
+			}
+		}
+
 		if isSequencer {
 			// if we are sequencing transactions, we do the sequencing loop...
 			witnessGenerator := witness.NewGenerator(
@@ -833,6 +850,28 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 				cfg.L1QueryBlocksThreads,
 			)
 
+			// in HA mode the leader is elected through the lease backend and standbys follow its datastream, the one
+			// it advertises in the lease or the configured one until it does
+			var leaderStream *client.StreamClient
+			var dialLeaderStream func(url string) zkStages.DatastreamClient
+			if cfg.IsSequencerHA() {
+				leaseBackend, err := sequencer.NewLeaseBackend(cfg.SequencerHABackend, cfg.SequencerHALeasePath)
+				if err != nil {
+					return nil, err
+				}
+				backend.sequencerElector = sequencer.NewElector(leaseBackend, cfg.SequencerHANodeId, cfg.SequencerHAStreamUrl, cfg.SequencerHALeaseTTL)
+				leaderStream = client.NewClient(cfg.L2DataStreamerUrl, cfg.DatastreamVersion, cfg.L2DataStreamerTimeout)
+				dialLeaderStream = func(url string) zkStages.DatastreamClient {
+					return client.NewClient(url, cfg.DatastreamVersion, cfg.L2DataStreamerTimeout)
+				}
+			}
+
+			// blocks that are not in the datastream yet are served to rpc nodes as pending state, unless the pool hides
+			// every transaction until it is mined
+			if !cfg.TxPoolHidePending {
+				backend.pendingStore = pending.NewStore()
+			}
+
 			backend.syncStages = stages2.NewSequencerZkStages(
 				backend.sentryCtx,
 				backend.chainDB,
@@ -850,6 +889,11 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 				backend.txPool2,
 				backend.txPool2DB,
 				verifier,
+				backend.sequencerElector,
+				leaderStream,
+				dialLeaderStream,
+				backend.pendingStore,
+				backend.zkEvents,
 			)
 
 			backend.syncUnwindOrder = zkStages.ZkSequencerUnwindOrder
@@ -868,6 +912,11 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 
 			streamClient := initDataStreamClient(cfg.Zk)
 
+			if cfg.PendingBlockPollInterval > 0 && cfg.L2RpcUrl != "" {
+				backend.pendingStore = pending.NewStore()
+				backend.pendingPoller = pending.NewPoller(cfg.L2RpcUrl, cfg.PendingBlockPollInterval, backend.pendingStore)
+			}
+
 			backend.syncStages = stages2.NewDefaultZkStages(
 				backend.sentryCtx,
 				backend.chainDB,
@@ -882,11 +931,12 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 				backend.l1Syncer,
 				streamClient,
 				backend.dataStream,
+				backend.zkEvents,
 			)
 
 			backend.syncUnwindOrder = zkStages.ZkUnwindOrder
+			backend.syncPruneOrder = zkStages.ZkPruneOrder
 		}
-		// TODO: SEQ: prune order
 
 	} else {
 		backend.syncStages = stages2.NewDefaultStages(backend.sentryCtx, backend.chainDB, stack.Config().P2P, config, backend.sentriesClient, backend.notifications, backend.downloaderClient, allSnapshots, backend.agg, backend.forkValidator, backend.engine)
@@ -994,7 +1044,7 @@ func (backend *Ethereum) Init(stack *node.Node, config *ethconfig.Config) error
 	if casted, ok := backend.engine.(*bor.Bor); ok {
 		borDb = casted.DB
 	}
-	apiList := commands.APIList(chainKv, borDb, ethRpcClient, txPoolRpcClient, miningRpcClient, ff, stateCache, blockReader, backend.agg, httpRpcCfg, backend.engine, config, backend.l1Syncer)
+	apiList := commands.APIList(chainKv, borDb, ethRpcClient, txPoolRpcClient, miningRpcClient, ff, stateCache, blockReader, backend.agg, httpRpcCfg, backend.engine, config, backend.l1Syncer, backend.pendingStore, backend.txPool2, backend.txPool2DB, backend.zkEvents)
 	authApiList := commands.AuthAPIList(chainKv, ethRpcClient, txPoolRpcClient, miningRpcClient, ff, stateCache, blockReader, backend.agg, httpRpcCfg, backend.engine, config)
 	go func() {
 		if err := cli.StartRpcServer(ctx, httpRpcCfg, apiList, authApiList); err != nil {
@@ -1333,6 +1383,14 @@ func (s *Ethereum) Start() error {
 	s.sentriesClient.StartStreamLoops(s.sentryCtx)
 	time.Sleep(10 * time.Millisecond) // just to reduce logs order confusion
 
+	if s.sequencerElector != nil {
+		s.sequencerElector.Start(s.sentryCtx)
+	}
+
+	if s.pendingPoller != nil {
+		s.pendingPoller.Start(s.sentryCtx)
+	}
+
 	go stages2.StageLoop(s.sentryCtx, s.chainConfig, s.chainDB, s.stagedSync, s.sentriesClient.Hd, s.notifications, s.sentriesClient.UpdateHead, s.waitForStageLoopStop, s.config.Sync.LoopThrottle)
 
 	return nil
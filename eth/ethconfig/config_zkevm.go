@@ -4,6 +4,10 @@ import (
 	"time"
 
 	"github.com/gateway-fm/cdk-erigon-lib/common"
+
+	"github.com/ledgerwatch/erigon/zk/acl"
+	"github.com/ledgerwatch/erigon/zk/gasless"
+	"github.com/ledgerwatch/erigon/zk/ratelimit"
 )
 
 type Zk struct {
@@ -30,6 +34,7 @@ type Zk struct {
 	SequencerBlockSealTime                 time.Duration
 	SequencerBatchSealTime                 time.Duration
 	SequencerNonEmptyBatchSealTime         time.Duration
+	SequencerCounterAwareSelection         bool
 	ExecutorUrls                           []string
 	ExecutorStrictMode                     bool
 	L1QueryBlocksThreads                   uint64
@@ -54,6 +59,34 @@ type Zk struct {
 
 	PoolManagerUrl         string
 	DisableVirtualCounters bool
+
+	RpcTxPreValidation     bool
+	RpcTxSimulation        bool
+	RpcTxSenderRateLimiter *ratelimit.Limiter[common.Address]
+	RpcTxACL               *acl.ACL
+
+	TxPoolQueuedTTL      time.Duration
+	TxPoolPendingTTL     time.Duration
+	TxPoolSenderMaxSlots uint64
+	TxPoolSenderMaxBytes uint64
+	TxPoolHidePending    bool
+	TxPoolPriceBump      uint64
+
+	GaslessPolicy *gasless.Policy
+
+	SequencerHABackend   string
+	SequencerHALeasePath string
+	SequencerHALeaseTTL  time.Duration
+	SequencerHANodeId    string
+	SequencerHAStreamUrl string
+
+	PendingBlockPollInterval time.Duration
+	PendingBlocksApi         bool
+
+	SmtGcBatchSize    uint64
+	SmtGcRetainBlocks uint64
+	SmtHistoryBlocks  uint64
+	SmtCacheSize      int
 }
 
 var DefaultZkConfig = &Zk{}
@@ -62,6 +95,34 @@ func (c *Zk) ShouldCountersBeUnlimited() bool {
 	return c.DisableVirtualCounters && !c.ExecutorStrictMode && len(c.ExecutorUrls) != 0
 }
 
+func (c *Zk) IsSequencerHA() bool {
+	return c.SequencerHABackend != ""
+}
+
 func (c *Zk) HasExecutors() bool {
 	return len(c.ExecutorUrls) > 0 && c.ExecutorUrls[0] != ""
 }
+
+// EffectiveGasPricePercentage is the percentage of its gas price a transaction pays depending on what it does
+func (c *Zk) EffectiveGasPricePercentage(to *common.Address, data []byte) uint8 {
+	if to == nil {
+		return c.EffectiveGasPriceForContractDeployment
+	}
+
+	dataLen := len(data)
+	if dataLen != 0 {
+		if dataLen >= 8 {
+			// transfer's method id 0xa9059cbb
+			isTransfer := data[0] == 169 && data[1] == 5 && data[2] == 156 && data[3] == 187
+			// transfer's method id 0x23b872dd
+			isTransferFrom := data[0] == 35 && data[1] == 184 && data[2] == 114 && data[3] == 221
+			if isTransfer || isTransferFrom {
+				return c.EffectiveGasPriceForErc20Transfer
+			}
+		}
+
+		return c.EffectiveGasPriceForContractInvocation
+	}
+
+	return c.EffectiveGasPriceForEthTransfer
+}
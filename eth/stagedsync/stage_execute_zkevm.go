@@ -147,6 +147,15 @@ Loop:
 			break Loop
 		}
 
+		if execRs.BlockInfo != nil {
+			if err = hermezDb.WriteBlockInfoRoot(blockNum, execRs.BlockInfoRoot); err != nil {
+				return err
+			}
+			if err = hermezDb.WriteBlockInfoTree(blockNum, execRs.BlockInfo); err != nil {
+				return err
+			}
+		}
+
 		// exec loop variables
 		header.GasUsed = uint64(execRs.GasUsed)
 		header.ReceiptHash = types.DeriveSha(execRs.Receipts)
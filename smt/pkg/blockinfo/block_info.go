@@ -8,6 +8,7 @@ import (
 
 	"github.com/ledgerwatch/erigon/smt/pkg/smt"
 	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+	zktypes "github.com/ledgerwatch/erigon/zk/types"
 
 	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
 )
@@ -18,6 +19,9 @@ const (
 
 type BlockInfoTree struct {
 	smt *smt.SMT
+
+	// info records the values inserted so the tree can be stored and rebuilt later
+	info zktypes.BlockInfo
 }
 
 func NewBlockInfoTree() *BlockInfoTree {
@@ -25,41 +29,74 @@ func NewBlockInfoTree() *BlockInfoTree {
 		smt: smt.NewSMT(nil),
 	}
 }
+
+// BuildBlockInfoTree rebuilds the tree of a block from the values recorded by Info
+func BuildBlockInfoTree(info *zktypes.BlockInfo) (*BlockInfoTree, error) {
+	b := NewBlockInfoTree()
+	if err := b.InitBlockHeader(&info.ParentHash, &info.Coinbase, info.BlockNumber, info.GasLimit, info.Timestamp, &info.Ger, &info.L1BlockHash); err != nil {
+		return nil, err
+	}
+	for _, tx := range info.Txs {
+		if err := b.setTx(tx); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := b.SetBlockGasUsed(info.GasUsed); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
 func (b *BlockInfoTree) GetRoot() *big.Int {
 	return b.smt.LastRoot()
 }
 
+// Info returns the values inserted into the tree so far
+func (b *BlockInfoTree) Info() *zktypes.BlockInfo {
+	return &b.info
+}
+
+// GetProof returns the path from the root of the tree to a key
+func (b *BlockInfoTree) GetProof(key utils.NodeKey) (*smt.Proof, error) {
+	return b.smt.GetProof(key, utils.ScalarToRoot(b.smt.LastRoot()))
+}
+
 func (b *BlockInfoTree) InitBlockHeader(oldBlockHash *libcommon.Hash, coinbase *libcommon.Address, blockNumber, gasLimit, timestamp uint64, ger, l1BlochHash *libcommon.Hash) error {
-	_, err := setL2BlockHash(b.smt, oldBlockHash)
-	if err != nil {
+	var leaves leafBatch
+	if err := leaves.addL2BlockHash(oldBlockHash); err != nil {
 		return err
 	}
-	_, err = setCoinbase(b.smt, coinbase)
-	if err != nil {
+	if err := leaves.addCoinbase(coinbase); err != nil {
 		return err
 	}
-
-	_, err = setBlockNumber(b.smt, blockNumber)
-	if err != nil {
+	if err := leaves.addBlockNumber(blockNumber); err != nil {
 		return err
 	}
-
-	_, err = setGasLimit(b.smt, gasLimit)
-	if err != nil {
+	if err := leaves.addGasLimit(gasLimit); err != nil {
 		return err
 	}
-	_, err = setTimestamp(b.smt, timestamp)
-	if err != nil {
+	if err := leaves.addTimestamp(timestamp); err != nil {
 		return err
 	}
-	_, err = setGer(b.smt, ger)
-	if err != nil {
+	if err := leaves.addGer(ger); err != nil {
 		return err
 	}
-	_, err = setL1BlockHash(b.smt, l1BlochHash)
-	if err != nil {
+	if err := leaves.addL1BlockHash(l1BlochHash); err != nil {
 		return err
 	}
+	if _, err := leaves.insert(b.smt); err != nil {
+		return err
+	}
+
+	b.info = zktypes.BlockInfo{
+		ParentHash:  *oldBlockHash,
+		Coinbase:    *coinbase,
+		BlockNumber: blockNumber,
+		GasLimit:    gasLimit,
+		Timestamp:   timestamp,
+		Ger:         *ger,
+		L1BlockHash: *l1BlochHash,
+	}
 	return nil
 }
 
@@ -71,22 +108,12 @@ func (b *BlockInfoTree) SetBlockTx(
 	cumulativeGasUsed uint64,
 	effectivePercentage uint8,
 ) (*big.Int, error) {
-	txIndexBig := big.NewInt(int64(txIndex))
-	_, err := setL2TxHash(b.smt, txIndexBig, l2TxHash.Big())
-	if err != nil {
-		return nil, err
-	}
-
-	bigStatus := big.NewInt(0).SetUint64(receipt.Status)
-	_, err = setTxStatus(b.smt, txIndexBig, bigStatus)
-	if err != nil {
-		return nil, err
-	}
-
-	bigCumulativeGasUsed := big.NewInt(0).SetUint64(cumulativeGasUsed)
-	_, err = setCumulativeGasUsed(b.smt, txIndexBig, bigCumulativeGasUsed)
-	if err != nil {
-		return nil, err
+	tx := zktypes.BlockInfoTx{
+		Index:               txIndex,
+		L2TxHash:            *l2TxHash,
+		Status:              receipt.Status,
+		CumulativeGasUsed:   cumulativeGasUsed,
+		EffectivePercentage: effectivePercentage,
 	}
 
 	// now encode the logs
@@ -103,23 +130,49 @@ func (b *BlockInfoTree) SetBlockTx(
 			return nil, err
 		}
 
-		logEncodedBig := utils.ConvertHexToBigInt(hash)
-		_, err = setTxLog(b.smt, txIndexBig, big.NewInt(logIndex), logEncodedBig)
-		if err != nil {
-			return nil, err
-		}
+		tx.Logs = append(tx.Logs, zktypes.BlockInfoLog{
+			Index: logIndex,
+			Hash:  libcommon.BigToHash(utils.ConvertHexToBigInt(hash)),
+		})
 
 		// increment log index
 		logIndex += 1
 	}
 
-	bigEffectivePercentage := big.NewInt(0).SetUint64(uint64(effectivePercentage))
-	root, err := setTxEffectivePercentage(b.smt, txIndexBig, bigEffectivePercentage)
-	if err != nil {
+	if err := b.setTx(tx); err != nil {
 		return nil, err
 	}
 
-	return root, nil
+	return b.smt.LastRoot(), nil
+}
+
+func (b *BlockInfoTree) setTx(tx zktypes.BlockInfoTx) error {
+	txIndexBig := big.NewInt(int64(tx.Index))
+
+	var leaves leafBatch
+	if err := leaves.addL2TxHash(txIndexBig, tx.L2TxHash.Big()); err != nil {
+		return err
+	}
+	if err := leaves.addTxStatus(txIndexBig, big.NewInt(0).SetUint64(tx.Status)); err != nil {
+		return err
+	}
+	if err := leaves.addCumulativeGasUsed(txIndexBig, big.NewInt(0).SetUint64(tx.CumulativeGasUsed)); err != nil {
+		return err
+	}
+	for _, log := range tx.Logs {
+		if err := leaves.addTxLog(txIndexBig, big.NewInt(log.Index), log.Hash.Big()); err != nil {
+			return err
+		}
+	}
+	if err := leaves.addTxEffectivePercentage(txIndexBig, big.NewInt(0).SetUint64(uint64(tx.EffectivePercentage))); err != nil {
+		return err
+	}
+	if _, err := leaves.insert(b.smt); err != nil {
+		return err
+	}
+
+	b.info.Txs = append(b.info.Txs, tx)
+	return nil
 }
 
 func (b *BlockInfoTree) SetBlockGasUsed(gasUsed uint64) (*big.Int, error) {
@@ -132,16 +185,30 @@ func (b *BlockInfoTree) SetBlockGasUsed(gasUsed uint64) (*big.Int, error) {
 	if err != nil {
 		return nil, err
 	}
+	b.info.GasUsed = gasUsed
 
 	return resp.NewRootScalar.ToBigInt(), nil
 }
 
-func setL2TxHash(smt *smt.SMT, txIndex *big.Int, l2TxHash *big.Int) (*big.Int, error) {
-	key, err := KeyTxHash(txIndex)
+// leafBatch collects the leaves of a block header or a transaction so they are inserted, and their hashes computed,
+// in one batch
+type leafBatch struct {
+	keys   []*utils.NodeKey
+	values []*utils.NodeValue8
+}
+
+func (l *leafBatch) add(key utils.NodeKey, value *big.Int) error {
+	v, err := utils.NodeValue8FromBigIntArray(utils.ScalarToArrayBig(value))
 	if err != nil {
-		return nil, err
+		return err
 	}
-	resp, err := smt.InsertKA(key, l2TxHash)
+	l.keys = append(l.keys, &key)
+	l.values = append(l.values, v)
+	return nil
+}
+
+func (l *leafBatch) insert(smt *smt.SMT) (*big.Int, error) {
+	resp, err := smt.InsertBatch("", l.keys, l.values, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -149,151 +216,98 @@ func setL2TxHash(smt *smt.SMT, txIndex *big.Int, l2TxHash *big.Int) (*big.Int, e
 	return resp.NewRootScalar.ToBigInt(), nil
 }
 
-func setTxStatus(smt *smt.SMT, txIndex *big.Int, status *big.Int) (*big.Int, error) {
-	key, err := KeyTxStatus(txIndex)
+func (l *leafBatch) addL2TxHash(txIndex *big.Int, l2TxHash *big.Int) error {
+	key, err := KeyTxHash(txIndex)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	resp, err := smt.InsertKA(key, status)
+	return l.add(key, l2TxHash)
+}
+
+func (l *leafBatch) addTxStatus(txIndex *big.Int, status *big.Int) error {
+	key, err := KeyTxStatus(txIndex)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	return resp.NewRootScalar.ToBigInt(), nil
+	return l.add(key, status)
 }
 
-func setCumulativeGasUsed(smt *smt.SMT, txIndex, cumulativeGasUsed *big.Int) (*big.Int, error) {
+func (l *leafBatch) addCumulativeGasUsed(txIndex, cumulativeGasUsed *big.Int) error {
 	key, err := KeyCumulativeGasUsed(txIndex)
 	if err != nil {
-		return nil, err
-	}
-	resp, err := smt.InsertKA(key, cumulativeGasUsed)
-	if err != nil {
-		return nil, err
+		return err
 	}
-
-	return resp.NewRootScalar.ToBigInt(), nil
+	return l.add(key, cumulativeGasUsed)
 }
 
-func setTxEffectivePercentage(smt *smt.SMT, txIndex, effectivePercentage *big.Int) (*big.Int, error) {
+func (l *leafBatch) addTxEffectivePercentage(txIndex, effectivePercentage *big.Int) error {
 	key, err := KeyEffectivePercentage(txIndex)
 	if err != nil {
-		return nil, err
-	}
-	resp, err := smt.InsertKA(key, effectivePercentage)
-	if err != nil {
-		return nil, err
+		return err
 	}
-
-	return resp.NewRootScalar.ToBigInt(), nil
+	return l.add(key, effectivePercentage)
 }
 
-func setTxLog(smt *smt.SMT, txIndex *big.Int, logIndex *big.Int, log *big.Int) (*big.Int, error) {
+func (l *leafBatch) addTxLog(txIndex *big.Int, logIndex *big.Int, log *big.Int) error {
 	key, err := KeyTxLogs(txIndex, logIndex)
 	if err != nil {
-		return nil, err
-	}
-	resp, err := smt.InsertKA(key, log)
-	if err != nil {
-		return nil, err
+		return err
 	}
-
-	return resp.NewRootScalar.ToBigInt(), nil
+	return l.add(key, log)
 }
 
-func setL2BlockHash(smt *smt.SMT, blockHash *libcommon.Hash) (*big.Int, error) {
+func (l *leafBatch) addL2BlockHash(blockHash *libcommon.Hash) error {
 	key, err := KeyBlockHeaderParams(big.NewInt(IndexBlockHeaderParamBlockHash))
 	if err != nil {
-		return nil, err
-	}
-	resp, err := smt.InsertKA(key, blockHash.Big())
-	if err != nil {
-		return nil, err
+		return err
 	}
-
-	return resp.NewRootScalar.ToBigInt(), nil
+	return l.add(key, blockHash.Big())
 }
 
-func setCoinbase(smt *smt.SMT, coinbase *libcommon.Address) (*big.Int, error) {
+func (l *leafBatch) addCoinbase(coinbase *libcommon.Address) error {
 	key, err := KeyBlockHeaderParams(big.NewInt(IndexBlockHeaderParamCoinbase))
 	if err != nil {
-		return nil, err
-	}
-	resp, err := smt.InsertKA(key, coinbase.Hash().Big())
-	if err != nil {
-		return nil, err
+		return err
 	}
-
-	return resp.NewRootScalar.ToBigInt(), nil
+	return l.add(key, coinbase.Hash().Big())
 }
 
-func setGasLimit(smt *smt.SMT, gasLimit uint64) (*big.Int, error) {
+func (l *leafBatch) addGasLimit(gasLimit uint64) error {
 	key, err := KeyBlockHeaderParams(big.NewInt(IndexBlockHeaderParamGasLimit))
 	if err != nil {
-		return nil, err
-	}
-	gasLimitBig := big.NewInt(0).SetUint64(gasLimit)
-
-	resp, err := smt.InsertKA(key, gasLimitBig)
-	if err != nil {
-		return nil, err
+		return err
 	}
-
-	return resp.NewRootScalar.ToBigInt(), nil
+	return l.add(key, big.NewInt(0).SetUint64(gasLimit))
 }
 
-func setBlockNumber(smt *smt.SMT, blockNumber uint64) (*big.Int, error) {
+func (l *leafBatch) addBlockNumber(blockNumber uint64) error {
 	key, err := KeyBlockHeaderParams(big.NewInt(IndexBlockHeaderParamNumber))
 	if err != nil {
-		return nil, err
-	}
-
-	blockNumberBig := big.NewInt(0).SetUint64(blockNumber)
-	resp, err := smt.InsertKA(key, blockNumberBig)
-	if err != nil {
-		return nil, err
+		return err
 	}
-
-	return resp.NewRootScalar.ToBigInt(), nil
+	return l.add(key, big.NewInt(0).SetUint64(blockNumber))
 }
 
-func setTimestamp(smt *smt.SMT, timestamp uint64) (*big.Int, error) {
+func (l *leafBatch) addTimestamp(timestamp uint64) error {
 	key, err := KeyBlockHeaderParams(big.NewInt(IndexBlockHeaderParamTimestamp))
 	if err != nil {
-		return nil, err
-	}
-	timestampBig := big.NewInt(0).SetUint64(timestamp)
-
-	resp, err := smt.InsertKA(key, timestampBig)
-	if err != nil {
-		return nil, err
+		return err
 	}
-
-	return resp.NewRootScalar.ToBigInt(), nil
+	return l.add(key, big.NewInt(0).SetUint64(timestamp))
 }
 
-func setGer(smt *smt.SMT, ger *libcommon.Hash) (*big.Int, error) {
+func (l *leafBatch) addGer(ger *libcommon.Hash) error {
 	key, err := KeyBlockHeaderParams(big.NewInt(IndexBlockHeaderParamGer))
 	if err != nil {
-		return nil, err
-	}
-	resp, err := smt.InsertKA(key, ger.Big())
-	if err != nil {
-		return nil, err
+		return err
 	}
-
-	return resp.NewRootScalar.ToBigInt(), nil
+	return l.add(key, ger.Big())
 }
 
-func setL1BlockHash(smt *smt.SMT, blockHash *libcommon.Hash) (*big.Int, error) {
+func (l *leafBatch) addL1BlockHash(blockHash *libcommon.Hash) error {
 	key, err := KeyBlockHeaderParams(big.NewInt(IndexBlockHeaderParamBlockHashL1))
 	if err != nil {
-		return nil, err
-	}
-	resp, err := smt.InsertKA(key, blockHash.Big())
-	if err != nil {
-		return nil, err
+		return err
 	}
-
-	return resp.NewRootScalar.ToBigInt(), nil
+	return l.add(key, blockHash.Big())
 }
@@ -266,7 +266,7 @@ func TestSetL2BlockHash(t *testing.T) {
 		smt := smt.NewSMT(nil)
 		blockHash := common.HexToHash(test.blockHash)
 
-		root, err := setL2BlockHash(smt, &blockHash)
+		root, err := insertLeaves(smt, func(l *leafBatch) error { return l.addL2BlockHash(&blockHash) })
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -293,7 +293,7 @@ func TestSetCoinbase(t *testing.T) {
 		smt := smt.NewSMT(nil)
 		coinbaseAddress := common.HexToAddress(test.coinbaseAddress)
 
-		root, err := setCoinbase(smt, &coinbaseAddress)
+		root, err := insertLeaves(smt, func(l *leafBatch) error { return l.addCoinbase(&coinbaseAddress) })
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -322,7 +322,7 @@ func TestSetBlockNumber(t *testing.T) {
 	for i, test := range tests {
 		smt := smt.NewSMT(nil)
 
-		root, err := setBlockNumber(smt, test.blockNum)
+		root, err := insertLeaves(smt, func(l *leafBatch) error { return l.addBlockNumber(test.blockNum) })
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -348,7 +348,7 @@ func TestSetGasLimit(t *testing.T) {
 	for i, test := range tests {
 		smt := smt.NewSMT(nil)
 
-		root, err := setGasLimit(smt, test.gasLimit)
+		root, err := insertLeaves(smt, func(l *leafBatch) error { return l.addGasLimit(test.gasLimit) })
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -374,7 +374,7 @@ func TestSetTimestamp(t *testing.T) {
 	for i, test := range tests {
 		smt := smt.NewSMT(nil)
 
-		root, err := setTimestamp(smt, test.timestamp)
+		root, err := insertLeaves(smt, func(l *leafBatch) error { return l.addTimestamp(test.timestamp) })
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -407,7 +407,7 @@ func TestSetGer(t *testing.T) {
 		smt := smt.NewSMT(nil)
 		ger := common.HexToHash(test.ger)
 
-		root, err := setGer(smt, &ger)
+		root, err := insertLeaves(smt, func(l *leafBatch) error { return l.addGer(&ger) })
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -440,7 +440,7 @@ func TestSetL1BlockHash(t *testing.T) {
 		smt := smt.NewSMT(nil)
 		l1BlockHash := common.HexToHash(test.l1BlockHash)
 
-		root, err := setL1BlockHash(smt, &l1BlockHash)
+		root, err := insertLeaves(smt, func(l *leafBatch) error { return l.addL1BlockHash(&l1BlockHash) })
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -457,7 +457,7 @@ func TestSetL2TxHash(t *testing.T) {
 	txIndex := big.NewInt(1)
 	l2TxHash := common.HexToHash("0x000000000000000000000000000000005Ca1aB1E").Big()
 
-	root, err := setL2TxHash(smt, txIndex, l2TxHash)
+	root, err := insertLeaves(smt, func(l *leafBatch) error { return l.addL2TxHash(txIndex, l2TxHash) })
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -476,7 +476,7 @@ func TestSetTxStatus(t *testing.T) {
 	txIndex := big.NewInt(1)
 	status := common.HexToHash("0x000000000000000000000000000000005Ca1aB1E").Big()
 
-	root, err := setTxStatus(smt, txIndex, status)
+	root, err := insertLeaves(smt, func(l *leafBatch) error { return l.addTxStatus(txIndex, status) })
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -495,7 +495,7 @@ func TestSetCumulativeGasUsed(t *testing.T) {
 	txIndex := big.NewInt(1)
 	cgu := common.HexToHash("0x000000000000000000000000000000005Ca1aB1E").Big()
 
-	root, err := setCumulativeGasUsed(smt, txIndex, cgu)
+	root, err := insertLeaves(smt, func(l *leafBatch) error { return l.addCumulativeGasUsed(txIndex, cgu) })
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -514,7 +514,7 @@ func TestSetTxEffectivePercentage(t *testing.T) {
 	txIndex := big.NewInt(1)
 	egp := common.HexToHash("0x000000000000000000000000000000005Ca1aB1E").Big()
 
-	root, err := setTxEffectivePercentage(smt, txIndex, egp)
+	root, err := insertLeaves(smt, func(l *leafBatch) error { return l.addTxEffectivePercentage(txIndex, egp) })
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -534,7 +534,7 @@ func TestSetTxLogs(t *testing.T) {
 	logIndex := big.NewInt(1)
 	log := common.HexToHash("0x000000000000000000000000000000005Ca1aB1E").Big()
 
-	root, err := setTxLog(smt, txIndex, logIndex, log)
+	root, err := insertLeaves(smt, func(l *leafBatch) error { return l.addTxLog(txIndex, logIndex, log) })
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -547,3 +547,76 @@ func TestSetTxLogs(t *testing.T) {
 		t.Fatalf("expected root %s, got %s", expectedRoot, actualRoot)
 	}
 }
+
+func TestBuildBlockInfoTree(t *testing.T) {
+	parentHash := common.HexToHash("0x1fe466d9df83e1d2a4c32e21c6078b8f5f590e7db30b006965faa2f27a9b4fea")
+	coinbase := common.HexToAddress("0x617b3a3528F9cDd6630fd3301B9c8911F7Bf063D")
+	ger := common.HexToHash("0x01")
+	l1BlockHash := common.HexToHash("0x02")
+
+	infoTree := NewBlockInfoTree()
+	if err := infoTree.InitBlockHeader(&parentHash, &coinbase, 5, 4294967295, 1944498031, &ger, &l1BlockHash); err != nil {
+		t.Fatal(err)
+	}
+
+	receipts := []*ethTypes.Receipt{
+		{
+			Status: 1,
+			Logs: []*types.Log{
+				{Topics: []common.Hash{common.HexToHash("0x01")}, Data: []byte{1, 2, 3}},
+				{Topics: []common.Hash{common.HexToHash("0x02"), common.HexToHash("0x03")}},
+			},
+		},
+		{Status: 0},
+	}
+	var logIndex int64
+	var cumulativeGasUsed uint64
+	for i, receipt := range receipts {
+		l2TxHash := common.BigToHash(big.NewInt(int64(i + 1)))
+		cumulativeGasUsed += 21000
+		if _, err := infoTree.SetBlockTx(&l2TxHash, i, receipt, logIndex, cumulativeGasUsed, 255); err != nil {
+			t.Fatal(err)
+		}
+		logIndex += int64(len(receipt.Logs))
+	}
+	root, err := infoTree.SetBlockGasUsed(cumulativeGasUsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rebuilt, err := BuildBlockInfoTree(infoTree.Info())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rebuilt.GetRoot().Cmp(root) != 0 {
+		t.Fatalf("expected rebuilt root %x, got %x", root, rebuilt.GetRoot())
+	}
+
+	info := rebuilt.Info()
+	if len(info.Txs) != 2 || len(info.Txs[0].Logs) != 2 || info.Txs[0].Logs[1].Index != 1 {
+		t.Fatalf("unexpected recorded txs %+v", info.Txs)
+	}
+
+	key, err := KeyCumulativeGasUsed(big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := rebuilt.GetProof(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proof.Value[0].Uint64() != 42000 {
+		t.Fatalf("expected proven cumulative gas used 42000, got %d", proof.Value[0].Uint64())
+	}
+	if len(proof.Siblings) == 0 {
+		t.Fatal("expected a proof with siblings")
+	}
+}
+
+func insertLeaves(s *smt.SMT, add func(l *leafBatch) error) (*big.Int, error) {
+	var leaves leafBatch
+	if err := add(&leaves); err != nil {
+		return nil, err
+	}
+	return leaves.insert(s)
+}
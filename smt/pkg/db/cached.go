@@ -0,0 +1,222 @@
+package db
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/VictoriaMetrics/metrics"
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+)
+
+var (
+	smtCacheHits   = metrics.GetOrCreateCounter(`smt_cache_total{result="hit"}`)
+	smtCacheMisses = metrics.GetOrCreateCounter(`smt_cache_total{result="miss"}`)
+)
+
+// NodeCache is an LRU of decoded SMT nodes which can be shared by the CachedDbs of successive transactions.  Nodes are
+// keyed by their hash so a cached node is never out of date, it only has to be dropped when the node is deleted.
+// Every path through the tree starts at the root so the upper levels stay at the front of the LRU.
+//
+// Nodes read or written through a CachedDb may only exist in a transaction which is later rolled back, so they are
+// staged and only enter the LRU on Flush, once the owner of the transaction has committed it.
+type NodeCache struct {
+	nodes  *lru.Cache[utils.NodeKey, utils.NodeValue12]
+	size   int
+	hits   atomic.Uint64
+	misses atomic.Uint64
+
+	lock   sync.Mutex
+	staged map[utils.NodeKey]utils.NodeValue12
+}
+
+func NewNodeCache(size int) (*NodeCache, error) {
+	nodes, err := lru.New[utils.NodeKey, utils.NodeValue12](size)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeCache{nodes: nodes, size: size, staged: make(map[utils.NodeKey]utils.NodeValue12)}, nil
+}
+
+// Flush moves the staged nodes into the cache, it is called once the transaction they were read or written in has
+// been committed
+func (c *NodeCache) Flush() {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	staged := c.staged
+	c.staged = make(map[utils.NodeKey]utils.NodeValue12)
+	c.lock.Unlock()
+
+	for key, value := range staged {
+		c.nodes.Add(key, value)
+	}
+}
+
+// Reset drops the staged nodes, the transaction they were read or written in has been rolled back
+func (c *NodeCache) Reset() {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.staged = make(map[utils.NodeKey]utils.NodeValue12)
+}
+
+// Stats returns the number of reads served from the cache and from the db since it was created
+func (c *NodeCache) Stats() (hits, misses uint64) {
+	return c.hits.Load(), c.misses.Load()
+}
+
+// Len is the number of nodes in the cache, staged nodes aren't counted
+func (c *NodeCache) Len() int {
+	return c.nodes.Len()
+}
+
+func (c *NodeCache) get(key utils.NodeKey) (utils.NodeValue12, bool) {
+	v, ok := c.nodes.Get(key)
+	if !ok {
+		c.lock.Lock()
+		v, ok = c.staged[key]
+		c.lock.Unlock()
+	}
+	if ok {
+		c.hit()
+	} else {
+		c.misses.Add(1)
+		smtCacheMisses.Inc()
+	}
+	return v, ok
+}
+
+// stage holds a node until Flush, once there are as many staged nodes as the cache holds the rest are left out
+func (c *NodeCache) stage(key utils.NodeKey, value utils.NodeValue12) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.staged[key]; ok || len(c.staged) < c.size {
+		c.staged[key] = value
+	}
+}
+
+func (c *NodeCache) remove(key utils.NodeKey) {
+	c.nodes.Remove(key)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.staged, key)
+}
+
+func (c *NodeCache) purge() {
+	c.nodes.Purge()
+	c.Reset()
+}
+
+func (c *NodeCache) hit() {
+	c.hits.Add(1)
+	smtCacheHits.Inc()
+}
+
+// CachedDb is an EriDb which serves node reads from a NodeCache.  While a batch is open node writes are held in
+// memory and only reach the db on CommitBatch, everything other than nodes goes straight to the EriDb.
+type CachedDb struct {
+	*EriDb
+	cache *NodeCache
+
+	// pending holds the nodes written in the open batch, nil for a deleted node
+	pending map[utils.NodeKey]*utils.NodeValue12
+}
+
+func NewCachedDb(eridb *EriDb, cache *NodeCache) *CachedDb {
+	return &CachedDb{
+		EriDb: eridb,
+		cache: cache,
+	}
+}
+
+func (m *CachedDb) OpenBatch(quitCh <-chan struct{}) {
+	m.EriDb.OpenBatch(quitCh)
+	m.pending = make(map[utils.NodeKey]*utils.NodeValue12)
+}
+
+func (m *CachedDb) CommitBatch() error {
+	pending := m.pending
+	m.pending = nil
+	for key, value := range pending {
+		if value == nil {
+			if err := m.EriDb.DeleteByNodeKey(key); err != nil {
+				m.EriDb.RollbackBatch()
+				return err
+			}
+			continue
+		}
+		if err := m.EriDb.Insert(key, *value); err != nil {
+			m.EriDb.RollbackBatch()
+			return err
+		}
+		m.cache.stage(key, *value)
+	}
+	return m.EriDb.CommitBatch()
+}
+
+func (m *CachedDb) RollbackBatch() {
+	m.pending = nil
+	m.EriDb.RollbackBatch()
+}
+
+func (m *CachedDb) Get(key utils.NodeKey) (utils.NodeValue12, error) {
+	if value, ok := m.pending[key]; ok {
+		m.cache.hit()
+		if value == nil {
+			return utils.NodeValue12{}, nil
+		}
+		return *value, nil
+	}
+
+	if value, ok := m.cache.get(key); ok {
+		return value, nil
+	}
+
+	value, err := m.EriDb.Get(key)
+	if err != nil {
+		return utils.NodeValue12{}, err
+	}
+	if value[0] != nil {
+		m.cache.stage(key, value)
+	}
+	return value, nil
+}
+
+func (m *CachedDb) Insert(key utils.NodeKey, value utils.NodeValue12) error {
+	if m.pending != nil {
+		m.pending[key] = &value
+		return nil
+	}
+	if err := m.EriDb.Insert(key, value); err != nil {
+		return err
+	}
+	m.cache.stage(key, value)
+	return nil
+}
+
+func (m *CachedDb) Delete(key string) error {
+	return m.DeleteByNodeKey(utils.ScalarToRoot(utils.ConvertHexToBigInt(key)))
+}
+
+func (m *CachedDb) DeleteByNodeKey(key utils.NodeKey) error {
+	m.cache.remove(key)
+	if m.pending != nil {
+		m.pending[key] = nil
+		return nil
+	}
+	return m.EriDb.DeleteByNodeKey(key)
+}
+
+// SweepUnmarked doesn't say which nodes it deleted so the whole cache is dropped
+func (m *CachedDb) SweepUnmarked(from []byte, limit int) ([]byte, int, error) {
+	next, swept, err := m.EriDb.SweepUnmarked(from, limit)
+	if swept > 0 {
+		m.cache.purge()
+	}
+	return next, swept, err
+}
@@ -0,0 +1,163 @@
+package db
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/gateway-fm/cdk-erigon-lib/kv/mdbx"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testNodeValue(start int64) utils.NodeValue12 {
+	var v utils.NodeValue12
+	for i := range v {
+		v[i] = big.NewInt(start + int64(i))
+	}
+	return v
+}
+
+func TestCachedDb(t *testing.T) {
+	dbi, _ := mdbx.NewTemporaryMdbx()
+	tx, _ := dbi.BeginRw(context.Background())
+	defer tx.Rollback()
+	require.NoError(t, CreateEriDbBuckets(tx))
+
+	cache, err := NewNodeCache(16)
+	require.NoError(t, err)
+	db := NewCachedDb(NewEriDb(tx), cache)
+
+	key := utils.NodeKey{1, 2, 3, 4}
+	value := testNodeValue(1)
+
+	// outside a batch writes go straight to the db
+	require.NoError(t, db.Insert(key, value))
+	stored, err := NewEriDb(tx).Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, value, stored)
+
+	retrieved, err := db.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, value, retrieved)
+	hits, misses := cache.Stats()
+	assert.Equal(t, uint64(1), hits)
+	assert.Equal(t, uint64(0), misses)
+
+	// a missing node is a miss and isn't cached
+	missing, err := db.Get(utils.NodeKey{5, 6, 7, 8})
+	require.NoError(t, err)
+	assert.Nil(t, missing[0])
+	assert.Equal(t, 0, cache.Len())
+	cache.Flush()
+	assert.Equal(t, 1, cache.Len())
+
+	require.NoError(t, db.DeleteByNodeKey(key))
+	retrieved, err = db.Get(key)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved[0])
+}
+
+func TestCachedDbBatch(t *testing.T) {
+	dbi, _ := mdbx.NewTemporaryMdbx()
+	tx, _ := dbi.BeginRw(context.Background())
+	defer tx.Rollback()
+	require.NoError(t, CreateEriDbBuckets(tx))
+
+	cache, err := NewNodeCache(16)
+	require.NoError(t, err)
+	db := NewCachedDb(NewEriDb(tx), cache)
+
+	key := utils.NodeKey{1, 2, 3, 4}
+	value := testNodeValue(1)
+	quit := make(chan struct{})
+
+	// writes in a batch are only visible through the cached db until it's committed
+	db.OpenBatch(quit)
+	require.NoError(t, db.Insert(key, value))
+	retrieved, err := db.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, value, retrieved)
+	stored, err := NewEriDb(tx).Get(key)
+	require.NoError(t, err)
+	assert.Nil(t, stored[0])
+
+	require.NoError(t, db.CommitBatch())
+	stored, err = NewEriDb(tx).Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, value, stored)
+	assert.Equal(t, 0, cache.Len())
+	cache.Flush()
+	assert.Equal(t, 1, cache.Len())
+
+	// rolling back drops the writes and deletes of the batch
+	other := utils.NodeKey{5, 6, 7, 8}
+	db.OpenBatch(quit)
+	require.NoError(t, db.Insert(other, testNodeValue(100)))
+	require.NoError(t, db.DeleteByNodeKey(key))
+	deleted, err := db.Get(key)
+	require.NoError(t, err)
+	assert.Nil(t, deleted[0])
+	db.RollbackBatch()
+
+	retrieved, err = db.Get(other)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved[0])
+	retrieved, err = db.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, value, retrieved)
+}
+
+func TestNodeCacheOnlyKeepsCommittedNodes(t *testing.T) {
+	dbi, _ := mdbx.NewTemporaryMdbx()
+	defer dbi.Close()
+	ctx := context.Background()
+
+	require.NoError(t, dbi.Update(ctx, CreateEriDbBuckets))
+
+	cache, err := NewNodeCache(16)
+	require.NoError(t, err)
+	quit := make(chan struct{})
+
+	write := func(key utils.NodeKey, value utils.NodeValue12, commit bool) {
+		tx, err := dbi.BeginRw(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		db := NewCachedDb(NewEriDb(tx), cache)
+		db.OpenBatch(quit)
+		require.NoError(t, db.Insert(key, value))
+		require.NoError(t, db.CommitBatch())
+		if commit {
+			require.NoError(t, tx.Commit())
+			cache.Flush()
+		} else {
+			cache.Reset()
+		}
+	}
+	read := func(key utils.NodeKey) utils.NodeValue12 {
+		tx, err := dbi.BeginRw(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		value, err := NewCachedDb(NewEriDb(tx), cache).Get(key)
+		require.NoError(t, err)
+		cache.Reset()
+		return value
+	}
+
+	// the transaction is rolled back after the batch was committed, its node must not outlive it in the cache
+	rolledBack := utils.NodeKey{1, 2, 3, 4}
+	write(rolledBack, testNodeValue(1), false)
+	assert.Equal(t, 0, cache.Len())
+	assert.Nil(t, read(rolledBack)[0])
+
+	committed := utils.NodeKey{5, 6, 7, 8}
+	write(committed, testNodeValue(100), true)
+	assert.Equal(t, 1, cache.Len())
+	hits, _ := cache.Stats()
+	assert.Equal(t, testNodeValue(100), read(committed))
+	after, _ := cache.Stats()
+	assert.Equal(t, hits+1, after)
+}
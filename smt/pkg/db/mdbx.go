@@ -1,11 +1,14 @@
 package db
 
 import (
+	"encoding/binary"
+	"errors"
 	"math/big"
 
 	"fmt"
 	"strings"
 
+	"github.com/gateway-fm/cdk-erigon-lib/common"
 	"github.com/gateway-fm/cdk-erigon-lib/kv"
 	"github.com/ledgerwatch/erigon/ethdb"
 	"github.com/ledgerwatch/erigon/ethdb/olddb"
@@ -29,6 +32,11 @@ const TableStats = "HermezSmtStats"
 const TableAccountValues = "HermezSmtAccountValues"
 const TableMetadata = "HermezSmtMetadata"
 const TableHashKey = "HermezSmtHashKey"
+const TableGcMarks = "HermezSmtGcMarks"
+const TableRootHistory = "HermezSmtRootHistory"
+
+// ErrKeySourceNotFound is returned for keys without a key source, they were never set or their source was not recorded
+var ErrKeySourceNotFound = errors.New("key source not found")
 
 type EriDb struct {
 	kvTx kv.RwTx
@@ -61,6 +69,16 @@ func CreateEriDbBuckets(tx kv.RwTx) error {
 		return err
 	}
 
+	err = tx.CreateBucket(TableGcMarks)
+	if err != nil {
+		return err
+	}
+
+	err = tx.CreateBucket(TableRootHistory)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -180,6 +198,129 @@ func (m *EriDb) DeleteByNodeKey(key utils.NodeKey) error {
 	return m.tx.Delete(TableSmt, []byte(k))
 }
 
+func (m *EriDb) MarkNode(key utils.NodeKey) error {
+	keyConc := utils.ArrayToScalar(key[:])
+	k := utils.ConvertBigIntToHex(keyConc)
+	return m.tx.Put(TableGcMarks, []byte(k), []byte{1})
+}
+
+func (m *EriDb) IsNodeMarked(key utils.NodeKey) (bool, error) {
+	keyConc := utils.ArrayToScalar(key[:])
+	k := utils.ConvertBigIntToHex(keyConc)
+	return m.tx.Has(TableGcMarks, []byte(k))
+}
+
+func (m *EriDb) ClearMarks() error {
+	return m.kvTx.ClearBucket(TableGcMarks)
+}
+
+// SweepUnmarked deletes the nodes without a mark, looking at no more than limit nodes starting at the from key.  It
+// returns the key to carry on from or nil once the end of the table is reached.
+func (m *EriDb) SweepUnmarked(from []byte, limit int) ([]byte, int, error) {
+	c, err := m.kvTx.RwCursor(TableSmt)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer c.Close()
+
+	scanned, swept := 0, 0
+	for k, _, err := c.Seek(from); k != nil; k, _, err = c.Next() {
+		if err != nil {
+			return nil, swept, err
+		}
+		if scanned >= limit {
+			return common.Copy(k), swept, nil
+		}
+		scanned++
+
+		marked, err := m.kvTx.Has(TableGcMarks, k)
+		if err != nil {
+			return nil, swept, err
+		}
+		if marked {
+			continue
+		}
+
+		// leaves also have an entry in the hash key table which goes with them
+		hashKey := utils.ConvertHexToBigInt(string(k)).Bytes()
+		if err = c.DeleteCurrent(); err != nil {
+			return nil, swept, err
+		}
+		if err = m.kvTx.Delete(TableHashKey, hashKey); err != nil {
+			return nil, swept, err
+		}
+		swept++
+	}
+
+	return nil, swept, nil
+}
+
+func (m *EriDb) GetGcState() ([]byte, error) {
+	data, err := m.tx.GetOne(TableStats, []byte("gcState"))
+	if err != nil {
+		return nil, err
+	}
+	return common.Copy(data), nil
+}
+
+func (m *EriDb) SetGcState(state []byte) error {
+	if state == nil {
+		return m.tx.Delete(TableStats, []byte("gcState"))
+	}
+	return m.tx.Put(TableStats, []byte("gcState"), state)
+}
+
+func (m *EriDb) SetRootAt(blockNo uint64, root *big.Int) error {
+	return m.tx.Put(TableRootHistory, blockKey(blockNo), []byte(utils.ConvertBigIntToHex(root)))
+}
+
+// GetRootAt returns the root recorded for the block or nil when there isn't one
+func (m *EriDb) GetRootAt(blockNo uint64) (*big.Int, error) {
+	data, err := m.tx.GetOne(TableRootHistory, blockKey(blockNo))
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	return utils.ConvertHexToBigInt(string(data)), nil
+}
+
+// DeleteRootsFrom removes the roots recorded for the block and every block after it
+func (m *EriDb) DeleteRootsFrom(blockNo uint64) error {
+	return m.deleteRoots(func(b uint64) bool { return b >= blockNo })
+}
+
+// DeleteRootsBefore removes the roots recorded for the blocks before the given one
+func (m *EriDb) DeleteRootsBefore(blockNo uint64) error {
+	return m.deleteRoots(func(b uint64) bool { return b < blockNo })
+}
+
+func (m *EriDb) deleteRoots(match func(blockNo uint64) bool) error {
+	var keys [][]byte
+	if err := m.tx.ForEach(TableRootHistory, nil, func(k, v []byte) error {
+		if match(binary.BigEndian.Uint64(k)) {
+			keys = append(keys, common.Copy(k))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		if err := m.tx.Delete(TableRootHistory, k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func blockKey(blockNo uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, blockNo)
+	return k
+}
+
 func (m *EriDb) GetAccountValue(key utils.NodeKey) (utils.NodeValue8, error) {
 	keyConc := utils.ArrayToScalar(key[:])
 	k := utils.ConvertBigIntToHex(keyConc)
@@ -235,7 +376,7 @@ func (m *EriDb) GetKeySource(key utils.NodeKey) ([]byte, error) {
 	}
 
 	if data == nil {
-		return nil, fmt.Errorf("key %x not found", keyConc.Bytes())
+		return nil, fmt.Errorf("key %x: %w", keyConc.Bytes(), ErrKeySourceNotFound)
 	}
 
 	return data, nil
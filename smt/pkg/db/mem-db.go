@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"sort"
 	"sync"
 
 	"github.com/ledgerwatch/erigon/smt/pkg/utils"
@@ -15,8 +16,11 @@ type MemDb struct {
 	DbKeySource map[string][]byte
 	DbHashKey   map[string][]byte
 	DbCode      map[string][]byte
+	DbGcMarks   map[string]struct{}
+	DbRoots     map[uint64]*big.Int
 	LastRoot    *big.Int
 	Depth       uint8
+	GcState     []byte
 
 	lock sync.RWMutex
 }
@@ -28,6 +32,8 @@ func NewMemDb() *MemDb {
 		DbKeySource: make(map[string][]byte),
 		DbHashKey:   make(map[string][]byte),
 		DbCode:      make(map[string][]byte),
+		DbGcMarks:   make(map[string]struct{}),
+		DbRoots:     make(map[uint64]*big.Int),
 		LastRoot:    big.NewInt(0),
 		Depth:       0,
 	}
@@ -123,6 +129,114 @@ func (m *MemDb) DeleteByNodeKey(key utils.NodeKey) error {
 	return nil
 }
 
+func (m *MemDb) MarkNode(key utils.NodeKey) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	keyConc := utils.ArrayToScalar(key[:])
+	m.DbGcMarks[utils.ConvertBigIntToHex(keyConc)] = struct{}{}
+	return nil
+}
+
+func (m *MemDb) IsNodeMarked(key utils.NodeKey) (bool, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	keyConc := utils.ArrayToScalar(key[:])
+	_, ok := m.DbGcMarks[utils.ConvertBigIntToHex(keyConc)]
+	return ok, nil
+}
+
+func (m *MemDb) ClearMarks() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.DbGcMarks = make(map[string]struct{})
+	return nil
+}
+
+func (m *MemDb) SweepUnmarked(from []byte, limit int) ([]byte, int, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	keys := make([]string, 0, len(m.Db))
+	for k := range m.Db {
+		if k >= string(from) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	swept := 0
+	for i, k := range keys {
+		if i >= limit {
+			return []byte(k), swept, nil
+		}
+		if _, ok := m.DbGcMarks[k]; ok {
+			continue
+		}
+		delete(m.Db, k)
+		delete(m.DbHashKey, k)
+		swept++
+	}
+
+	return nil, swept, nil
+}
+
+func (m *MemDb) GetGcState() ([]byte, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.GcState, nil
+}
+
+func (m *MemDb) SetGcState(state []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.GcState = state
+	return nil
+}
+
+func (m *MemDb) SetRootAt(blockNo uint64, root *big.Int) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.DbRoots[blockNo] = new(big.Int).Set(root)
+	return nil
+}
+
+func (m *MemDb) GetRootAt(blockNo uint64) (*big.Int, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.DbRoots[blockNo], nil
+}
+
+func (m *MemDb) DeleteRootsFrom(blockNo uint64) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for b := range m.DbRoots {
+		if b >= blockNo {
+			delete(m.DbRoots, b)
+		}
+	}
+	return nil
+}
+
+func (m *MemDb) DeleteRootsBefore(blockNo uint64) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for b := range m.DbRoots {
+		if b < blockNo {
+			delete(m.DbRoots, b)
+		}
+	}
+	return nil
+}
+
 func (m *MemDb) GetAccountValue(key utils.NodeKey) (utils.NodeValue8, error) {
 	m.lock.RLock()         // Lock for reading
 	defer m.lock.RUnlock() // Make sure to unlock when done
@@ -184,7 +298,7 @@ func (m *MemDb) GetKeySource(key utils.NodeKey) ([]byte, error) {
 	s, ok := m.DbKeySource[keyConc.String()]
 
 	if !ok {
-		return nil, fmt.Errorf("key not found")
+		return nil, fmt.Errorf("key %s: %w", keyConc.String(), ErrKeySourceNotFound)
 	}
 
 	return s, nil
@@ -0,0 +1,268 @@
+// Package poseidon is a pure Go implementation of the goldilocks Poseidon permutation used to hash SMT nodes.  It
+// gives the same results as the iden3 goldenposeidon package and the vectorized C implementation, working on plain
+// uint64s in a fixed size state so a hash doesn't allocate.  It is faster than the C implementation on CPUs without
+// SIMD, with AVX2 or AVX-512 the C implementation is faster.
+package poseidon
+
+import (
+	"fmt"
+	"math/bits"
+
+	goldenposeidon "github.com/iden3/go-iden3-crypto/goldenposeidon"
+)
+
+const (
+	// Modulus is the goldilocks prime 2^64 - 2^32 + 1
+	Modulus = 0xffffffff00000001
+
+	// epsilon is 2^64 mod Modulus
+	epsilon = 0xffffffff
+
+	width         = 12
+	fullRounds    = goldenposeidon.NROUNDSF
+	partialRounds = goldenposeidon.NROUNDSP
+
+	roundConstantsLen  = fullRounds*width + partialRounds
+	sparseConstantsLen = (width*2 - 1) * partialRounds
+)
+
+// the constants of the optimised permutation, the same as goldenposeidon but in canonical form rather than montgomery.
+// The matrices are transposed so a row of the result is read from consecutive entries.
+var (
+	roundConstants  [roundConstantsLen]uint64
+	mdsMatrix       [width][width]uint64 // the circulant MDS matrix, every entry is below 2^8
+	preSparseMatrix [width][width]uint64 // applied by the last round before the partial rounds
+	sparseConstants [sparseConstantsLen]uint64
+
+	// mixedRoundConstants are the round constants of the full rounds multiplied by the matrix that follows them, so
+	// they are added once to the mixed state instead of to every element before mixing
+	mixedRoundConstants [fullRounds - 1][width]uint64
+)
+
+func init() {
+	if len(goldenposeidon.C) != roundConstantsLen || len(goldenposeidon.S) != sparseConstantsLen {
+		panic(fmt.Sprintf("poseidon: unexpected constant lengths %d and %d", len(goldenposeidon.C), len(goldenposeidon.S)))
+	}
+	for i, c := range goldenposeidon.C {
+		roundConstants[i] = c.ToUint64Regular()
+	}
+	for i, s := range goldenposeidon.S {
+		sparseConstants[i] = s.ToUint64Regular()
+	}
+	for i := 0; i < width; i++ {
+		for j := 0; j < width; j++ {
+			mdsMatrix[i][j] = goldenposeidon.M[j][i].ToUint64Regular()
+			preSparseMatrix[i][j] = goldenposeidon.P[j][i].ToUint64Regular()
+			if mdsMatrix[i][j] >= 1<<8 {
+				panic("poseidon: mds matrix entry does not fit in 8 bits")
+			}
+		}
+	}
+
+	for r := 0; r < fullRounds-1; r++ {
+		matrix := &mdsMatrix
+		if r == fullRounds/2-1 {
+			matrix = &preSparseMatrix
+		}
+		var c [width]uint64
+		copy(c[:], roundConstants[fullRoundConstantsOffset(r):])
+		mixFull(&c, matrix, &[width]uint64{})
+		for i := range c {
+			mixedRoundConstants[r][i] = canonical(c[i])
+		}
+	}
+}
+
+// fullRoundConstantsOffset is where the constants added after the sbox of a full round start, the last full round
+// doesn't have any
+func fullRoundConstantsOffset(r int) int {
+	if r < fullRounds/2 {
+		return (r + 1) * width
+	}
+	return (r+1)*width + partialRounds
+}
+
+// Hash computes the poseidon hash of 8 input elements and 4 capacity elements, the inputs don't have to be reduced
+func Hash(in [8]uint64, capacity [4]uint64) [4]uint64 {
+	var out [4]uint64
+	HashWithResult(&in, &capacity, &out)
+	return out
+}
+
+// HashWithResult is Hash writing the result to out
+func HashWithResult(in *[8]uint64, capacity *[4]uint64, out *[4]uint64) {
+	var state [width]uint64
+	for i := 0; i < 8; i++ {
+		state[i] = add(in[i], roundConstants[i])
+	}
+	for i := 0; i < 4; i++ {
+		state[8+i] = add(capacity[i], roundConstants[8+i])
+	}
+
+	// first half of the full rounds, the last one mixes with the matrix preparing the sparse partial rounds
+	for r := 0; r < fullRounds/2; r++ {
+		sbox(&state)
+		if r == fullRounds/2-1 {
+			mixFull(&state, &preSparseMatrix, &mixedRoundConstants[r])
+		} else {
+			mixMds(&state, &mixedRoundConstants[r])
+		}
+	}
+
+	for r := 0; r < partialRounds; r++ {
+		state[0] = add(exp7(state[0]), roundConstants[(fullRounds/2+1)*width+r])
+		mixSparse(&state, r*(width*2-1))
+	}
+
+	for r := fullRounds / 2; r < fullRounds; r++ {
+		sbox(&state)
+		if r < fullRounds-1 {
+			mixMds(&state, &mixedRoundConstants[r])
+		} else {
+			mixMds(&state, &[width]uint64{})
+		}
+	}
+
+	out[0] = canonical(state[0])
+	out[1] = canonical(state[1])
+	out[2] = canonical(state[2])
+	out[3] = canonical(state[3])
+}
+
+func sbox(state *[width]uint64) {
+	state[0] = exp7(state[0])
+	state[1] = exp7(state[1])
+	state[2] = exp7(state[2])
+	state[3] = exp7(state[3])
+	state[4] = exp7(state[4])
+	state[5] = exp7(state[5])
+	state[6] = exp7(state[6])
+	state[7] = exp7(state[7])
+	state[8] = exp7(state[8])
+	state[9] = exp7(state[9])
+	state[10] = exp7(state[10])
+	state[11] = exp7(state[11])
+}
+
+// mixMds multiplies the state by the MDS matrix and adds the constants.  The matrix entries are below 2^8 so the
+// halves of the state elements can be multiplied and summed in plain uint64s, a row is only reduced once.
+func mixMds(state *[width]uint64, constants *[width]uint64) {
+	var lo, hi [width]uint64
+	for i := 0; i < width; i++ {
+		lo[i] = state[i] & 0xffffffff
+		hi[i] = state[i] >> 32
+	}
+
+	for i := 0; i < width; i++ {
+		m := &mdsMatrix[i]
+		sumLo := m[0]*lo[0] + m[1]*lo[1] + m[2]*lo[2] + m[3]*lo[3] + m[4]*lo[4] + m[5]*lo[5] +
+			m[6]*lo[6] + m[7]*lo[7] + m[8]*lo[8] + m[9]*lo[9] + m[10]*lo[10] + m[11]*lo[11]
+		sumHi := m[0]*hi[0] + m[1]*hi[1] + m[2]*hi[2] + m[3]*hi[3] + m[4]*hi[4] + m[5]*hi[5] +
+			m[6]*hi[6] + m[7]*hi[7] + m[8]*hi[8] + m[9]*hi[9] + m[10]*hi[10] + m[11]*hi[11]
+
+		// sumHi*2^32 + sumLo + constant in 128 bits
+		rHi := sumHi >> 32
+		rLo, carry := bits.Add64(sumHi<<32, sumLo, 0)
+		rHi += carry
+		rLo, carry = bits.Add64(rLo, constants[i], 0)
+		state[i] = reduce128(rHi+carry, rLo)
+	}
+}
+
+// mixFull multiplies the state by a matrix of full size elements and adds the constants, a row is summed into 192
+// bits before reducing
+func mixFull(state *[width]uint64, matrix *[width][width]uint64, constants *[width]uint64) {
+	var result [width]uint64
+	for i := 0; i < width; i++ {
+		m := &matrix[i]
+		top, hi, lo := uint64(0), uint64(0), constants[i]
+		for j := 0; j < width; j++ {
+			pHi, pLo := bits.Mul64(m[j], state[j])
+			var carry uint64
+			lo, carry = bits.Add64(lo, pLo, 0)
+			hi, carry = bits.Add64(hi, pHi, carry)
+			top += carry
+		}
+		result[i] = reduce192(top, hi, lo)
+	}
+	*state = result
+}
+
+// mixSparse applies the sparse matrix of a partial round, the first row is full and the rest only depend on the first
+// element
+func mixSparse(state *[width]uint64, offset int) {
+	s := (*[width*2 - 1]uint64)(sparseConstants[offset : offset+width*2-1])
+	first := state[0]
+
+	var top, hi, lo, carry uint64
+	for i := 0; i < width; i++ {
+		pHi, pLo := bits.Mul64(s[i], state[i])
+		lo, carry = bits.Add64(lo, pLo, 0)
+		hi, carry = bits.Add64(hi, pHi, carry)
+		top += carry
+	}
+	state[0] = reduce192(top, hi, lo)
+
+	for i := 1; i < width; i++ {
+		state[i] = mulAdd(s[width+i-1], first, state[i])
+	}
+}
+
+func exp7(x uint64) uint64 {
+	x2 := mul(x, x)
+	x3 := mul(x2, x)
+	x4 := mul(x2, x2)
+	return mul(x3, x4)
+}
+
+// add returns a value congruent to a + b, neither needs to be reduced and neither is the result
+func add(a, b uint64) uint64 {
+	sum, carry := bits.Add64(a, b, 0)
+	// 2^64 is epsilon mod p, adding it again can't carry a second time
+	sum, carry = bits.Add64(sum, mask(carry), 0)
+	return sum + mask(carry)
+}
+
+// mask returns epsilon for a carry or borrow of 1 and 0 otherwise
+func mask(bit uint64) uint64 {
+	return -bit >> 32
+}
+
+func mul(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return reduce128(hi, lo)
+}
+
+// mulAdd returns a value congruent to a*b + c, which always fits in 128 bits
+func mulAdd(a, b, c uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	lo, carry := bits.Add64(lo, c, 0)
+	return reduce128(hi+carry, lo)
+}
+
+// reduce128 returns a value below 2^64 congruent to hi*2^64 + lo
+func reduce128(hi, lo uint64) uint64 {
+	hiHi := hi >> 32
+	hiLo := hi & epsilon
+
+	// hiHi*2^96 is -hiHi mod p
+	t0, borrow := bits.Sub64(lo, hiHi, 0)
+	t0 -= mask(borrow)
+
+	// hiLo*2^64 is hiLo*epsilon mod p
+	t1 := hiLo<<32 - hiLo
+	t2, carry := bits.Add64(t0, t1, 0)
+	return t2 + mask(carry)
+}
+
+// reduce192 returns a value below 2^64 congruent to top*2^128 + hi*2^64 + lo
+func reduce192(top, hi, lo uint64) uint64 {
+	return reduce128(reduce128(top, hi), lo)
+}
+
+func canonical(x uint64) uint64 {
+	if x >= Modulus {
+		return x - Modulus
+	}
+	return x
+}
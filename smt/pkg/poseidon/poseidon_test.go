@@ -0,0 +1,98 @@
+package poseidon
+
+import (
+	"math/rand"
+	"testing"
+
+	goldenposeidon "github.com/iden3/go-iden3-crypto/goldenposeidon"
+)
+
+// the first vector is utils.HASH_POSEIDON_ALL_ZEROES, the others were produced by goldenposeidon
+var testVectors = []struct {
+	in       [8]uint64
+	capacity [4]uint64
+	expected [4]uint64
+}{
+	{
+		in:       [8]uint64{0, 0, 0, 0, 0, 0, 0, 0},
+		capacity: [4]uint64{0, 0, 0, 0},
+		expected: [4]uint64{0x3c18a9786cb0b359, 0xc4055e3364a246c3, 0x7953db0ab48808f4, 0xc71603f33a1144ca},
+	},
+	{
+		in:       [8]uint64{1, 1, 1, 1, 1, 1, 1, 1},
+		capacity: [4]uint64{1, 1, 1, 1},
+		expected: [4]uint64{0xe3fd1ad5743c4d77, 0xb94b3adc599d5630, 0x09783d643dd45102, 0xa89f8f921605bbc8},
+	},
+	{
+		in:       [8]uint64{Modulus - 1, Modulus - 1, Modulus - 1, Modulus - 1, Modulus - 1, Modulus - 1, Modulus - 1, Modulus - 1},
+		capacity: [4]uint64{Modulus - 1, Modulus - 1, Modulus - 1, Modulus - 1},
+		expected: [4]uint64{0xbe0085cfc57a8357, 0xd95af71847d05c09, 0xcf55a13d33c1c953, 0x95803a74f4530e82},
+	},
+	{
+		in:       [8]uint64{1, 2, 3, 4, 5, 6, 7, 8},
+		capacity: [4]uint64{0, 0, 0, 0},
+		expected: [4]uint64{0xd110aa6a46373941, 0x8f238fcceb658894, 0x9cd4f8353866fb4f, 0x274913f0007aa232},
+	},
+}
+
+func randomInput(rnd *rand.Rand) (in [8]uint64, capacity [4]uint64) {
+	for i := range in {
+		in[i] = rnd.Uint64()
+	}
+	for i := range capacity {
+		capacity[i] = rnd.Uint64()
+	}
+	return in, capacity
+}
+
+func TestHashVectors(t *testing.T) {
+	for i, test := range testVectors {
+		if actual := Hash(test.in, test.capacity); actual != test.expected {
+			t.Errorf("vector %d: expected %x, got %x", i, test.expected, actual)
+		}
+	}
+}
+
+func TestHashMatchesGoldenPoseidon(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		in, capacity := randomInput(rnd)
+		// half of the inputs are reduced, the others exercise values above the modulus
+		if i%2 == 0 {
+			for j := range in {
+				in[j] %= Modulus
+			}
+			for j := range capacity {
+				capacity[j] %= Modulus
+			}
+		}
+
+		expected, err := goldenposeidon.Hash(in, capacity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := Hash(in, capacity); actual != expected {
+			t.Fatalf("input %v %v: expected %x, got %x", in, capacity, expected, actual)
+		}
+	}
+}
+
+func TestHashDoesNotAllocate(t *testing.T) {
+	in, capacity := randomInput(rand.New(rand.NewSource(3)))
+	var out [4]uint64
+	allocs := testing.AllocsPerRun(100, func() {
+		HashWithResult(&in, &capacity, &out)
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+}
+
+func BenchmarkHash(b *testing.B) {
+	in, capacity := randomInput(rand.New(rand.NewSource(4)))
+	var out [4]uint64
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		HashWithResult(&in, &capacity, &out)
+	}
+}
@@ -341,6 +341,10 @@ func (s *SMT) InsertKeySource(nodeKey *utils.NodeKey, key int, accountAddr *libc
 }
 
 func (s *SMT) DeleteKeySource(nodeKey *utils.NodeKey) error {
+	if s.KeepStaleNodes {
+		// older roots may still have a leaf for the key
+		return nil
+	}
 	return s.Db.DeleteKeySource(*nodeKey)
 }
 
@@ -49,9 +49,34 @@ type DebuggableDB interface {
 	GetDb() map[string][]string
 }
 
+type GcDB interface {
+	DB
+	MarkNode(key utils.NodeKey) error
+	IsNodeMarked(key utils.NodeKey) (bool, error)
+	ClearMarks() error
+	SweepUnmarked(from []byte, limit int) ([]byte, int, error)
+	GetGcState() ([]byte, error)
+	SetGcState(state []byte) error
+}
+
+type HistoryDB interface {
+	DB
+	SetRootAt(blockNo uint64, root *big.Int) error
+	GetRootAt(blockNo uint64) (*big.Int, error)
+	DeleteRootsFrom(blockNo uint64) error
+	DeleteRootsBefore(blockNo uint64) error
+}
+
 type SMT struct {
 	Db DB
 
+	// HashWorkers bounds the goroutines used to hash a batch insert, zero uses one per cpu
+	HashWorkers int
+
+	// KeepStaleNodes leaves the nodes and key sources a batch insert replaces in the db so older roots can still be
+	// read, it is up to garbage collection to remove them
+	KeepStaleNodes bool
+
 	clearUpMutex sync.Mutex
 }
 
@@ -90,8 +115,17 @@ func (s *SMT) SetLastRoot(lr *big.Int) {
 	}
 }
 
+// isMdbx is true when the db reads through an mdbx tx, which can't be shared with another goroutine
+func (s *SMT) isMdbx() bool {
+	switch s.Db.(type) {
+	case *db.EriDb, *db.CachedDb:
+		return true
+	}
+	return false
+}
+
 func (s *SMT) StartPeriodicCheck(doneChan chan bool) {
-	if _, ok := s.Db.(*db.EriDb); ok {
+	if s.isMdbx() {
 		log.Warn("mdbx tx cannot be used in goroutine - periodic check disabled")
 		return
 	}
@@ -582,7 +616,7 @@ func (s *SMT) PrintTree() {
 type VisitedNodesMap map[string]bool
 
 func (s *SMT) CheckOrphanedNodes(ctx context.Context) int {
-	if _, ok := s.Db.(*db.EriDb); ok {
+	if s.isMdbx() {
 		log.Warn("mdbx tx cannot be used in goroutine - periodic check disabled")
 		return 0
 	}
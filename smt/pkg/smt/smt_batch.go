@@ -2,7 +2,6 @@ package smt
 
 import (
 	"fmt"
-	"sync"
 
 	"github.com/dgravesa/go-parallel/parallel"
 	"github.com/ledgerwatch/erigon/smt/pkg/utils"
@@ -144,12 +143,14 @@ func (s *SMT) InsertBatch(logPrefix string, nodeKeys []*utils.NodeKey, nodeValue
 
 	s.updateDepth(maxInsertingNodePathLevel)
 
-	for _, mapLevel0 := range nodeHashesForDelete {
-		for _, mapLevel1 := range mapLevel0 {
-			for _, mapLevel2 := range mapLevel1 {
-				for _, nodeHash := range mapLevel2 {
-					s.Db.DeleteByNodeKey(*nodeHash)
-					s.Db.DeleteHashKey(*nodeHash)
+	if !s.KeepStaleNodes {
+		for _, mapLevel0 := range nodeHashesForDelete {
+			for _, mapLevel1 := range mapLevel0 {
+				for _, mapLevel2 := range mapLevel1 {
+					for _, nodeHash := range mapLevel2 {
+						s.Db.DeleteByNodeKey(*nodeHash)
+						s.Db.DeleteHashKey(*nodeHash)
+					}
 				}
 			}
 		}
@@ -166,7 +167,9 @@ func (s *SMT) InsertBatch(logPrefix string, nodeKeys []*utils.NodeKey, nodeValue
 	if smtBatchNodeRoot == nil {
 		rootNodeHash = &utils.NodeKey{0, 0, 0, 0}
 	} else {
-		calculateAndSaveHashesDfs(s, smtBatchNodeRoot, make([]int, 256), 0)
+		if err := calculateAndSaveHashesDfs(s, smtBatchNodeRoot, make([]int, 256), 0, s.hashWorkers()); err != nil {
+			return nil, err
+		}
 		rootNodeHash = (*utils.NodeKey)(smtBatchNodeRoot.hash)
 	}
 	if err := s.setLastRoot(*rootNodeHash); err != nil {
@@ -232,54 +235,29 @@ func removeDuplicateEntriesByKeys(size *int, nodeKeys *[]*utils.NodeKey, nodeVal
 }
 
 func calculateNodeValueHashesIfMissing(s *SMT, nodeValues []*utils.NodeValue8, nodeValuesHashes *[]*[4]uint64) error {
-	var globalError error
-	size := len(nodeValues)
-	cpuNum := parallel.DefaultNumGoroutines()
-
-	if size > (cpuNum << 2) {
-		var wg sync.WaitGroup
-		itemsPerCpu := (size + cpuNum - 1) / cpuNum
-
-		wg.Add(cpuNum)
-
-		for cpuIndex := 0; cpuIndex < cpuNum; cpuIndex++ {
-			go func(cpuIndexInThread int) {
-				defer wg.Done()
-				startIndex := cpuIndexInThread * itemsPerCpu
-				endIndex := startIndex + itemsPerCpu
-				if endIndex > size {
-					endIndex = size
-				}
-				err := calculateNodeValueHashesIfMissingInInterval(s, nodeValues, nodeValuesHashes, startIndex, endIndex)
-				if err != nil {
-					globalError = err
-				}
-			}(cpuIndex)
+	var missing []int
+	var inputs []utils.HashInput
+	for i := range nodeValues {
+		if (*nodeValuesHashes)[i] == nil {
+			missing = append(missing, i)
+			inputs = append(inputs, utils.HashInput{In: nodeValues[i].ToUintArray(), Capacity: utils.BranchCapacity})
 		}
+	}
 
-		wg.Wait()
-	} else {
-		globalError = calculateNodeValueHashesIfMissingInInterval(s, nodeValues, nodeValuesHashes, 0, len(nodeValues))
+	hashes := make([][4]uint64, len(inputs))
+	utils.HashBatch(inputs, hashes, s.hashWorkers())
+	for j, i := range missing {
+		(*nodeValuesHashes)[i] = &hashes[j]
 	}
 
-	return globalError
+	return nil
 }
 
-func calculateNodeValueHashesIfMissingInInterval(s *SMT, nodeValues []*utils.NodeValue8, nodeValuesHashes *[]*[4]uint64, startIndex, endIndex int) error {
-	for i := startIndex; i < endIndex; i++ {
-		if (*nodeValuesHashes)[i] != nil {
-			continue
-		}
-
-		nodeValueHashObj, err := s.hashcalc(nodeValues[i].ToUintArray(), utils.BranchCapacity)
-		if err != nil {
-			return err
-		}
-
-		(*nodeValuesHashes)[i] = &nodeValueHashObj
+func (s *SMT) hashWorkers() int {
+	if s.HashWorkers > 0 {
+		return s.HashWorkers
 	}
-
-	return nil
+	return parallel.DefaultNumGoroutines()
 }
 
 func calculateRootNodeHashIfNil(s *SMT, root **utils.NodeKey) error {
@@ -376,44 +354,90 @@ func updateNodeHashesForDelete(nodeHashesForDelete map[uint64]map[uint64]map[uin
 	}
 }
 
-func calculateAndSaveHashesDfs(s *SMT, smtBatchNode *smtBatchNode, path []int, level int) error {
+// calculateAndSaveHashesDfs hashes the batch tree a level at a time using up to workers goroutines and then saves the
+// nodes in a single depth first pass so the db writes are in the same order whatever the number of workers
+func calculateAndSaveHashesDfs(s *SMT, smtBatchNode *smtBatchNode, path []int, level int, workers int) error {
+	calculateHashesByLevel(smtBatchNode, workers)
+
+	return saveHashesDfs(s, smtBatchNode, path, level)
+}
+
+// calculateHashesByLevel hashes the batch tree a level at a time from the deepest up.  The nodes of a level only depend
+// on the level below so each level is hashed as one batch.
+func calculateHashesByLevel(root *smtBatchNode, workers int) {
+	levels := [][]*smtBatchNode{{root}}
+	for {
+		var next []*smtBatchNode
+		for _, node := range levels[len(levels)-1] {
+			if node.isLeaf() {
+				continue
+			}
+			if node.leftNode != nil {
+				next = append(next, node.leftNode)
+			}
+			if node.rightNode != nil {
+				next = append(next, node.rightNode)
+			}
+		}
+		if len(next) == 0 {
+			break
+		}
+		levels = append(levels, next)
+	}
+
+	var inputs []utils.HashInput
+	for l := len(levels) - 1; l >= 0; l-- {
+		nodes := levels[l]
+
+		inputs = inputs[:0]
+		for _, node := range nodes {
+			if node.isLeaf() {
+				inputs = append(inputs, utils.HashInput{
+					In:       utils.ConcatArrays4(*node.nodeLeftHashOrRemainingKey, *node.nodeRightHashOrValueHash),
+					Capacity: utils.LeafCapacity,
+				})
+			} else {
+				branchValue := node.branchValue()
+				inputs = append(inputs, utils.HashInput{In: branchValue.ToUintArray(), Capacity: utils.BranchCapacity})
+			}
+		}
+
+		hashes := make([][4]uint64, len(nodes))
+		utils.HashBatch(inputs, hashes, workers)
+		for i, node := range nodes {
+			node.hash = &hashes[i]
+		}
+	}
+}
+
+func saveHashesDfs(s *SMT, smtBatchNode *smtBatchNode, path []int, level int) error {
 	if smtBatchNode.isLeaf() {
-		hashObj, err := s.hashcalcAndSave(utils.ConcatArrays4(*smtBatchNode.nodeLeftHashOrRemainingKey, *smtBatchNode.nodeRightHashOrValueHash), utils.LeafCapacity)
+		hashObj, err := s.hashSave(utils.ConcatArrays4(*smtBatchNode.nodeLeftHashOrRemainingKey, *smtBatchNode.nodeRightHashOrValueHash), utils.LeafCapacity, *smtBatchNode.hash)
 		if err != nil {
 			return err
 		}
 
 		nodeKey := utils.JoinKey(path[:level], *smtBatchNode.nodeLeftHashOrRemainingKey)
-		s.Db.InsertHashKey(hashObj, *nodeKey)
-
-		smtBatchNode.hash = &hashObj
-		return nil
+		return s.Db.InsertHashKey(hashObj, *nodeKey)
 	}
 
-	var totalHash utils.NodeValue8
-
 	if smtBatchNode.leftNode != nil {
 		path[level] = 0
-		calculateAndSaveHashesDfs(s, smtBatchNode.leftNode, path, level+1)
-		totalHash.SetHalfValue(*smtBatchNode.leftNode.hash, 0)
-	} else {
-		totalHash.SetHalfValue(*smtBatchNode.nodeLeftHashOrRemainingKey, 0)
+		if err := saveHashesDfs(s, smtBatchNode.leftNode, path, level+1); err != nil {
+			return err
+		}
 	}
 
 	if smtBatchNode.rightNode != nil {
 		path[level] = 1
-		calculateAndSaveHashesDfs(s, smtBatchNode.rightNode, path, level+1)
-		totalHash.SetHalfValue(*smtBatchNode.rightNode.hash, 1)
-	} else {
-		totalHash.SetHalfValue(*smtBatchNode.nodeRightHashOrValueHash, 1)
+		if err := saveHashesDfs(s, smtBatchNode.rightNode, path, level+1); err != nil {
+			return err
+		}
 	}
 
-	hashObj, err := s.hashcalcAndSave(totalHash.ToUintArray(), utils.BranchCapacity)
-	if err != nil {
-		return err
-	}
-	smtBatchNode.hash = &hashObj
-	return nil
+	branchValue := smtBatchNode.branchValue()
+	_, err := s.hashSave(branchValue.ToUintArray(), utils.BranchCapacity, *smtBatchNode.hash)
+	return err
 }
 
 type smtBatchNode struct {
@@ -462,6 +486,25 @@ func (sbn *smtBatchNode) isLeaf() bool {
 	return sbn.leaf
 }
 
+// branchValue is the value hashed for a branch node, its children must already be hashed
+func (sbn *smtBatchNode) branchValue() utils.NodeValue8 {
+	var totalHash utils.NodeValue8
+
+	if sbn.leftNode != nil {
+		totalHash.SetHalfValue(*sbn.leftNode.hash, 0)
+	} else {
+		totalHash.SetHalfValue(*sbn.nodeLeftHashOrRemainingKey, 0)
+	}
+
+	if sbn.rightNode != nil {
+		totalHash.SetHalfValue(*sbn.rightNode.hash, 1)
+	} else {
+		totalHash.SetHalfValue(*sbn.nodeRightHashOrValueHash, 1)
+	}
+
+	return totalHash
+}
+
 func (sbn *smtBatchNode) getTheSingleLeafAndDirectionIfAny() (*smtBatchNode, int) {
 	if sbn.leftNode != nil && sbn.rightNode == nil && sbn.leftNode.isLeaf() {
 		return sbn.leftNode, 0
@@ -698,3 +698,86 @@ func assertTraverse(t *testing.T, s *smt.SMT) {
 	err := s.Traverse(ctx, smtBatchRootHash, action)
 	assert.NilError(t, err)
 }
+
+// recordingDb keeps the order nodes are written in
+type recordingDb struct {
+	*db.MemDb
+	writes []utils.NodeKey
+}
+
+func (r *recordingDb) Insert(key utils.NodeKey, value utils.NodeValue12) error {
+	r.writes = append(r.writes, key)
+	return r.MemDb.Insert(key, value)
+}
+
+func (r *recordingDb) InsertHashKey(key utils.NodeKey, value utils.NodeKey) error {
+	r.writes = append(r.writes, key, value)
+	return r.MemDb.InsertHashKey(key, value)
+}
+
+func randomBatch(size int) ([]*utils.NodeKey, []*utils.NodeValue8) {
+	keys := make([]*utils.NodeKey, 0, size)
+	values := make([]*utils.NodeValue8, 0, size)
+	for i := 0; i < size; i++ {
+		k := utils.ScalarToNodeKey(big.NewInt(rand.Int63()))
+		v, _ := utils.NodeValue8FromBigIntArray(utils.ScalarToArrayBig(big.NewInt(rand.Int63())))
+		keys = append(keys, &k)
+		values = append(values, v)
+	}
+	return keys, values
+}
+
+// the batch tree is hashed a level at a time with the levels split between HashWorkers goroutines, the number of
+// workers must change neither the root nor the order the nodes are written in
+func TestBatchLevelHashingIsDeterministic(t *testing.T) {
+	rand.Seed(1)
+	first, firstValues := randomBatch(1 << 12)
+	second, secondValues := randomBatch(1 << 10)
+
+	var roots []string
+	var writes [][]utils.NodeKey
+	for _, workers := range []int{1, 3, 16} {
+		recorder := &recordingDb{MemDb: db.NewMemDb()}
+		s := smt.NewSMT(recorder)
+		s.HashWorkers = workers
+
+		_, err := s.InsertBatch("", first, firstValues, nil, nil)
+		assert.NilError(t, err)
+		_, err = s.InsertBatch("", second, secondValues, nil, nil)
+		assert.NilError(t, err)
+
+		root, err := s.Db.GetLastRoot()
+		assert.NilError(t, err)
+		roots = append(roots, utils.ConvertBigIntToHex(root))
+		writes = append(writes, recorder.writes)
+
+		assertSmtDbStructure(t, s, false)
+	}
+
+	for i := 1; i < len(roots); i++ {
+		assert.Equal(t, roots[0], roots[i])
+		assert.DeepEqual(t, writes[0], writes[i])
+	}
+}
+
+// BenchmarkInsertBatchLevelHashing compares hashing the levels of the batch tree on one goroutine and on one per cpu
+func BenchmarkInsertBatchLevelHashing(b *testing.B) {
+	rand.Seed(1)
+	keys, values := randomBatch(1 << 15)
+
+	for _, workers := range []int{1, 0} {
+		name := fmt.Sprintf("workers=%d", workers)
+		if workers == 0 {
+			name = "workers=cpus"
+		}
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s := smt.NewSMT(nil)
+				s.HashWorkers = workers
+				if _, err := s.InsertBatch("", keys, values, nil, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -71,8 +71,22 @@ func (s *SMT) GenerateFromKVBulk(logPrefix string, nodeKeys []utils.NodeKey) ([4
 
 	maxReachedLevel := 0
 
+	// the value hashes of the leaves don't depend on the tree so they are hashed in batches ahead of the inserts
+	leafValues := make(leafValueCache, leafValuesPrefetchSize)
+	hashWorkers := s.hashWorkers()
+
 	tempTreeBuildStart := time.Now()
-	for _, k := range nodeKeys {
+	for i, k := range nodeKeys {
+		if i%leafValuesPrefetchSize == 0 {
+			end := i + leafValuesPrefetchSize
+			if end > len(nodeKeys) {
+				end = len(nodeKeys)
+			}
+			if err := s.prefetchLeafValues(nodeKeys[i:end], leafValues, hashWorkers); err != nil {
+				return [4]uint64{}, err
+			}
+		}
+
 		// split the key
 		keys := k.GetPath()
 		// find last node
@@ -145,7 +159,7 @@ func (s *SMT) GenerateFromKVBulk(logPrefix string, nodeKeys []utils.NodeKey) ([4
 				pathToDeleteFrom := make([]int, level+level2+1)
 				copy(pathToDeleteFrom, keys[:level+level2])
 				pathToDeleteFrom[level+level2] = 0
-				_, leftHash, err := nodeToDelFrom.node0.deleteTree(pathToDeleteFrom, s)
+				_, leftHash, err := nodeToDelFrom.node0.deleteTree(pathToDeleteFrom, s, leafValues)
 				if err != nil {
 					return err
 				}
@@ -209,7 +223,7 @@ func (s *SMT) GenerateFromKVBulk(logPrefix string, nodeKeys []utils.NodeKey) ([4
 						pathToDeleteFrom := make([]int, level+1)
 						copy(pathToDeleteFrom, keys[:level])
 						pathToDeleteFrom[level] = 0
-						_, leftHash, err := nodeToDelFrom.node0.deleteTree(pathToDeleteFrom, s)
+						_, leftHash, err := nodeToDelFrom.node0.deleteTree(pathToDeleteFrom, s, leafValues)
 						if err != nil {
 							return err
 						}
@@ -263,7 +277,7 @@ func (s *SMT) GenerateFromKVBulk(logPrefix string, nodeKeys []utils.NodeKey) ([4
 		rootNode.rKey = newRkey
 	}
 
-	_, finalRoot, err := rootNode.deleteTree(pathToDeleteFrom, s)
+	_, finalRoot, err := rootNode.deleteTree(pathToDeleteFrom, s, leafValues)
 	if err != nil {
 		return [4]uint64{}, err
 	}
@@ -320,7 +334,7 @@ func (n *SmtNode) findLastNode(keys []int) ([]*SmtNode, int) {
 	return siblings, level
 }
 
-func (n *SmtNode) deleteTree(keyPath []int, s *SMT) ([]utils.NodeKey, [4]uint64, error) {
+func (n *SmtNode) deleteTree(keyPath []int, s *SMT, leafValues leafValueCache) ([]utils.NodeKey, [4]uint64, error) {
 	deletedKeys := []utils.NodeKey{}
 
 	if n.isLeaf() {
@@ -329,16 +343,22 @@ func (n *SmtNode) deleteTree(keyPath []int, s *SMT) ([]utils.NodeKey, [4]uint64,
 		if err != nil {
 			return nil, [4]uint64{}, err
 		}
-		v, err := s.Db.GetAccountValue(k)
-		if err != nil {
-			return nil, [4]uint64{}, err
-		}
 
 		// deletedKeys = append(deletedKeys, k)
 
 		newKey := utils.RemoveKeyBits(k, len(keyPath))
 		//hash and save leaf
-		newLeafHash, err := s.createNewLeaf(k, newKey, v)
+		var newLeafHash [4]uint64
+		if leaf, ok := leafValues[k]; ok {
+			delete(leafValues, k)
+			newLeafHash, err = s.createNewLeafWithValueHash(k, newKey, leaf.value, leaf.hash)
+		} else {
+			var v utils.NodeValue8
+			if v, err = s.Db.GetAccountValue(k); err != nil {
+				return nil, [4]uint64{}, err
+			}
+			newLeafHash, err = s.createNewLeaf(k, newKey, v)
+		}
 		if err != nil {
 			return nil, [4]uint64{}, err
 		}
@@ -352,7 +372,7 @@ func (n *SmtNode) deleteTree(keyPath []int, s *SMT) ([]utils.NodeKey, [4]uint64,
 			return nil, [4]uint64{}, fmt.Errorf("node has previously deleted left part")
 		}
 		localKeyPath := append(keyPath, 0)
-		_, leftHash, err := n.node0.deleteTree(localKeyPath, s)
+		_, leftHash, err := n.node0.deleteTree(localKeyPath, s, leafValues)
 		if err != nil {
 			return nil, [4]uint64{}, err
 		}
@@ -364,7 +384,7 @@ func (n *SmtNode) deleteTree(keyPath []int, s *SMT) ([]utils.NodeKey, [4]uint64,
 
 	if n.node1 != nil {
 		localKeyPath := append(keyPath, 1)
-		_, rightHash, err := n.node1.deleteTree(localKeyPath, s)
+		_, rightHash, err := n.node1.deleteTree(localKeyPath, s, leafValues)
 		if err != nil {
 			return nil, [4]uint64{}, err
 		}
@@ -391,6 +411,20 @@ func (s *SMT) createNewLeaf(k, rkey utils.NodeKey, v utils.NodeValue8) ([4]uint6
 		return [4]uint64{}, err
 	}
 
+	return s.saveNewLeaf(k, rkey, newValH)
+}
+
+// createNewLeafWithValueHash is createNewLeaf for a value that has already been hashed
+func (s *SMT) createNewLeafWithValueHash(k, rkey utils.NodeKey, v utils.NodeValue8, valueHash [4]uint64) ([4]uint64, error) {
+	newValH, err := s.hashSave(v.ToUintArray(), utils.BranchCapacity, valueHash)
+	if err != nil {
+		return [4]uint64{}, err
+	}
+
+	return s.saveNewLeaf(k, rkey, newValH)
+}
+
+func (s *SMT) saveNewLeaf(k, rkey utils.NodeKey, newValH [4]uint64) ([4]uint64, error) {
 	newLeafHash, err := s.hashcalcAndSave(utils.ConcatArrays4(rkey, newValH), utils.LeafCapacity)
 
 	s.Db.InsertHashKey(newLeafHash, k)
@@ -401,3 +435,36 @@ func (s *SMT) createNewLeaf(k, rkey utils.NodeKey, v utils.NodeValue8) ([4]uint6
 
 	return newLeafHash, nil
 }
+
+// leafValuesPrefetchSize is how many leaf values are read and hashed in one batch when building the tree
+const leafValuesPrefetchSize = 4096
+
+type leafValue struct {
+	value utils.NodeValue8
+	hash  [4]uint64
+}
+
+// leafValueCache holds the values and value hashes of leaves that haven't been saved yet
+type leafValueCache map[utils.NodeKey]leafValue
+
+// prefetchLeafValues reads the values of the keys and hashes them in one batch
+func (s *SMT) prefetchLeafValues(nodeKeys []utils.NodeKey, leafValues leafValueCache, workers int) error {
+	inputs := make([]utils.HashInput, len(nodeKeys))
+	values := make([]utils.NodeValue8, len(nodeKeys))
+	for i, k := range nodeKeys {
+		v, err := s.Db.GetAccountValue(k)
+		if err != nil {
+			return err
+		}
+		values[i] = v
+		inputs[i] = utils.HashInput{In: v.ToUintArray(), Capacity: utils.BranchCapacity}
+	}
+
+	hashes := make([][4]uint64, len(inputs))
+	utils.HashBatch(inputs, hashes, workers)
+
+	for i, k := range nodeKeys {
+		leafValues[k] = leafValue{value: values[i], hash: hashes[i]}
+	}
+	return nil
+}
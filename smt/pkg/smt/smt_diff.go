@@ -0,0 +1,273 @@
+package smt
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+)
+
+// LeafChange is a key whose value differs between two roots, a value is zero when the key isn't in that tree
+type LeafChange struct {
+	Key  utils.NodeKey
+	From *big.Int
+	To   *big.Int
+}
+
+// AccountDiff holds the changed leaves of an account, fields are nil when unchanged
+type AccountDiff struct {
+	Balance    *LeafChange
+	Nonce      *LeafChange
+	CodeHash   *LeafChange
+	CodeLength *LeafChange
+	Storage    map[libcommon.Hash]*LeafChange
+}
+
+// StateDiff is a diff between two roots mapped back to accounts.  Unresolved holds the changes to keys without a key
+// source in the db.  Next is set when the diff was cut short, it is the key of the first change left out.
+type StateDiff struct {
+	Accounts   map[libcommon.Address]*AccountDiff
+	Unresolved []*LeafChange
+	Next       *utils.NodeKey
+}
+
+// diffNode is a subtree in one of the trees being compared.  A leaf stays a leaf while the other side is walked below
+// the level it sits at, so it only ever has a hash at the level it was read from the db.
+type diffNode struct {
+	hash      utils.NodeKey
+	branch    *utils.NodeValue12
+	leafKey   *utils.NodeKey
+	valueHash utils.NodeKey
+}
+
+// Diff walks the trees under the two roots calling fn for every key whose value differs, in the order of their paths
+// through the tree and starting from the key start when it is set.  Subtrees with the same hash are skipped.  Returning
+// false from fn stops the walk, the key of the change it was given can be used as start to carry on from there.
+func (s *SMT) Diff(ctx context.Context, from, to utils.NodeKey, start *utils.NodeKey, fn func(*LeafChange) (bool, error)) error {
+	a, err := s.loadDiffNode(from, nil)
+	if err != nil {
+		return err
+	}
+	b, err := s.loadDiffNode(to, nil)
+	if err != nil {
+		return err
+	}
+	var startPath []int
+	if start != nil {
+		startPath = start.GetPath()
+	}
+	_, err = s.diff(ctx, a, b, make([]int, 0, 256), startPath, fn)
+	return err
+}
+
+// diff compares the subtrees at path, startPath is only set while path is a prefix of the start key
+func (s *SMT) diff(ctx context.Context, a, b *diffNode, path []int, startPath []int, fn func(*LeafChange) (bool, error)) (bool, error) {
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	default:
+	}
+
+	if a == nil && b == nil {
+		return true, nil
+	}
+
+	if a != nil && b != nil {
+		if a.branch != nil && b.branch != nil && a.hash == b.hash {
+			return true, nil
+		}
+		if a.leafKey != nil && b.leafKey != nil && a.leafKey.IsEqualTo(*b.leafKey) {
+			if a.valueHash == b.valueHash {
+				return true, nil
+			}
+			return s.reportChange(*a.leafKey, a, b, startPath, fn)
+		}
+	}
+
+	// nothing left to split, the leaves on either side are for different keys and are reported in path order
+	if (a == nil || a.leafKey != nil) && (b == nil || b.leafKey != nil) {
+		if a != nil && b != nil && comparePaths(b.leafKey.GetPath(), a.leafKey.GetPath()) < 0 {
+			cont, err := s.reportChange(*b.leafKey, nil, b, startPath, fn)
+			if err != nil || !cont {
+				return cont, err
+			}
+			return s.reportChange(*a.leafKey, a, nil, startPath, fn)
+		}
+		if a != nil {
+			cont, err := s.reportChange(*a.leafKey, a, nil, startPath, fn)
+			if err != nil || !cont {
+				return cont, err
+			}
+		}
+		if b != nil {
+			return s.reportChange(*b.leafKey, nil, b, startPath, fn)
+		}
+		return true, nil
+	}
+
+	level := len(path)
+	for direction := 0; direction < 2; direction++ {
+		childStart := startPath
+		if startPath != nil {
+			if direction < startPath[level] {
+				continue
+			}
+			if direction > startPath[level] {
+				childStart = nil
+			}
+		}
+		aChild, err := s.diffChild(a, path, direction)
+		if err != nil {
+			return false, err
+		}
+		bChild, err := s.diffChild(b, path, direction)
+		if err != nil {
+			return false, err
+		}
+		cont, err := s.diff(ctx, aChild, bChild, append(path[:level], direction), childStart, fn)
+		if err != nil || !cont {
+			return cont, err
+		}
+	}
+
+	return true, nil
+}
+
+// diffChild returns the subtree in the direction, a leaf is passed down on the side of its key
+func (s *SMT) diffChild(n *diffNode, path []int, direction int) (*diffNode, error) {
+	if n == nil {
+		return nil, nil
+	}
+	if n.leafKey != nil {
+		if n.leafKey.GetPath()[len(path)] == direction {
+			return n, nil
+		}
+		return nil, nil
+	}
+
+	child := utils.NodeKeyFromBigIntArray(n.branch[direction*4 : direction*4+4])
+	usedBits := append(append(make([]int, 0, len(path)+1), path...), direction)
+	return s.loadDiffNode(child, usedBits)
+}
+
+func (s *SMT) loadDiffNode(hash utils.NodeKey, usedBits []int) (*diffNode, error) {
+	if hash.IsZero() {
+		return nil, nil
+	}
+
+	v, err := s.Db.Get(hash)
+	if err != nil {
+		return nil, err
+	}
+	if v[0] == nil {
+		return nil, errMissingNode(hash)
+	}
+
+	n := &diffNode{hash: hash}
+	if v.IsFinalNode() {
+		n.leafKey = utils.JoinKey(usedBits, utils.NodeKeyFromBigIntArray(v[0:4]))
+		n.valueHash = utils.NodeKeyFromBigIntArray(v[4:8])
+	} else {
+		n.branch = &v
+	}
+	return n, nil
+}
+
+func (s *SMT) reportChange(key utils.NodeKey, a, b *diffNode, startPath []int, fn func(*LeafChange) (bool, error)) (bool, error) {
+	if startPath != nil && comparePaths(key.GetPath(), startPath) < 0 {
+		return true, nil
+	}
+	from, err := s.leafValue(a)
+	if err != nil {
+		return false, err
+	}
+	to, err := s.leafValue(b)
+	if err != nil {
+		return false, err
+	}
+	if from.Cmp(to) == 0 {
+		return true, nil
+	}
+	return fn(&LeafChange{Key: key, From: from, To: to})
+}
+
+func (s *SMT) leafValue(n *diffNode) (*big.Int, error) {
+	if n == nil {
+		return big.NewInt(0), nil
+	}
+	return s.valueAt(n.valueHash)
+}
+
+func (s *SMT) valueAt(valueHash utils.NodeKey) (*big.Int, error) {
+	v, err := s.Db.Get(valueHash)
+	if err != nil {
+		return nil, err
+	}
+	if v[0] == nil {
+		return nil, errMissingNode(valueHash)
+	}
+	value := utils.Value8FromBigIntArray(v[0:8])
+	return utils.ArrayBigToScalar(utils.BigIntArrayFromNodeValue8(&value)), nil
+}
+
+func errMissingNode(hash utils.NodeKey) error {
+	return fmt.Errorf("smt node %s is missing", utils.ConvertBigIntToHex(hash.ToBigInt()))
+}
+
+// StateDiff diffs the two roots and maps the changed keys back to the accounts and storage slots they belong to.  It
+// starts from the key start when it is set and stops after limit changes when limit is positive.
+func (s *SMT) StateDiff(ctx context.Context, from, to utils.NodeKey, start *utils.NodeKey, limit int) (*StateDiff, error) {
+	result := &StateDiff{Accounts: make(map[libcommon.Address]*AccountDiff)}
+
+	var count int
+	err := s.Diff(ctx, from, to, start, func(change *LeafChange) (bool, error) {
+		if limit > 0 && count == limit {
+			next := change.Key
+			result.Next = &next
+			return false, nil
+		}
+		count++
+
+		t, addr, storage, ok, err := s.resolveKey(change.Key)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			result.Unresolved = append(result.Unresolved, change)
+			return true, nil
+		}
+
+		account, ok := result.Accounts[addr]
+		if !ok {
+			account = &AccountDiff{}
+			result.Accounts[addr] = account
+		}
+
+		switch t {
+		case utils.KEY_BALANCE:
+			account.Balance = change
+		case utils.KEY_NONCE:
+			account.Nonce = change
+		case utils.SC_CODE:
+			account.CodeHash = change
+		case utils.SC_LENGTH:
+			account.CodeLength = change
+		case utils.SC_STORAGE:
+			if account.Storage == nil {
+				account.Storage = make(map[libcommon.Hash]*LeafChange)
+			}
+			account.Storage[storage] = change
+		default:
+			result.Unresolved = append(result.Unresolved, change)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,186 @@
+package smt
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/holiman/uint256"
+
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+	"github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+)
+
+func TestSMT_Diff(t *testing.T) {
+	ctx := context.Background()
+	s := NewSMT(db.NewMemDb())
+	s.KeepStaleNodes = true
+
+	rand.Seed(1)
+	before := map[int]int{}
+	for i := 0; i < 300; i++ {
+		before[rand.Intn(1000)+1] = rand.Intn(100) + 1
+	}
+	insertBlock(t, s, 1, before)
+	from := utils.ScalarToRoot(s.LastRoot())
+
+	changes := map[int]int{}
+	for k := range before {
+		switch rand.Intn(4) {
+		case 0:
+			changes[k] = 0
+		case 1:
+			changes[k] = before[k] + 1
+		}
+	}
+	for i := 0; i < 50; i++ {
+		k := rand.Intn(1000) + 1001
+		changes[k] = rand.Intn(100) + 1
+	}
+	// writing the same value again is not a change
+	for k, v := range before {
+		if _, ok := changes[k]; !ok {
+			changes[k] = v
+			break
+		}
+	}
+	insertBlock(t, s, 2, changes)
+	to := utils.ScalarToRoot(s.LastRoot())
+
+	expected := map[utils.NodeKey][2]int64{}
+	for k, v := range changes {
+		if before[k] != v {
+			expected[utils.ScalarToNodeKey(big.NewInt(int64(k)))] = [2]int64{int64(before[k]), int64(v)}
+		}
+	}
+
+	found := map[utils.NodeKey][2]int64{}
+	var previous []int
+	err := s.Diff(ctx, from, to, nil, func(change *LeafChange) (bool, error) {
+		if _, ok := found[change.Key]; ok {
+			t.Errorf("key %v reported twice", change.Key)
+		}
+		if previous != nil && comparePaths(change.Key.GetPath(), previous) <= 0 {
+			t.Errorf("key %v reported out of path order", change.Key)
+		}
+		previous = change.Key.GetPath()
+		found[change.Key] = [2]int64{change.From.Int64(), change.To.Int64()}
+		return true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(found) != len(expected) {
+		t.Fatalf("expected %d changes, got %d", len(expected), len(found))
+	}
+	for k, v := range expected {
+		if found[k] != v {
+			t.Errorf("key %v: expected %v, got %v", k, v, found[k])
+		}
+	}
+
+	// diffing a root with itself finds nothing
+	if err = s.Diff(ctx, to, to, nil, func(change *LeafChange) (bool, error) {
+		t.Errorf("unexpected change %v", change.Key)
+		return true, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// paging through the diff finds every change once
+	paged := map[utils.NodeKey]bool{}
+	var start *utils.NodeKey
+	for pages := 0; ; pages++ {
+		if pages > len(expected) {
+			t.Fatal("paging does not terminate")
+		}
+		diff, err := s.StateDiff(ctx, from, to, start, 7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(diff.Unresolved) > 7 {
+			t.Fatalf("expected at most 7 changes in a page, got %d", len(diff.Unresolved))
+		}
+		for _, change := range diff.Unresolved {
+			if paged[change.Key] {
+				t.Errorf("key %v reported on two pages", change.Key)
+			}
+			paged[change.Key] = true
+		}
+		if diff.Next == nil {
+			break
+		}
+		start = diff.Next
+	}
+	if len(paged) != len(expected) {
+		t.Fatalf("expected %d changes over all pages, got %d", len(expected), len(paged))
+	}
+}
+
+func TestSMT_StateDiff(t *testing.T) {
+	ctx := context.Background()
+	s := NewSMT(db.NewMemDb())
+	s.KeepStaleNodes = true
+
+	addr1 := libcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+	addr2 := libcommon.HexToAddress("0x2000000000000000000000000000000000000002")
+	slot := "0x0000000000000000000000000000000000000000000000000000000000000001"
+
+	account := func(balance, nonce uint64) *accounts.Account {
+		return &accounts.Account{Balance: *uint256.NewInt(balance), Nonce: nonce}
+	}
+
+	if _, _, err := s.SetStorage("", map[libcommon.Address]*accounts.Account{
+		addr1: account(100, 1),
+		addr2: account(200, 0),
+	}, nil, map[libcommon.Address]map[string]string{
+		addr2: {slot: "0x05"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	from := utils.ScalarToRoot(s.LastRoot())
+
+	if _, _, err := s.SetStorage("", map[libcommon.Address]*accounts.Account{
+		addr1: account(90, 2),
+	}, nil, map[libcommon.Address]map[string]string{
+		addr2: {slot: "0x07"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	to := utils.ScalarToRoot(s.LastRoot())
+
+	diff, err := s.StateDiff(ctx, from, to, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Unresolved) != 0 {
+		t.Fatalf("expected every change to resolve, got %d unresolved", len(diff.Unresolved))
+	}
+	if len(diff.Accounts) != 2 {
+		t.Fatalf("expected 2 changed accounts, got %d", len(diff.Accounts))
+	}
+
+	a1 := diff.Accounts[addr1]
+	if a1 == nil || a1.Balance == nil || a1.Balance.From.Int64() != 100 || a1.Balance.To.Int64() != 90 {
+		t.Fatalf("unexpected balance change for %s: %+v", addr1, a1)
+	}
+	if a1.Nonce == nil || a1.Nonce.From.Int64() != 1 || a1.Nonce.To.Int64() != 2 {
+		t.Fatalf("unexpected nonce change for %s: %+v", addr1, a1.Nonce)
+	}
+	if a1.Storage != nil || a1.CodeHash != nil {
+		t.Fatalf("unexpected changes for %s: %+v", addr1, a1)
+	}
+
+	a2 := diff.Accounts[addr2]
+	if a2 == nil || a2.Balance != nil || a2.Nonce != nil || len(a2.Storage) != 1 {
+		t.Fatalf("unexpected changes for %s: %+v", addr2, a2)
+	}
+	change := a2.Storage[libcommon.HexToHash(slot)]
+	if change == nil || change.From.Int64() != 5 || change.To.Int64() != 7 {
+		t.Fatalf("unexpected storage change for %s: %+v", addr2, change)
+	}
+}
@@ -0,0 +1,144 @@
+package smt
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+)
+
+const gcSweeping = byte(1)
+
+type GcResult struct {
+	Marked   int
+	Swept    int
+	Finished bool
+}
+
+// CollectGarbage runs one step of a mark and sweep over the tree, deleting the nodes that can't be reached from the
+// last root or any of the given roots.  At most budget nodes are marked or looked at by the sweep in a call and the
+// progress is kept in the db, so calling it repeatedly completes a full pass without holding up whoever runs it.
+//
+// Marks are kept until a pass finishes.  Whilst sweeping, the nodes written since the previous call are marked first
+// regardless of the budget so nothing reachable from the roots is ever swept.
+func (s *SMT) CollectGarbage(ctx context.Context, roots []utils.NodeKey, budget int) (*GcResult, error) {
+	gcDb, ok := s.Db.(GcDB)
+	if !ok {
+		return nil, errors.New("smt db does not support garbage collection")
+	}
+
+	s.clearUpMutex.Lock()
+	defer s.clearUpMutex.Unlock()
+
+	lastRoot, err := s.getLastRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := gcDb.GetGcState()
+	if err != nil {
+		return nil, err
+	}
+	sweeping := len(state) > 0 && state[0] == gcSweeping
+
+	markBudget := budget
+	if sweeping {
+		markBudget = math.MaxInt
+	}
+
+	result := &GcResult{}
+	for _, root := range append([]utils.NodeKey{lastRoot}, roots...) {
+		done, err := s.markReachable(ctx, gcDb, root, markBudget, &result.Marked)
+		if err != nil {
+			return nil, err
+		}
+		if !done {
+			return result, nil
+		}
+	}
+
+	from := []byte{}
+	if sweeping {
+		from = state[1:]
+	}
+
+	sweepBudget := budget - result.Marked
+	if sweepBudget <= 0 {
+		return result, gcDb.SetGcState(append([]byte{gcSweeping}, from...))
+	}
+
+	next, swept, err := gcDb.SweepUnmarked(from, sweepBudget)
+	if err != nil {
+		return nil, err
+	}
+	result.Swept = swept
+
+	if next != nil {
+		return result, gcDb.SetGcState(append([]byte{gcSweeping}, next...))
+	}
+
+	if err = gcDb.ClearMarks(); err != nil {
+		return nil, err
+	}
+	result.Finished = true
+
+	return result, gcDb.SetGcState(nil)
+}
+
+// markReachable marks the node and everything below it, stopping once marked reaches the budget.  A node is only
+// marked after all of its children so a marked node never has to be visited again, which lets the marking carry on
+// where it left off in the next call.
+func (s *SMT) markReachable(ctx context.Context, gcDb GcDB, key utils.NodeKey, budget int, marked *int) (bool, error) {
+	if key.IsZero() {
+		return true, nil
+	}
+
+	isMarked, err := gcDb.IsNodeMarked(key)
+	if err != nil {
+		return false, err
+	}
+	if isMarked {
+		return true, nil
+	}
+	if *marked >= budget {
+		return false, nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	default:
+	}
+
+	node, err := s.Db.Get(key)
+	if err != nil {
+		return false, err
+	}
+	if node[0] == nil {
+		// roots of blocks hashed as part of a range were never written, there is nothing under them to keep
+		return true, nil
+	}
+
+	if node.IsFinalNode() {
+		// the value of a leaf is a node of its own which can be shared by any leaf holding the same value
+		if err = gcDb.MarkNode(utils.NodeKeyFromBigIntArray(node[4:8])); err != nil {
+			return false, err
+		}
+		*marked++
+	} else {
+		for i := 0; i < 2; i++ {
+			done, err := s.markReachable(ctx, gcDb, utils.NodeKeyFromBigIntArray(node[i*4:i*4+4]), budget, marked)
+			if err != nil || !done {
+				return done, err
+			}
+		}
+	}
+
+	if err = gcDb.MarkNode(key); err != nil {
+		return false, err
+	}
+	*marked++
+
+	return true, nil
+}
@@ -0,0 +1,185 @@
+package smt
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+)
+
+func TestSMT_CollectGarbage(t *testing.T) {
+	ctx := context.Background()
+	memDb := db.NewMemDb()
+	s := NewSMT(memDb)
+
+	for i := 1; i <= 50; i++ {
+		mustInsert(t, s, i, i)
+	}
+	retained := utils.ScalarToRoot(s.LastRoot())
+
+	for i := 1; i <= 50; i++ {
+		mustInsert(t, s, i, i+100)
+	}
+	for i := 1; i <= 10; i++ {
+		mustInsert(t, s, i, 0)
+	}
+
+	result, err := s.CollectGarbage(ctx, []utils.NodeKey{retained}, math.MaxInt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Finished || result.Swept == 0 {
+		t.Fatalf("expected a finished pass sweeping nodes, got %+v", result)
+	}
+
+	live := reachableNodes(t, s, memDb, s.LastRoot())
+	for k := range reachableNodes(t, s, memDb, retained.ToBigInt()) {
+		live[k] = struct{}{}
+	}
+	if len(live) != len(memDb.Db) {
+		t.Fatalf("expected %d nodes left, got %d", len(live), len(memDb.Db))
+	}
+
+	// once the old root is no longer retained its nodes go too
+	if result, err = s.CollectGarbage(ctx, nil, math.MaxInt); err != nil {
+		t.Fatal(err)
+	}
+	if !result.Finished || result.Swept == 0 {
+		t.Fatalf("expected a finished pass sweeping nodes, got %+v", result)
+	}
+	if live = reachableNodes(t, s, memDb, s.LastRoot()); len(live) != len(memDb.Db) {
+		t.Fatalf("expected %d nodes left, got %d", len(live), len(memDb.Db))
+	}
+}
+
+func TestSMT_CollectGarbageIncremental(t *testing.T) {
+	ctx := context.Background()
+	memDb := db.NewMemDb()
+	s := NewSMT(memDb)
+
+	values := map[int]int{}
+	for i := 1; i <= 50; i++ {
+		mustInsert(t, s, i, i)
+		values[i] = i
+	}
+
+	// keep changing the tree between the steps of the pass
+	finished := false
+	for i := 0; i < 1000 && !finished; i++ {
+		result, err := s.CollectGarbage(ctx, nil, 25)
+		if err != nil {
+			t.Fatal(err)
+		}
+		finished = result.Finished
+
+		key := i%60 + 1
+		mustInsert(t, s, key, i+1000)
+		values[key] = i + 1000
+	}
+	if !finished {
+		t.Fatal("garbage collection did not finish")
+	}
+
+	reachableNodes(t, s, memDb, s.LastRoot())
+
+	expected := NewSMT(nil)
+	for k, v := range values {
+		mustInsert(t, expected, k, v)
+	}
+	if s.LastRoot().Cmp(expected.LastRoot()) != 0 {
+		t.Fatalf("expected root %x, got %x", expected.LastRoot(), s.LastRoot())
+	}
+
+	// the collected tree can still be written to
+	mustInsert(t, s, 1, 1)
+	mustInsert(t, expected, 1, 1)
+	if s.LastRoot().Cmp(expected.LastRoot()) != 0 {
+		t.Fatalf("expected root %x, got %x", expected.LastRoot(), s.LastRoot())
+	}
+}
+
+func TestSMT_CollectGarbageStaleNodes(t *testing.T) {
+	ctx := context.Background()
+	memDb := db.NewMemDb()
+	s := NewSMT(memDb)
+	s.KeepStaleNodes = true
+
+	mustInsertBatch(t, s, map[int]int{1: 10, 2: 20, 3: 30, 4: 40})
+	retained := utils.ScalarToRoot(s.LastRoot())
+	mustInsertBatch(t, s, map[int]int{1: 11, 3: 0, 5: 50})
+
+	// the batch insert left the nodes of the old root for the collector
+	reachableNodes(t, s, memDb, retained.ToBigInt())
+
+	// a root which was never written, like that of a block hashed as part of a range, has nothing to keep
+	unwritten := utils.ScalarToRoot(big.NewInt(12345))
+	result, err := s.CollectGarbage(ctx, []utils.NodeKey{retained, unwritten}, math.MaxInt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Finished {
+		t.Fatalf("expected a finished pass, got %+v", result)
+	}
+	reachableNodes(t, s, memDb, retained.ToBigInt())
+
+	if result, err = s.CollectGarbage(ctx, nil, math.MaxInt); err != nil {
+		t.Fatal(err)
+	}
+	if !result.Finished || result.Swept == 0 {
+		t.Fatalf("expected a finished pass sweeping nodes, got %+v", result)
+	}
+	if live := reachableNodes(t, s, memDb, s.LastRoot()); len(live) != len(memDb.Db) {
+		t.Fatalf("expected %d nodes left, got %d", len(live), len(memDb.Db))
+	}
+}
+
+func mustInsertBatch(t *testing.T, s *SMT, values map[int]int) {
+	keys := make([]*utils.NodeKey, 0, len(values))
+	vals := make([]*utils.NodeValue8, 0, len(values))
+	for k, v := range values {
+		key := utils.ScalarToNodeKey(big.NewInt(int64(k)))
+		val, err := utils.NodeValue8FromBigInt(big.NewInt(int64(v)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, &key)
+		vals = append(vals, val)
+	}
+	if _, err := s.InsertBatch("", keys, vals, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mustInsert(t *testing.T, s *SMT, k, v int) {
+	if _, err := s.InsertBI(big.NewInt(int64(k)), big.NewInt(int64(v))); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// reachableNodes walks the tree from root failing on any node missing from the db
+func reachableNodes(t *testing.T, s *SMT, memDb *db.MemDb, root *big.Int) map[string]struct{} {
+	nodes := map[string]struct{}{}
+	check := func(k utils.NodeKey) {
+		key := utils.ConvertBigIntToHex(utils.ArrayToScalar(k[:]))
+		if _, ok := memDb.Db[key]; !ok {
+			t.Fatalf("node %s is missing", key)
+		}
+		nodes[key] = struct{}{}
+	}
+
+	err := s.Traverse(context.Background(), root, func(prefix []byte, k utils.NodeKey, v utils.NodeValue12) (bool, error) {
+		check(k)
+		if v.IsFinalNode() {
+			check(utils.NodeKeyFromBigIntArray(v[4:8]))
+		}
+		return true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return nodes
+}
@@ -0,0 +1,132 @@
+package smt
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+)
+
+var ErrHistoryNotAvailable = errors.New("smt history not available for block")
+
+// Proof is the path through the tree to a key.  When the key isn't in the tree the path ends at an empty branch or at
+// the leaf of another key which shares its prefix.
+type Proof struct {
+	Root     utils.NodeKey
+	Siblings []utils.NodeKey // siblings of the nodes on the path, from the root down
+	Value    utils.NodeValue8
+
+	LeafRemainingKey *utils.NodeKey // nil when the path ends at an empty branch
+	LeafValueHash    *utils.NodeKey
+	KeyLeaf          *utils.NodeKey // the leaf holding the key, nil when the key isn't in the tree
+}
+
+// RootAt returns the root recorded for the block, the nodes under it are kept for as long as the block is inside the
+// history window
+func (s *SMT) RootAt(blockNo uint64) (*big.Int, error) {
+	historyDb, ok := s.Db.(HistoryDB)
+	if !ok {
+		return nil, fmt.Errorf("%w %d: db does not record roots", ErrHistoryNotAvailable, blockNo)
+	}
+
+	root, err := historyDb.GetRootAt(blockNo)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, fmt.Errorf("%w %d", ErrHistoryNotAvailable, blockNo)
+	}
+	return root, nil
+}
+
+// RootAtOrLast returns the root recorded for the block, falling back to the current root when the block is lastBlock,
+// the block the tree was last hashed at, so that block is available without the history being kept
+func (s *SMT) RootAtOrLast(blockNo, lastBlock uint64) (utils.NodeKey, error) {
+	root, err := s.RootAt(blockNo)
+	if err == nil {
+		return utils.ScalarToRoot(root), nil
+	}
+	if !errors.Is(err, ErrHistoryNotAvailable) {
+		return utils.NodeKey{}, err
+	}
+	if blockNo != lastBlock {
+		return utils.NodeKey{}, fmt.Errorf("%w %d, the smt is at block %d", ErrHistoryNotAvailable, blockNo, lastBlock)
+	}
+	return utils.ScalarToRoot(s.LastRoot()), nil
+}
+
+func (s *SMT) GetProofAt(key utils.NodeKey, blockNo uint64) (*Proof, error) {
+	root, err := s.RootAt(blockNo)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetProof(key, utils.ScalarToRoot(root))
+}
+
+// GetProof walks the tree under root to the key
+func (s *SMT) GetProof(key utils.NodeKey, root utils.NodeKey) (*Proof, error) {
+	proof := &Proof{Root: root}
+	for i := range proof.Value {
+		proof.Value[i] = big.NewInt(0)
+	}
+	path := key.GetPath()
+
+	node := root
+	for level := 0; !node.IsZero(); level++ {
+		v, err := s.Db.Get(node)
+		if err != nil {
+			return nil, err
+		}
+		if v[0] == nil {
+			return nil, errMissingNode(node)
+		}
+
+		if v.IsFinalNode() {
+			rKey := utils.NodeKeyFromBigIntArray(v[0:4])
+			valueHash := utils.NodeKeyFromBigIntArray(v[4:8])
+			proof.LeafRemainingKey = &rKey
+			proof.LeafValueHash = &valueHash
+
+			if utils.JoinKey(path[:level], rKey).IsEqualTo(key) {
+				value, err := s.Db.Get(valueHash)
+				if err != nil {
+					return nil, err
+				}
+				proof.Value = utils.Value8FromBigIntArray(value[0:8])
+				leaf := node
+				proof.KeyLeaf = &leaf
+			}
+			return proof, nil
+		}
+
+		sibling := 1 - path[level]
+		proof.Siblings = append(proof.Siblings, utils.NodeKeyFromBigIntArray(v[sibling*4:sibling*4+4]))
+		node = utils.NodeKeyFromBigIntArray(v[path[level]*4 : path[level]*4+4])
+	}
+
+	return proof, nil
+}
+
+// RevertKeys brings the key source and hash key tables of the given keys back in line with the tree under root, once
+// the last root was moved back to it by an unwind.  sources holds the key source of each key.  Entries of the unwound
+// blocks are not deleted: older roots of the history window may share them, the same as going forward with stale nodes
+// kept, and the garbage collector removes the hash keys along with their leaves.
+func (s *SMT) RevertKeys(root utils.NodeKey, sources map[utils.NodeKey][]byte) error {
+	for key, source := range sources {
+		proof, err := s.GetProof(key, root)
+		if err != nil {
+			return err
+		}
+		if proof.KeyLeaf == nil {
+			continue
+		}
+		if err = s.Db.InsertHashKey(*proof.KeyLeaf, key); err != nil {
+			return err
+		}
+		if err = s.Db.InsertKeySource(key, source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package smt
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+)
+
+func insertBlock(t *testing.T, s *SMT, blockNo uint64, values map[int]int) {
+	t.Helper()
+	keys := make([]*utils.NodeKey, 0, len(values))
+	vals := make([]*utils.NodeValue8, 0, len(values))
+	for k, v := range values {
+		key := utils.ScalarToNodeKey(big.NewInt(int64(k)))
+		val, err := utils.NodeValue8FromBigInt(big.NewInt(int64(v)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, &key)
+		vals = append(vals, val)
+	}
+	if _, err := s.InsertBatch("", keys, vals, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Db.(HistoryDB).SetRootAt(blockNo, s.LastRoot()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func verifyProof(t *testing.T, key utils.NodeKey, proof *Proof) {
+	t.Helper()
+	valueHash, err := utils.Hash(proof.Value.ToUintArray(), utils.BranchCapacity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	level := len(proof.Siblings)
+	node, err := utils.Hash(utils.ConcatArrays4(utils.RemoveKeyBits(key, level), valueHash), utils.LeafCapacity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := key.GetPath()
+	for i := level - 1; i >= 0; i-- {
+		in := utils.ConcatArrays4(node, proof.Siblings[i])
+		if path[i] == 1 {
+			in = utils.ConcatArrays4(proof.Siblings[i], node)
+		}
+		if node, err = utils.Hash(in, utils.BranchCapacity); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if utils.NodeKey(node) != proof.Root {
+		t.Fatalf("proof for key %v does not hash to the root", key)
+	}
+}
+
+func TestSMT_GetValueAt(t *testing.T) {
+	s := NewSMT(db.NewMemDb())
+	s.KeepStaleNodes = true
+
+	blocks := []map[int]int{
+		{1: 10, 2: 20, 3: 30},
+		{1: 11, 4: 40},
+		{2: 0, 5: 50},
+		{1: 0, 3: 31, 6: 60},
+	}
+	expected := map[int]int{}
+	history := make([]map[int]int, 0, len(blocks))
+	for i, changes := range blocks {
+		insertBlock(t, s, uint64(i+1), changes)
+		for k, v := range changes {
+			expected[k] = v
+		}
+		snapshot := map[int]int{}
+		for k, v := range expected {
+			snapshot[k] = v
+		}
+		history = append(history, snapshot)
+	}
+
+	for i, values := range history {
+		blockNo := uint64(i + 1)
+		for k := 1; k <= 6; k++ {
+			key := utils.ScalarToNodeKey(big.NewInt(int64(k)))
+			proof, err := s.GetProofAt(key, blockNo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := utils.ArrayBigToScalar(utils.BigIntArrayFromNodeValue8(&proof.Value))
+			if got.Cmp(big.NewInt(int64(values[k]))) != 0 {
+				t.Errorf("block %d key %d: expected %d, got %d", blockNo, k, values[k], got)
+			}
+			if values[k] != 0 {
+				verifyProof(t, key, proof)
+			}
+		}
+	}
+
+	if _, err := s.RootAt(10); !errors.Is(err, ErrHistoryNotAvailable) {
+		t.Fatalf("expected ErrHistoryNotAvailable, got %v", err)
+	}
+}
+
+func TestSMT_GetValueAtWithoutStaleNodes(t *testing.T) {
+	s := NewSMT(db.NewMemDb())
+
+	insertBlock(t, s, 1, map[int]int{1: 10, 2: 20, 3: 30})
+	insertBlock(t, s, 2, map[int]int{1: 11, 2: 21, 3: 31})
+
+	key := utils.ScalarToNodeKey(big.NewInt(1))
+	if _, err := s.GetProofAt(key, 1); err == nil {
+		t.Fatal("expected the replaced nodes of block 1 to be gone")
+	}
+	proof, err := s.GetProofAt(key, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := utils.ArrayBigToScalar(utils.BigIntArrayFromNodeValue8(&proof.Value)); got.Cmp(big.NewInt(11)) != 0 {
+		t.Fatalf("expected 11, got %d", got)
+	}
+}
+
+func TestSMT_RootAtOrLast(t *testing.T) {
+	s := NewSMT(db.NewMemDb())
+	s.KeepStaleNodes = true
+	insertBlock(t, s, 1, map[int]int{1: 10})
+	recorded := s.LastRoot()
+
+	// a block hashed without the history being recorded
+	if _, err := s.InsertBatch("", []*utils.NodeKey{{1}}, []*utils.NodeValue8{{big.NewInt(1), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0)}}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := s.RootAtOrLast(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != utils.ScalarToRoot(recorded) {
+		t.Fatal("expected the recorded root of block 1")
+	}
+	if root, err = s.RootAtOrLast(2, 2); err != nil {
+		t.Fatal(err)
+	}
+	if root != utils.ScalarToRoot(s.LastRoot()) {
+		t.Fatal("expected the current root for the last hashed block")
+	}
+	if _, err = s.RootAtOrLast(3, 2); !errors.Is(err, ErrHistoryNotAvailable) {
+		t.Fatalf("expected ErrHistoryNotAvailable, got %v", err)
+	}
+}
+
+func TestSMT_RevertKeys(t *testing.T) {
+	s := NewSMT(db.NewMemDb())
+	s.KeepStaleNodes = true
+
+	source := func(k int) []byte { return []byte{byte(k)} }
+	insertBlock(t, s, 1, map[int]int{1: 10, 2: 20, 3: 30})
+	root1 := utils.ScalarToRoot(s.LastRoot())
+	insertBlock(t, s, 2, map[int]int{1: 0, 2: 21, 4: 40})
+	root2 := utils.ScalarToRoot(s.LastRoot())
+
+	leafAt := func(root utils.NodeKey, k int) *utils.NodeKey {
+		proof, err := s.GetProof(utils.ScalarToNodeKey(big.NewInt(int64(k))), root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return proof.KeyLeaf
+	}
+	if leafAt(root2, 1) != nil || leafAt(root2, 4) == nil {
+		t.Fatal("expected block 2 to delete key 1 and add key 4")
+	}
+
+	// the aux tables lost the entries of block 1, unwind to it
+	touched := map[utils.NodeKey][]byte{}
+	for _, k := range []int{1, 2, 4} {
+		key := utils.ScalarToNodeKey(big.NewInt(int64(k)))
+		touched[key] = source(k)
+		if leaf := leafAt(root1, k); leaf != nil {
+			if err := s.Db.DeleteHashKey(*leaf); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	s.SetLastRoot(root1.ToBigInt())
+	if err := s.RevertKeys(root1, touched); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, k := range []int{1, 2} {
+		key := utils.ScalarToNodeKey(big.NewInt(int64(k)))
+		if got, err := s.Db.GetKeySource(key); err != nil || got[0] != byte(k) {
+			t.Errorf("key %d: expected its key source back, got %v %v", k, got, err)
+		}
+		if hashKey, err := s.Db.GetHashKey(*leafAt(root1, k)); err != nil || hashKey != key {
+			t.Errorf("key %d: expected the hash key of its leaf back, got %v %v", k, hashKey, err)
+		}
+	}
+	if _, err := s.Db.GetKeySource(utils.ScalarToNodeKey(big.NewInt(4))); err == nil {
+		t.Error("key 4 is not in the tree at block 1, it should not get a key source")
+	}
+}
@@ -0,0 +1,151 @@
+package smt
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+
+	"github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+)
+
+// StateLeaf is a leaf of the SMT decoded into the account field it holds.  Resolved is false when the db has no key
+// source for the key, the leaf then only has its key and value.
+type StateLeaf struct {
+	Key        utils.NodeKey
+	Value      *big.Int
+	Resolved   bool
+	Type       int
+	Address    libcommon.Address
+	StorageKey libcommon.Hash
+}
+
+// Field names the account field of the leaf
+func (l *StateLeaf) Field() string {
+	if !l.Resolved {
+		return "unknown"
+	}
+	return FieldName(l.Type)
+}
+
+// FieldName names the account field held by leaves with the key source type
+func FieldName(t int) string {
+	switch t {
+	case utils.KEY_BALANCE:
+		return "balance"
+	case utils.KEY_NONCE:
+		return "nonce"
+	case utils.SC_CODE:
+		return "codeHash"
+	case utils.SC_STORAGE:
+		return "storage"
+	case utils.SC_LENGTH:
+		return "codeLength"
+	default:
+		return "unknown"
+	}
+}
+
+// IterateLeaves calls fn for every leaf under root in the order of their paths through the tree, starting from the
+// leaf whose key is start when it is set.  Returning false from fn stops the walk, the next leaf's key can be used
+// as start to carry on from there.
+func (s *SMT) IterateLeaves(ctx context.Context, root utils.NodeKey, start *utils.NodeKey, fn func(key utils.NodeKey, value *big.Int) (bool, error)) error {
+	var startPath []int
+	if start != nil {
+		startPath = start.GetPath()
+	}
+	_, err := s.iterateLeaves(ctx, root, make([]int, 0, 256), startPath, fn)
+	return err
+}
+
+// iterateLeaves walks the subtree at path, startPath is only set while path is a prefix of the start key
+func (s *SMT) iterateLeaves(ctx context.Context, node utils.NodeKey, path []int, startPath []int, fn func(key utils.NodeKey, value *big.Int) (bool, error)) (bool, error) {
+	if node.IsZero() {
+		return true, nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	default:
+	}
+
+	v, err := s.Db.Get(node)
+	if err != nil {
+		return false, err
+	}
+	if v[0] == nil {
+		return false, errMissingNode(node)
+	}
+
+	if v.IsFinalNode() {
+		key := utils.JoinKey(path, utils.NodeKeyFromBigIntArray(v[0:4]))
+		if startPath != nil && comparePaths(key.GetPath(), startPath) < 0 {
+			return true, nil
+		}
+		value, err := s.valueAt(utils.NodeKeyFromBigIntArray(v[4:8]))
+		if err != nil {
+			return false, err
+		}
+		return fn(*key, value)
+	}
+
+	level := len(path)
+	for direction := 0; direction < 2; direction++ {
+		childStart := startPath
+		if startPath != nil {
+			if direction < startPath[level] {
+				continue
+			}
+			if direction > startPath[level] {
+				childStart = nil
+			}
+		}
+		child := utils.NodeKeyFromBigIntArray(v[direction*4 : direction*4+4])
+		cont, err := s.iterateLeaves(ctx, child, append(path[:level], direction), childStart, fn)
+		if err != nil || !cont {
+			return cont, err
+		}
+	}
+
+	return true, nil
+}
+
+func comparePaths(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+	return len(a) - len(b)
+}
+
+// IterateState is IterateLeaves with every leaf mapped back to its account through the key sources
+func (s *SMT) IterateState(ctx context.Context, root utils.NodeKey, start *utils.NodeKey, fn func(*StateLeaf) (bool, error)) error {
+	return s.IterateLeaves(ctx, root, start, func(key utils.NodeKey, value *big.Int) (bool, error) {
+		t, addr, storage, ok, err := s.resolveKey(key)
+		if err != nil {
+			return false, err
+		}
+		return fn(&StateLeaf{Key: key, Value: value, Resolved: ok, Type: t, Address: addr, StorageKey: storage})
+	})
+}
+
+// resolveKey decodes the key source of the key, it returns false when there isn't one
+func (s *SMT) resolveKey(key utils.NodeKey) (int, libcommon.Address, libcommon.Hash, bool, error) {
+	// key sources are only written for keys that have been set, a missing one is reported rather than failing
+	keySource, err := s.Db.GetKeySource(key)
+	if errors.Is(err, db.ErrKeySourceNotFound) {
+		return 0, libcommon.Address{}, libcommon.Hash{}, false, nil
+	}
+	if err != nil {
+		return 0, libcommon.Address{}, libcommon.Hash{}, false, err
+	}
+	t, addr, storage, err := utils.DecodeKeySource(keySource)
+	if err != nil {
+		return 0, libcommon.Address{}, libcommon.Hash{}, false, err
+	}
+	return t, addr, storage, true, nil
+}
@@ -0,0 +1,167 @@
+package smt
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/holiman/uint256"
+
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+	"github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+)
+
+func TestSMT_IterateLeaves(t *testing.T) {
+	ctx := context.Background()
+	s := NewSMT(db.NewMemDb())
+
+	rand.Seed(2)
+	values := map[int]int{}
+	for i := 0; i < 500; i++ {
+		values[rand.Intn(100000)+1] = rand.Intn(1000) + 1
+	}
+	insertBlock(t, s, 1, values)
+	root := utils.ScalarToRoot(s.LastRoot())
+
+	byKey := make(map[utils.NodeKey]int, len(values))
+	for k, v := range values {
+		byKey[utils.ScalarToNodeKey(big.NewInt(int64(k)))] = v
+	}
+
+	var keys []utils.NodeKey
+	err := s.IterateLeaves(ctx, root, nil, func(key utils.NodeKey, value *big.Int) (bool, error) {
+		expected, ok := byKey[key]
+		if !ok {
+			t.Fatalf("unexpected key %v", key)
+		}
+		if value.Cmp(big.NewInt(int64(expected))) != 0 {
+			t.Fatalf("key %v: expected %d, got %d", key, expected, value)
+		}
+		if len(keys) > 0 && comparePaths(keys[len(keys)-1].GetPath(), key.GetPath()) >= 0 {
+			t.Fatalf("key %v is out of order", key)
+		}
+		keys = append(keys, key)
+		return true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != len(values) {
+		t.Fatalf("expected %d leaves, got %d", len(values), len(keys))
+	}
+
+	// walking in pages gives the same leaves in the same order
+	const pageSize = 37
+	var paged []utils.NodeKey
+	var start *utils.NodeKey
+	for {
+		var next *utils.NodeKey
+		count := 0
+		err := s.IterateLeaves(ctx, root, start, func(key utils.NodeKey, value *big.Int) (bool, error) {
+			if count == pageSize {
+				next = &key
+				return false, nil
+			}
+			paged = append(paged, key)
+			count++
+			return true, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next == nil {
+			break
+		}
+		start = next
+	}
+	if len(paged) != len(keys) {
+		t.Fatalf("expected %d paged leaves, got %d", len(keys), len(paged))
+	}
+	for i := range keys {
+		if keys[i] != paged[i] {
+			t.Fatalf("leaf %d: expected %v, got %v", i, keys[i], paged[i])
+		}
+	}
+}
+
+func TestSMT_IterateState(t *testing.T) {
+	ctx := context.Background()
+	s := NewSMT(db.NewMemDb())
+
+	addr := libcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+	slot := "0x0000000000000000000000000000000000000000000000000000000000000003"
+	if _, _, err := s.SetStorage("", map[libcommon.Address]*accounts.Account{
+		addr: {Balance: *uint256.NewInt(100), Nonce: 4},
+	}, nil, map[libcommon.Address]map[string]string{
+		addr: {slot: "0x09"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	fields := map[string]int64{}
+	err := s.IterateState(ctx, utils.ScalarToRoot(s.LastRoot()), nil, func(leaf *StateLeaf) (bool, error) {
+		if !leaf.Resolved || leaf.Address != addr {
+			t.Fatalf("unexpected leaf %+v", leaf)
+		}
+		if leaf.Type == utils.SC_STORAGE && leaf.StorageKey != libcommon.HexToHash(slot) {
+			t.Fatalf("unexpected storage key %s", leaf.StorageKey)
+		}
+		fields[leaf.Field()] = leaf.Value.Int64()
+		return true, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]int64{"balance": 100, "nonce": 4, "storage": 9}
+	if len(fields) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, fields)
+	}
+	for field, value := range expected {
+		if fields[field] != value {
+			t.Errorf("%s: expected %d, got %d", field, value, fields[field])
+		}
+	}
+}
+
+// keySourceErrDb fails to read key sources the way a broken db would
+type keySourceErrDb struct {
+	*db.MemDb
+}
+
+func (d keySourceErrDb) GetKeySource(key utils.NodeKey) ([]byte, error) {
+	return nil, errors.New("read failed")
+}
+
+func TestSMT_IterateStateUnresolved(t *testing.T) {
+	ctx := context.Background()
+	s := NewSMT(db.NewMemDb())
+	insertBlock(t, s, 1, map[int]int{1: 10, 2: 20})
+	root := utils.ScalarToRoot(s.LastRoot())
+
+	// keys without a key source are reported unresolved
+	var unresolved int
+	if err := s.IterateState(ctx, root, nil, func(leaf *StateLeaf) (bool, error) {
+		if !leaf.Resolved {
+			unresolved++
+		}
+		return true, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if unresolved != 2 {
+		t.Fatalf("expected 2 unresolved leaves, got %d", unresolved)
+	}
+
+	// any other failure to read the key source is an error
+	s.Db = keySourceErrDb{s.Db.(*db.MemDb)}
+	if err := s.IterateState(ctx, root, nil, func(leaf *StateLeaf) (bool, error) {
+		return true, nil
+	}); err == nil {
+		t.Fatal("expected the key source read error")
+	}
+}
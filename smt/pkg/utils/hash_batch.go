@@ -0,0 +1,50 @@
+package utils
+
+import "sync"
+
+// minHashesPerWorker is the fewest hashes handed to a goroutine, below it starting the goroutine costs more than it saves
+const minHashesPerWorker = 64
+
+// HashInput is the data of one hash in a batch
+type HashInput struct {
+	In       [8]uint64
+	Capacity [4]uint64
+}
+
+// HashBatch hashes every input into the same index of out using up to workers goroutines.  The hashes are
+// independent so the results are the same whatever the number of workers.
+func HashBatch(inputs []HashInput, out [][4]uint64, workers int) {
+	if len(out) < len(inputs) {
+		panic("hash batch output is shorter than the inputs")
+	}
+
+	if maxWorkers := len(inputs) / minHashesPerWorker; workers > maxWorkers {
+		workers = maxWorkers
+	}
+	if workers <= 1 {
+		hashRange(inputs, out)
+		return
+	}
+
+	var wg sync.WaitGroup
+	perWorker := (len(inputs) + workers - 1) / workers
+	for start := perWorker; start < len(inputs); start += perWorker {
+		end := start + perWorker
+		if end > len(inputs) {
+			end = len(inputs)
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			hashRange(inputs[start:end], out[start:end])
+		}(start, end)
+	}
+	hashRange(inputs[:perWorker], out[:perWorker])
+	wg.Wait()
+}
+
+func hashRange(inputs []HashInput, out [][4]uint64) {
+	for i := range inputs {
+		hashWithResult(&inputs[i].In, &inputs[i].Capacity, &out[i])
+	}
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"math/rand"
+	"testing"
+
+	poseidon "github.com/gateway-fm/vectorized-poseidon-gold/src/vectorizedposeidongold"
+
+	goposeidon "github.com/ledgerwatch/erigon/smt/pkg/poseidon"
+)
+
+func randomHashInputs(seed int64, n int) []HashInput {
+	rnd := rand.New(rand.NewSource(seed))
+	inputs := make([]HashInput, n)
+	for i := range inputs {
+		for j := range inputs[i].In {
+			inputs[i].In[j] = rnd.Uint64()
+		}
+		for j := range inputs[i].Capacity {
+			inputs[i].Capacity[j] = rnd.Uint64()
+		}
+	}
+	return inputs
+}
+
+func TestHashImplementationsMatch(t *testing.T) {
+	for i, input := range randomHashInputs(1, 2000) {
+		vectorized, err := poseidon.Hash(input.In, input.Capacity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pure := goposeidon.Hash(input.In, input.Capacity); pure != vectorized {
+			t.Fatalf("input %d: vectorized poseidon gives %x, pure go gives %x", i, vectorized, pure)
+		}
+	}
+}
+
+func TestHashBatch(t *testing.T) {
+	inputs := randomHashInputs(2, 1000)
+
+	for _, workers := range []int{0, 1, 3, 8, 64} {
+		out := make([][4]uint64, len(inputs))
+		HashBatch(inputs, out, workers)
+		for i, input := range inputs {
+			expected, err := Hash(input.In, input.Capacity)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out[i] != expected {
+				t.Fatalf("%d workers, input %d: expected %x, got %x", workers, i, expected, out[i])
+			}
+		}
+	}
+}
+
+func BenchmarkVectorizedHash(b *testing.B) {
+	input := randomHashInputs(3, 1)[0]
+	var result [4]uint64
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		poseidon.HashWithResult(&input.In, &input.Capacity, &result)
+	}
+}
+
+func BenchmarkPureGoHash(b *testing.B) {
+	input := randomHashInputs(3, 1)[0]
+	var result [4]uint64
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		goposeidon.HashWithResult(&input.In, &input.Capacity, &result)
+	}
+}
+
+func BenchmarkHashBatch(b *testing.B) {
+	inputs := randomHashInputs(4, 4096)
+	out := make([][4]uint64, len(inputs))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		HashBatch(inputs, out, 8)
+	}
+}
@@ -13,6 +13,8 @@ import (
 	poseidon "github.com/gateway-fm/vectorized-poseidon-gold/src/vectorizedposeidongold"
 	"github.com/gateway-fm/cdk-erigon-lib/common"
 	"github.com/gateway-fm/cdk-erigon-lib/common/length"
+
+	goposeidon "github.com/ledgerwatch/erigon/smt/pkg/poseidon"
 )
 
 const (
@@ -47,11 +49,21 @@ const (
 var (
 	LeafCapacity   = [4]uint64{1, 0, 0, 0}
 	BranchCapacity = [4]uint64{0, 0, 0, 0}
-	hashFunc       = poseidon.Hash
+
+	// hashWithResult is the fastest poseidon implementation on this CPU, the C one needs AVX to beat the pure Go one
+	hashWithResult = goposeidon.HashWithResult
 )
 
+func init() {
+	if poseidon.UsingSimd {
+		hashWithResult = poseidon.HashWithResult
+	}
+}
+
 func Hash(in [8]uint64, capacity [4]uint64) ([4]uint64, error) {
-	return hashFunc(in, capacity)
+	var result [4]uint64
+	hashWithResult(&in, &capacity, &result)
+	return result, nil
 }
 
 func (nk *NodeKey) IsZero() bool {
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ledgerwatch/erigon/cmd/utils"
+	"github.com/ledgerwatch/erigon/turbo/logging"
+	"github.com/ledgerwatch/erigon/zk/acl"
+	"github.com/ledgerwatch/erigon/zk/poolmanager"
+)
+
+var (
+	poolManagerHttpAddrFlag = cli.StringFlag{
+		Name:  "pool-manager.http.addr",
+		Usage: "Address rpc nodes forward transactions to, their zkevm.pool-manager-url",
+		Value: "localhost:8548",
+	}
+	poolManagerSequencerAddrFlag = cli.StringFlag{
+		Name:  "pool-manager.sequencer.addr",
+		Usage: "Private api of the sequencer, its private.api.addr, for its state and txpool",
+		Value: "localhost:9090",
+	}
+	poolManagerACLFileFlag = cli.StringFlag{
+		Name:  "pool-manager.acl-file",
+		Usage: "JSON file with the rpc nodes and senders allowed to send transactions, everyone when not set",
+	}
+	poolManagerSenderRateLimitFlag = cli.IntFlag{
+		Name:  "pool-manager.sender-rate-limit",
+		Usage: "Transactions per second each sender can send, 0 is no limit",
+		Value: 0,
+	}
+	poolManagerClientRateLimitFlag = cli.IntFlag{
+		Name:  "pool-manager.client-rate-limit",
+		Usage: "Requests per second each rpc node can send, 0 is no limit",
+		Value: 0,
+	}
+)
+
+var poolManagerCommand = cli.Command{
+	Action: MigrateFlags(runPoolManager),
+	Name:   "pool-manager",
+	Usage:  "Take the transactions of rpc nodes and add them to the txpool of a sequencer",
+	Flags: []cli.Flag{
+		&poolManagerHttpAddrFlag,
+		&poolManagerSequencerAddrFlag,
+		&poolManagerACLFileFlag,
+		&poolManagerSenderRateLimitFlag,
+		&poolManagerClientRateLimitFlag,
+		&utils.TLSCACertFlag,
+		&utils.TLSCertFlag,
+		&utils.TLSKeyFlag,
+	},
+	Category: "ZKEVM COMMANDS",
+	Description: `
+The pool-manager command serves eth_sendRawTransaction for rpc nodes with zkevm.pool-manager-url
+set.  Transactions are deduplicated, checked against the state of the sequencer read through its
+private api, filtered by the acl and rate limits, then added to the txpool of the sequencer.`,
+}
+
+func runPoolManager(cliCtx *cli.Context) error {
+	logging.SetupLoggerCtx("pool-manager", cliCtx)
+
+	managerACL, err := acl.Load(cliCtx.String(poolManagerACLFileFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return poolmanager.Run(ctx, poolmanager.ServerConfig{
+		Config: poolmanager.Config{
+			ACL:             managerACL,
+			SenderRateLimit: cliCtx.Int(poolManagerSenderRateLimitFlag.Name),
+			ClientRateLimit: cliCtx.Int(poolManagerClientRateLimitFlag.Name),
+		},
+		HttpAddr:      cliCtx.String(poolManagerHttpAddrFlag.Name),
+		SequencerAddr: cliCtx.String(poolManagerSequencerAddrFlag.Name),
+		TLSCACert:     cliCtx.String(utils.TLSCACertFlag.Name),
+		TLSCertFile:   cliCtx.String(utils.TLSCertFlag.Name),
+		TLSKeyFile:    cliCtx.String(utils.TLSKeyFlag.Name),
+	})
+}
@@ -188,6 +188,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.SequencerBlockSealTime,
 	&utils.SequencerBatchSealTime,
 	&utils.SequencerNonEmptyBatchSealTime,
+	&utils.SequencerCounterAwareSelection,
 	&utils.ExecutorUrls,
 	&utils.ExecutorStrictMode,
 	&utils.L1QueryBlocksThreads,
@@ -210,4 +211,26 @@ var DefaultFlags = []cli.Flag{
 	&utils.DebugStepAfter,
 	&utils.PoolManagerUrl,
 	&utils.DisableVirtualCounters,
+	&utils.RpcTxPreValidation,
+	&utils.RpcTxSimulation,
+	&utils.RpcTxSenderRateLimit,
+	&utils.RpcTxACLFile,
+	&utils.TxPoolQueuedTTL,
+	&utils.TxPoolPendingTTL,
+	&utils.TxPoolSenderMaxSlots,
+	&utils.TxPoolSenderMaxBytes,
+	&utils.TxPoolHidePending,
+	&utils.TxPoolPriceBump,
+	&utils.GaslessPolicyFile,
+	&utils.SequencerHABackend,
+	&utils.SequencerHALeasePath,
+	&utils.SequencerHALeaseTTL,
+	&utils.SequencerHANodeId,
+	&utils.SequencerHAStreamUrl,
+	&utils.PendingBlockPollInterval,
+	&utils.PendingBlocksApi,
+	&utils.SmtGcBatchSize,
+	&utils.SmtGcRetainBlocks,
+	&utils.SmtHistoryBlocks,
+	&utils.SmtCacheSize,
 }
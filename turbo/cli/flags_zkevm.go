@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"math"
+	"os"
 
 	"strings"
 
@@ -11,10 +12,16 @@ import (
 	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
 	"github.com/ledgerwatch/erigon/cmd/utils"
 	"github.com/ledgerwatch/erigon/eth/ethconfig"
+	"github.com/ledgerwatch/erigon/zk/acl"
+	"github.com/ledgerwatch/erigon/zk/gasless"
+	"github.com/ledgerwatch/erigon/zk/ratelimit"
 	"github.com/ledgerwatch/erigon/zk/sequencer"
 	"github.com/urfave/cli/v2"
 )
 
+// rpcTxSenderRateLimiterSize is how many senders the rate limiter of an rpc node keeps track of
+const rpcTxSenderRateLimiterSize = 10_000
+
 func ApplyFlagsForZkConfig(ctx *cli.Context, cfg *ethconfig.Config) {
 	checkFlag := func(flagName string, value interface{}) {
 		switch v := value.(type) {
@@ -53,6 +60,52 @@ func ApplyFlagsForZkConfig(ctx *cli.Context, cfg *ethconfig.Config) {
 		panic(fmt.Sprintf("could not parse sequencer batch seal time timeout value %s", sequencerNonEmptyBatchSealTimeVal))
 	}
 
+	sequencerHALeaseTTLVal := ctx.String(utils.SequencerHALeaseTTL.Name)
+	sequencerHALeaseTTL, err := time.ParseDuration(sequencerHALeaseTTLVal)
+	if err != nil {
+		panic(fmt.Sprintf("could not parse sequencer ha lease ttl value %s", sequencerHALeaseTTLVal))
+	}
+
+	gaslessPolicy, err := gasless.LoadPolicy(ctx.String(utils.GaslessPolicyFile.Name))
+	if err != nil {
+		panic(fmt.Sprintf("could not load gasless policy: %v", err))
+	}
+
+	rpcTxACL, err := acl.Load(ctx.String(utils.RpcTxACLFile.Name))
+	if err != nil {
+		panic(fmt.Sprintf("could not load rpc tx acl: %v", err))
+	}
+
+	rpcTxSenderRateLimiter, err := ratelimit.New[libcommon.Address](ctx.Int(utils.RpcTxSenderRateLimit.Name), rpcTxSenderRateLimiterSize)
+	if err != nil {
+		panic(fmt.Sprintf("could not create rpc tx sender rate limiter: %v", err))
+	}
+
+	sequencerHANodeId := ctx.String(utils.SequencerHANodeId.Name)
+	if sequencerHANodeId == "" {
+		if sequencerHANodeId, err = os.Hostname(); err != nil {
+			panic(fmt.Sprintf("could not determine the hostname for the sequencer ha node id: %v", err))
+		}
+	}
+
+	pendingBlockPollIntervalVal := ctx.String(utils.PendingBlockPollInterval.Name)
+	pendingBlockPollInterval, err := time.ParseDuration(pendingBlockPollIntervalVal)
+	if err != nil {
+		panic(fmt.Sprintf("could not parse pending block poll interval value %s", pendingBlockPollIntervalVal))
+	}
+
+	txPoolQueuedTTLVal := ctx.String(utils.TxPoolQueuedTTL.Name)
+	txPoolQueuedTTL, err := time.ParseDuration(txPoolQueuedTTLVal)
+	if err != nil {
+		panic(fmt.Sprintf("could not parse txpool queued ttl value %s", txPoolQueuedTTLVal))
+	}
+
+	txPoolPendingTTLVal := ctx.String(utils.TxPoolPendingTTL.Name)
+	txPoolPendingTTL, err := time.ParseDuration(txPoolPendingTTLVal)
+	if err != nil {
+		panic(fmt.Sprintf("could not parse txpool pending ttl value %s", txPoolPendingTTLVal))
+	}
+
 	effectiveGasPriceForEthTransferVal := ctx.Float64(utils.EffectiveGasPriceForEthTransfer.Name)
 	effectiveGasPriceForErc20TransferVal := ctx.Float64(utils.EffectiveGasPriceForErc20Transfer.Name)
 	effectiveGasPriceForContractInvocationVal := ctx.Float64(utils.EffectiveGasPriceForContractInvocation.Name)
@@ -95,6 +148,7 @@ func ApplyFlagsForZkConfig(ctx *cli.Context, cfg *ethconfig.Config) {
 		SequencerBlockSealTime:                 sequencerBlockSealTime,
 		SequencerBatchSealTime:                 sequencerBatchSealTime,
 		SequencerNonEmptyBatchSealTime:         sequencerNonEmptyBatchSealTime,
+		SequencerCounterAwareSelection:         ctx.Bool(utils.SequencerCounterAwareSelection.Name),
 		ExecutorUrls:                           strings.Split(ctx.String(utils.ExecutorUrls.Name), ","),
 		ExecutorStrictMode:                     ctx.Bool(utils.ExecutorStrictMode.Name),
 		L1QueryBlocksThreads:                   ctx.Uint64(utils.L1QueryBlocksThreads.Name),
@@ -115,6 +169,28 @@ func ApplyFlagsForZkConfig(ctx *cli.Context, cfg *ethconfig.Config) {
 		DebugStepAfter:                         ctx.Uint64(utils.DebugStepAfter.Name),
 		PoolManagerUrl:                         ctx.String(utils.PoolManagerUrl.Name),
 		DisableVirtualCounters:                 ctx.Bool(utils.DisableVirtualCounters.Name),
+		RpcTxPreValidation:                     ctx.Bool(utils.RpcTxPreValidation.Name),
+		RpcTxSimulation:                        ctx.Bool(utils.RpcTxSimulation.Name),
+		RpcTxSenderRateLimiter:                 rpcTxSenderRateLimiter,
+		RpcTxACL:                               rpcTxACL,
+		TxPoolQueuedTTL:                        txPoolQueuedTTL,
+		TxPoolPendingTTL:                       txPoolPendingTTL,
+		TxPoolSenderMaxSlots:                   ctx.Uint64(utils.TxPoolSenderMaxSlots.Name),
+		TxPoolSenderMaxBytes:                   ctx.Uint64(utils.TxPoolSenderMaxBytes.Name),
+		TxPoolHidePending:                      ctx.Bool(utils.TxPoolHidePending.Name),
+		TxPoolPriceBump:                        ctx.Uint64(utils.TxPoolPriceBump.Name),
+		GaslessPolicy:                          gaslessPolicy,
+		SequencerHABackend:                     ctx.String(utils.SequencerHABackend.Name),
+		SequencerHALeasePath:                   ctx.String(utils.SequencerHALeasePath.Name),
+		SequencerHALeaseTTL:                    sequencerHALeaseTTL,
+		SequencerHANodeId:                      sequencerHANodeId,
+		SequencerHAStreamUrl:                   ctx.String(utils.SequencerHAStreamUrl.Name),
+		PendingBlockPollInterval:               pendingBlockPollInterval,
+		PendingBlocksApi:                       ctx.Bool(utils.PendingBlocksApi.Name),
+		SmtGcBatchSize:                         ctx.Uint64(utils.SmtGcBatchSize.Name),
+		SmtGcRetainBlocks:                      ctx.Uint64(utils.SmtGcRetainBlocks.Name),
+		SmtHistoryBlocks:                       ctx.Uint64(utils.SmtHistoryBlocks.Name),
+		SmtCacheSize:                           ctx.Int(utils.SmtCacheSize.Name),
 	}
 
 	checkFlag(utils.L2ChainIdFlag.Name, cfg.L2ChainId)
@@ -131,6 +207,20 @@ func ApplyFlagsForZkConfig(ctx *cli.Context, cfg *ethconfig.Config) {
 		if cfg.Zk.ExecutorStrictMode && (len(cfg.Zk.ExecutorUrls) == 0 || cfg.ExecutorUrls[0] == "") {
 			panic("You must set executor urls when running in executor strict mode (zkevm.executor-strict)")
 		}
+
+		// a standby sequencer follows the leader through the datastream
+		if cfg.Zk.IsSequencerHA() {
+			checkFlag(utils.SequencerHALeasePath.Name, cfg.SequencerHALeasePath)
+			checkFlag(utils.L2DataStreamerUrlFlag.Name, cfg.L2DataStreamerUrl)
+			if cfg.SequencerHALeaseTTL <= 0 {
+				panic(fmt.Sprintf("Flag not set: %s", utils.SequencerHALeaseTTL.Name))
+			}
+		}
+	}
+
+	// the history window keeps every node a block replaces, only the garbage collector removes them
+	if cfg.SmtHistoryBlocks > 0 && cfg.SmtGcBatchSize == 0 {
+		panic(fmt.Sprintf("Flag %s needs %s to be set, the SMT grows without bound otherwise", utils.SmtHistoryBlocks.Name, utils.SmtGcBatchSize.Name))
 	}
 
 	checkFlag(utils.AddressSequencerFlag.Name, cfg.AddressSequencer)
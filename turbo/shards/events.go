@@ -6,6 +6,8 @@ import (
 	"github.com/gateway-fm/cdk-erigon-lib/common"
 	"github.com/gateway-fm/cdk-erigon-lib/gointerfaces/remote"
 	"github.com/ledgerwatch/erigon/core/types"
+	smtdb "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/zk/events"
 )
 
 type RpcEventType uint64
@@ -142,4 +144,6 @@ type Notifications struct {
 	Events               *Events
 	Accumulator          *Accumulator
 	StateChangesConsumer StateChangeConsumer
+	ZkEvents             *events.Events   // flushed once the stage loop has committed the data they describe
+	SmtCache             *smtdb.NodeCache // nil when the smt node cache is disabled, flushed alongside ZkEvents
 }
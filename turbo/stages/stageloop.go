@@ -165,6 +165,11 @@ func StageLoopStep(ctx context.Context, chainConfig *chain.Config, db kv.RwDB, s
 		}
 		notifications.Accumulator.Reset(stateVersion)
 	}
+	if notifications != nil {
+		// whatever the last cycle queued was rolled back with it
+		notifications.ZkEvents.Reset()
+		notifications.SmtCache.Reset()
+	}
 
 	err = sync.Run(db, tx, initialCycle, false /* quiet */)
 	if err != nil {
@@ -182,6 +187,10 @@ func StageLoopStep(ctx context.Context, chainConfig *chain.Config, db kv.RwDB, s
 		}
 		commitTime = time.Since(commitStart)
 	}
+	if notifications != nil {
+		notifications.ZkEvents.Flush()
+		notifications.SmtCache.Flush()
+	}
 
 	// -- send notifications START
 	//TODO: can this 2 headers be 1
@@ -15,7 +15,10 @@ import (
 	"github.com/ledgerwatch/erigon/turbo/engineapi"
 	"github.com/ledgerwatch/erigon/turbo/shards"
 	"github.com/ledgerwatch/erigon/turbo/snapshotsync"
+	"github.com/ledgerwatch/erigon/zk/events"
 	"github.com/ledgerwatch/erigon/zk/legacy_executor_verifier"
+	"github.com/ledgerwatch/erigon/zk/pending"
+	"github.com/ledgerwatch/erigon/zk/sequencer"
 	zkStages "github.com/ledgerwatch/erigon/zk/stages"
 	"github.com/ledgerwatch/erigon/zk/syncer"
 	"github.com/ledgerwatch/erigon/zk/txpool"
@@ -35,6 +38,7 @@ func NewDefaultZkStages(ctx context.Context,
 	l1Syncer *syncer.L1Syncer,
 	datastreamClient zkStages.DatastreamClient,
 	datastreamServer *datastreamer.StreamServer,
+	zkEvents *events.Events,
 ) []*stagedsync.Stage {
 	dirs := cfg.Dirs
 	blockReader := snapshotsync.NewBlockReaderWithSnapshots(snapshots, cfg.TransactionsV3)
@@ -45,8 +49,8 @@ func NewDefaultZkStages(ctx context.Context,
 	runInTestMode := cfg.ImportMode
 
 	return zkStages.DefaultZkStages(ctx,
-		zkStages.StageL1SyncerCfg(db, l1Syncer, cfg.Zk),
-		zkStages.StageBatchesCfg(db, datastreamClient, cfg.Zk),
+		zkStages.StageL1SyncerCfg(db, l1Syncer, cfg.Zk, zkEvents),
+		zkStages.StageBatchesCfg(db, datastreamClient, cfg.Zk, zkEvents),
 		zkStages.StageDataStreamCatchupCfg(datastreamServer, db, cfg.Genesis.Config.ChainID.Uint64()),
 		stagedsync.StageCumulativeIndexCfg(db),
 		stagedsync.StageBlockHashesCfg(db, dirs.Tmp, controlServer.ChainConfig),
@@ -72,7 +76,7 @@ func NewDefaultZkStages(ctx context.Context,
 			cfg.Zk,
 		),
 		stagedsync.StageHashStateCfg(db, dirs, cfg.HistoryV3, agg),
-		zkStages.StageZkInterHashesCfg(db, true, true, false, dirs.Tmp, blockReader, controlServer.Hd, cfg.HistoryV3, agg, cfg.Zk),
+		zkStages.StageZkInterHashesCfg(db, true, true, false, dirs.Tmp, blockReader, controlServer.Hd, cfg.HistoryV3, agg, cfg.Zk, notifications.SmtCache),
 		stagedsync.StageHistoryCfg(db, cfg.Prune, dirs.Tmp),
 		stagedsync.StageLogIndexCfg(db, cfg.Prune, dirs.Tmp),
 		stagedsync.StageCallTracesCfg(db, cfg.Prune, 0, dirs.Tmp),
@@ -98,6 +102,11 @@ func NewSequencerZkStages(ctx context.Context,
 	txPool *txpool.TxPool,
 	txPoolDb kv.RwDB,
 	verifier *legacy_executor_verifier.LegacyExecutorVerifier,
+	elector *sequencer.Elector,
+	leaderStream zkStages.DatastreamClient,
+	dialLeaderStream func(url string) zkStages.DatastreamClient,
+	pendingStore *pending.Store,
+	zkEvents *events.Events,
 ) []*stagedsync.Stage {
 	dirs := cfg.Dirs
 	blockReader := snapshotsync.NewBlockReaderWithSnapshots(snapshots, cfg.TransactionsV3)
@@ -108,7 +117,7 @@ func NewSequencerZkStages(ctx context.Context,
 
 	return zkStages.SequencerZkStages(ctx,
 		stagedsync.StageCumulativeIndexCfg(db),
-		zkStages.StageL1SequencerSyncCfg(db, cfg.Zk, l1Syncer),
+		zkStages.StageL1SequencerSyncCfg(db, cfg.Zk, l1Syncer, zkEvents),
 		zkStages.StageSequencerL1BlockSyncCfg(db, cfg.Zk, l1BlockSyncer),
 		zkStages.StageDataStreamCatchupCfg(datastreamServer, db, cfg.Genesis.Config.ChainID.Uint64()),
 		zkStages.StageSequenceBlocksCfg(
@@ -132,9 +141,12 @@ func NewSequencerZkStages(ctx context.Context,
 			cfg.Zk,
 			txPool,
 			txPoolDb,
+			zkStages.StageSequencerHACfg(elector, leaderStream, dialLeaderStream),
+			pendingStore,
+			zkEvents,
 		),
 		stagedsync.StageHashStateCfg(db, dirs, cfg.HistoryV3, agg),
-		zkStages.StageZkInterHashesCfg(db, true, true, false, dirs.Tmp, blockReader, controlServer.Hd, cfg.HistoryV3, agg, cfg.Zk),
+		zkStages.StageZkInterHashesCfg(db, true, true, false, dirs.Tmp, blockReader, controlServer.Hd, cfg.HistoryV3, agg, cfg.Zk, notifications.SmtCache),
 		zkStages.StageSequencerExecutorVerifyCfg(db, verifier),
 		stagedsync.StageHistoryCfg(db, cfg.Prune, dirs.Tmp),
 		stagedsync.StageLogIndexCfg(db, cfg.Prune, dirs.Tmp),
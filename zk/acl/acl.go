@@ -0,0 +1,91 @@
+package acl
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+)
+
+// ACL decides who can send transactions through the pool manager or an rpc node.  The clients are the rpc nodes
+// forwarding to the pool manager, by network, and the senders are the signers of the transactions.  An empty allow
+// list allows everyone, the deny list wins over it.
+type ACL struct {
+	AllowedClients []string            `json:"allowedClients"` // CIDRs or plain IPs
+	AllowedSenders []libcommon.Address `json:"allowedSenders"`
+	DeniedSenders  []libcommon.Address `json:"deniedSenders"`
+
+	clients []*net.IPNet
+	allowed map[libcommon.Address]struct{}
+	denied  map[libcommon.Address]struct{}
+}
+
+// Load reads an acl from a json file, everyone is allowed when the path is empty
+func Load(path string) (*ACL, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading acl: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes and checks a json acl
+func Parse(data []byte) (*ACL, error) {
+	var acl ACL
+	if err := json.Unmarshal(data, &acl); err != nil {
+		return nil, fmt.Errorf("decoding acl: %w", err)
+	}
+	for _, client := range acl.AllowedClients {
+		_, network, err := net.ParseCIDR(client)
+		if err != nil {
+			ip := net.ParseIP(client)
+			if ip == nil {
+				return nil, fmt.Errorf("acl: invalid client %q", client)
+			}
+			network = &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}
+		}
+		acl.clients = append(acl.clients, network)
+	}
+	acl.allowed = make(map[libcommon.Address]struct{}, len(acl.AllowedSenders))
+	for _, sender := range acl.AllowedSenders {
+		acl.allowed[sender] = struct{}{}
+	}
+	acl.denied = make(map[libcommon.Address]struct{}, len(acl.DeniedSenders))
+	for _, sender := range acl.DeniedSenders {
+		acl.denied[sender] = struct{}{}
+	}
+	return &acl, nil
+}
+
+// AllowsClient tells if an rpc node can forward transactions from its ip
+func (a *ACL) AllowsClient(ip net.IP) bool {
+	if a == nil || len(a.clients) == 0 {
+		return true
+	}
+	for _, network := range a.clients {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsSender tells if the transactions of a sender are accepted
+func (a *ACL) AllowsSender(sender libcommon.Address) bool {
+	if a == nil {
+		return true
+	}
+	if _, ok := a.denied[sender]; ok {
+		return false
+	}
+	if len(a.allowed) == 0 {
+		return true
+	}
+	_, ok := a.allowed[sender]
+	return ok
+}
@@ -0,0 +1,178 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+
+	"github.com/ledgerwatch/erigon/zk/types"
+)
+
+const subscriptionBuffer = 32
+
+// BatchClosed is sent once no more blocks will be added to a batch
+type BatchClosed struct {
+	BatchNumber     uint64
+	LastBlockNumber uint64
+}
+
+// L1Batch is sent when a sequence or verification covering batches up to BatchNumber is seen on the L1
+type L1Batch struct {
+	BatchNumber   uint64
+	L1BlockNumber uint64
+	L1TxHash      common.Hash
+	StateRoot     common.Hash
+}
+
+type feed[T any] struct {
+	lock sync.Mutex
+	id   int
+	subs map[int]chan T
+}
+
+func (f *feed[T]) subscribe() (chan T, func()) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.subs == nil {
+		f.subs = map[int]chan T{}
+	}
+	ch := make(chan T, subscriptionBuffer)
+	f.id++
+	id := f.id
+	f.subs[id] = ch
+	return ch, func() {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		if _, ok := f.subs[id]; ok {
+			delete(f.subs, id)
+			close(ch)
+		}
+	}
+}
+
+func (f *feed[T]) send(msg T) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, ch := range f.subs {
+		// a slow subscriber loses its oldest events rather than holding up the stages
+		common.PrioritizedSend(ch, msg)
+	}
+}
+
+// Events fans out the zk specific chain events written by the stages to rpc subscribers.  The stages queue events as
+// they write the data and the stage loop flushes them once its transaction is committed, a cycle that fails drops its
+// events with Reset.  Events carry everything a subscriber needs rather than asking it to read the db.  All methods are
+// safe to call on a nil receiver so stages can run without subscribers.
+type Events struct {
+	batchClosed      feed[BatchClosed]
+	batchVirtualized feed[L1Batch]
+	batchVerified    feed[L1Batch]
+	l1InfoTreeUpdate feed[types.L1InfoTreeUpdate]
+
+	lock   sync.Mutex
+	queued []func()
+}
+
+func NewEvents() *Events {
+	return &Events{}
+}
+
+func (e *Events) AddBatchClosedSubscription() (chan BatchClosed, func()) {
+	if e == nil {
+		return nil, func() {}
+	}
+	return e.batchClosed.subscribe()
+}
+
+func (e *Events) AddBatchVirtualizedSubscription() (chan L1Batch, func()) {
+	if e == nil {
+		return nil, func() {}
+	}
+	return e.batchVirtualized.subscribe()
+}
+
+func (e *Events) AddBatchVerifiedSubscription() (chan L1Batch, func()) {
+	if e == nil {
+		return nil, func() {}
+	}
+	return e.batchVerified.subscribe()
+}
+
+func (e *Events) AddL1InfoTreeUpdateSubscription() (chan types.L1InfoTreeUpdate, func()) {
+	if e == nil {
+		return nil, func() {}
+	}
+	return e.l1InfoTreeUpdate.subscribe()
+}
+
+func (e *Events) OnBatchClosed(batchNumber, lastBlockNumber uint64) {
+	if e == nil {
+		return
+	}
+	msg := BatchClosed{BatchNumber: batchNumber, LastBlockNumber: lastBlockNumber}
+	e.queue(func() { e.batchClosed.send(msg) })
+}
+
+func (e *Events) OnBatchVirtualized(info types.L1BatchInfo) {
+	if e == nil {
+		return
+	}
+	msg := toL1Batch(info)
+	e.queue(func() { e.batchVirtualized.send(msg) })
+}
+
+func (e *Events) OnBatchVerified(info types.L1BatchInfo) {
+	if e == nil {
+		return
+	}
+	msg := toL1Batch(info)
+	e.queue(func() { e.batchVerified.send(msg) })
+}
+
+func (e *Events) OnL1InfoTreeUpdate(update *types.L1InfoTreeUpdate) {
+	if e == nil || update == nil {
+		return
+	}
+	msg := *update
+	e.queue(func() { e.l1InfoTreeUpdate.send(msg) })
+}
+
+func (e *Events) queue(send func()) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.queued = append(e.queued, send)
+}
+
+// Flush sends the events queued since the last flush or reset, it is called once the data they describe is committed
+func (e *Events) Flush() {
+	if e == nil {
+		return
+	}
+	e.lock.Lock()
+	queued := e.queued
+	e.queued = nil
+	e.lock.Unlock()
+
+	for _, send := range queued {
+		send()
+	}
+}
+
+// Reset drops the queued events, the transaction that wrote their data was not committed
+func (e *Events) Reset() {
+	if e == nil {
+		return
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.queued = nil
+}
+
+func toL1Batch(info types.L1BatchInfo) L1Batch {
+	return L1Batch{
+		BatchNumber:   info.BatchNo,
+		L1BlockNumber: info.L1BlockNo,
+		L1TxHash:      info.L1TxHash,
+		StateRoot:     info.StateRoot,
+	}
+}
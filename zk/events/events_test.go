@@ -0,0 +1,82 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/zk/types"
+)
+
+func TestEvents_Subscriptions(t *testing.T) {
+	e := NewEvents()
+
+	closed, unsubscribeClosed := e.AddBatchClosedSubscription()
+	verified, unsubscribeVerified := e.AddBatchVerifiedSubscription()
+	defer unsubscribeVerified()
+
+	e.OnBatchClosed(5, 100)
+	e.OnBatchVerified(types.L1BatchInfo{BatchNo: 4, L1BlockNo: 10, L1TxHash: common.HexToHash("0x1")})
+	e.Flush()
+
+	require.Len(t, closed, 1)
+	assert.Equal(t, BatchClosed{BatchNumber: 5, LastBlockNumber: 100}, <-closed)
+	require.Len(t, verified, 1)
+	assert.Equal(t, L1Batch{BatchNumber: 4, L1BlockNumber: 10, L1TxHash: common.HexToHash("0x1")}, <-verified)
+
+	unsubscribeClosed()
+	_, ok := <-closed
+	assert.False(t, ok)
+
+	// sending after unsubscribing must not panic and calling unsubscribe twice is harmless
+	e.OnBatchClosed(6, 101)
+	unsubscribeClosed()
+}
+
+func TestEvents_SlowSubscriberDoesNotBlock(t *testing.T) {
+	e := NewEvents()
+	ch, unsubscribe := e.AddBatchClosedSubscription()
+	defer unsubscribe()
+
+	for i := uint64(0); i < subscriptionBuffer*2; i++ {
+		e.OnBatchClosed(i, i)
+	}
+	e.Flush()
+
+	assert.LessOrEqual(t, len(ch), subscriptionBuffer)
+}
+
+func TestEvents_SentOnlyOnceFlushed(t *testing.T) {
+	e := NewEvents()
+	ch, unsubscribe := e.AddBatchClosedSubscription()
+	defer unsubscribe()
+
+	// the cycle that closed batch 5 was rolled back
+	e.OnBatchClosed(5, 100)
+	require.Empty(t, ch)
+	e.Reset()
+	e.Flush()
+	require.Empty(t, ch)
+
+	e.OnBatchClosed(5, 101)
+	e.Flush()
+	require.Len(t, ch, 1)
+	assert.Equal(t, BatchClosed{BatchNumber: 5, LastBlockNumber: 101}, <-ch)
+
+	// nothing is sent twice
+	e.Flush()
+	require.Empty(t, ch)
+}
+
+func TestEvents_NilIsNoop(t *testing.T) {
+	var e *Events
+	e.OnBatchClosed(1, 1)
+	e.OnL1InfoTreeUpdate(&types.L1InfoTreeUpdate{})
+	e.Flush()
+	e.Reset()
+	ch, unsubscribe := e.AddL1InfoTreeUpdateSubscription()
+	assert.Nil(t, ch)
+	unsubscribe()
+}
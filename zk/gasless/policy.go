@@ -0,0 +1,134 @@
+package gasless
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/hexutility"
+)
+
+// Selector is the first 4 bytes of the call data of a transaction, the method it calls
+type Selector [4]byte
+
+func (s *Selector) UnmarshalText(input []byte) error {
+	if err := hexutility.UnmarshalFixedText("Selector", input, s[:]); err != nil {
+		return fmt.Errorf("invalid method selector %q: %w", input, err)
+	}
+	return nil
+}
+
+func (s Selector) MarshalText() ([]byte, error) {
+	return []byte(hexutility.Encode(s[:])), nil
+}
+
+// Rule allows zero priced transactions that match all of its non empty fields.  Contract creations never match.
+type Rule struct {
+	Name      string              `json:"name"`
+	Contracts []libcommon.Address `json:"contracts"` // the targets of the transactions, any target when empty
+	Selectors []Selector          `json:"selectors"` // the methods called, any method when empty
+	Senders   []libcommon.Address `json:"senders"`   // the senders allowed, anyone when empty
+
+	// DailyGasQuota is how much gas a sender can have sponsored in a day, 0 when there is no limit
+	DailyGasQuota uint64 `json:"dailyGasQuota"`
+}
+
+func (r *Rule) matches(sender libcommon.Address, to *libcommon.Address, data []byte) bool {
+	if to == nil {
+		return false
+	}
+	if len(r.Contracts) > 0 && !containsAddress(r.Contracts, *to) {
+		return false
+	}
+	if len(r.Senders) > 0 && !containsAddress(r.Senders, sender) {
+		return false
+	}
+	if len(r.Selectors) > 0 {
+		if len(data) < len(Selector{}) {
+			return false
+		}
+		var selector Selector
+		copy(selector[:], data)
+		found := false
+		for _, s := range r.Selectors {
+			if s == selector {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAddress(addresses []libcommon.Address, addr libcommon.Address) bool {
+	for _, a := range addresses {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// Policy decides which zero priced transactions are sponsored.  A nil policy sponsors nothing and leaves the pricing
+// of transactions to the global gasless settings.
+type Policy struct {
+	Rules []Rule `json:"rules"`
+}
+
+// LoadPolicy reads a policy from a json file, there is no policy when the path is empty
+func LoadPolicy(path string) (*Policy, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading gasless policy: %w", err)
+	}
+	return ParsePolicy(data)
+}
+
+// ParsePolicy decodes and checks a json policy
+func ParsePolicy(data []byte) (*Policy, error) {
+	var policy Policy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("decoding gasless policy: %w", err)
+	}
+	if len(policy.Rules) == 0 {
+		return nil, errors.New("gasless policy has no rules")
+	}
+	names := make(map[string]struct{}, len(policy.Rules))
+	for i, rule := range policy.Rules {
+		if strings.TrimSpace(rule.Name) == "" {
+			return nil, fmt.Errorf("gasless policy rule %d has no name", i)
+		}
+		if _, ok := names[rule.Name]; ok {
+			return nil, fmt.Errorf("gasless policy rule %q is defined twice", rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+	}
+	return &policy, nil
+}
+
+// Enabled is true when zero priced transactions have to match a rule to be accepted
+func (p *Policy) Enabled() bool {
+	return p != nil && len(p.Rules) > 0
+}
+
+// Match returns the first rule that sponsors a transaction, nil when none does
+func (p *Policy) Match(sender libcommon.Address, to *libcommon.Address, data []byte) *Rule {
+	if p == nil {
+		return nil
+	}
+	for i := range p.Rules {
+		if p.Rules[i].matches(sender, to, data) {
+			return &p.Rules[i]
+		}
+	}
+	return nil
+}
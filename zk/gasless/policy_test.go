@@ -0,0 +1,79 @@
+package gasless
+
+import (
+	"testing"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `{
+	"rules": [
+		{
+			"name": "onboarding",
+			"contracts": ["0x00000000000000000000000000000000000000aa"],
+			"selectors": ["0xa9059cbb"],
+			"dailyGasQuota": 100000
+		},
+		{
+			"name": "partners",
+			"senders": ["0x0000000000000000000000000000000000000001"]
+		}
+	]
+}`
+
+func TestPolicy_Match(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	require.True(t, policy.Enabled())
+
+	contract := libcommon.HexToAddress("0xaa")
+	other := libcommon.HexToAddress("0xbb")
+	partner := libcommon.HexToAddress("0x1")
+	sender := libcommon.HexToAddress("0x2")
+	transfer := libcommon.FromHex("0xa9059cbb0000")
+	approve := libcommon.FromHex("0x095ea7b30000")
+
+	rule := policy.Match(sender, &contract, transfer)
+	require.NotNil(t, rule)
+	assert.Equal(t, "onboarding", rule.Name)
+	assert.Equal(t, uint64(100000), rule.DailyGasQuota)
+
+	assert.Nil(t, policy.Match(sender, &contract, approve))
+	assert.Nil(t, policy.Match(sender, &contract, transfer[:3]))
+	assert.Nil(t, policy.Match(sender, &other, transfer))
+	assert.Nil(t, policy.Match(sender, nil, transfer))
+
+	rule = policy.Match(partner, &other, approve)
+	require.NotNil(t, rule)
+	assert.Equal(t, "partners", rule.Name)
+	assert.Nil(t, policy.Match(partner, nil, nil), "contract creations are never sponsored")
+}
+
+func TestPolicy_NilMatchesNothing(t *testing.T) {
+	var policy *Policy
+	to := libcommon.HexToAddress("0xaa")
+	assert.False(t, policy.Enabled())
+	assert.Nil(t, policy.Match(libcommon.Address{}, &to, nil))
+
+	policy, err := LoadPolicy("")
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+}
+
+func TestParsePolicy_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"no rules":       `{"rules": []}`,
+		"no name":        `{"rules": [{"senders": ["0x0000000000000000000000000000000000000001"]}]}`,
+		"duplicate name": `{"rules": [{"name": "a"}, {"name": "a"}]}`,
+		"short selector": `{"rules": [{"name": "a", "selectors": ["0xa9059c"]}]}`,
+		"selector no 0x": `{"rules": [{"name": "a", "selectors": ["a9059cbb"]}]}`,
+	}
+	for name, policy := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParsePolicy([]byte(policy))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package gasless
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/length"
+)
+
+// chargeSize is the length of an encoded charge: transaction hash, day, sender and gas
+const chargeSize = length.Hash + 8 + length.Addr + 8
+
+// Day is the day the daily quotas of a time are counted in, days start at midnight UTC
+func Day(t time.Time) uint64 {
+	return uint64(t.UTC().Unix()) / uint64((24 * time.Hour).Seconds())
+}
+
+type charge struct {
+	day    uint64
+	sender libcommon.Address
+	gas    uint64
+}
+
+type usageKey struct {
+	day    uint64
+	sender libcommon.Address
+}
+
+// Quotas keeps how much gas each sender has had sponsored per day.  Every transaction charged is remembered so it can
+// be refunded when the block it was mined in is unwound, even if the day has changed since.
+type Quotas struct {
+	used    map[usageKey]uint64
+	charges map[libcommon.Hash]charge
+}
+
+func NewQuotas() *Quotas {
+	return &Quotas{
+		used:    map[usageKey]uint64{},
+		charges: map[libcommon.Hash]charge{},
+	}
+}
+
+// Used is the gas sponsored for a sender on a day
+func (q *Quotas) Used(day uint64, sender libcommon.Address) uint64 {
+	return q.used[usageKey{day, sender}]
+}
+
+// Charge counts the gas of a mined transaction against the quota of its sender, a transaction is only charged once
+func (q *Quotas) Charge(txHash libcommon.Hash, day uint64, sender libcommon.Address, gas uint64) {
+	if _, ok := q.charges[txHash]; ok {
+		return
+	}
+	q.charges[txHash] = charge{day: day, sender: sender, gas: gas}
+	q.used[usageKey{day, sender}] += gas
+}
+
+// Refund gives back the gas of an unwound transaction to the day it was charged on
+func (q *Quotas) Refund(txHash libcommon.Hash) {
+	c, ok := q.charges[txHash]
+	if !ok {
+		return
+	}
+	delete(q.charges, txHash)
+	key := usageKey{c.day, c.sender}
+	if q.used[key] <= c.gas {
+		delete(q.used, key)
+	} else {
+		q.used[key] -= c.gas
+	}
+}
+
+// Prune forgets the charges of the days before the previous one, they can no longer be spent nor, in practice,
+// unwound
+func (q *Quotas) Prune(today uint64) {
+	for txHash, c := range q.charges {
+		if c.day+1 < today {
+			delete(q.charges, txHash)
+		}
+	}
+	for key := range q.used {
+		if key.day+1 < today {
+			delete(q.used, key)
+		}
+	}
+}
+
+// MarshalBinary encodes the charges, the usage is rebuilt from them
+func (q *Quotas) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, len(q.charges)*chargeSize)
+	for txHash, c := range q.charges {
+		buf = append(buf, txHash[:]...)
+		buf = binary.BigEndian.AppendUint64(buf, c.day)
+		buf = append(buf, c.sender[:]...)
+		buf = binary.BigEndian.AppendUint64(buf, c.gas)
+	}
+	return buf, nil
+}
+
+func (q *Quotas) UnmarshalBinary(data []byte) error {
+	if len(data)%chargeSize != 0 {
+		return fmt.Errorf("gasless quotas: unexpected length %d", len(data))
+	}
+	q.used = map[usageKey]uint64{}
+	q.charges = make(map[libcommon.Hash]charge, len(data)/chargeSize)
+	for pos := 0; pos < len(data); pos += chargeSize {
+		record := data[pos : pos+chargeSize]
+		txHash := libcommon.BytesToHash(record[:length.Hash])
+		day := binary.BigEndian.Uint64(record[length.Hash:])
+		sender := libcommon.BytesToAddress(record[length.Hash+8 : length.Hash+8+length.Addr])
+		gas := binary.BigEndian.Uint64(record[length.Hash+8+length.Addr:])
+		q.Charge(txHash, day, sender, gas)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package gasless
+
+import (
+	"testing"
+	"time"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDay(t *testing.T) {
+	midnight := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, Day(midnight), Day(midnight.Add(23*time.Hour)))
+	assert.Equal(t, Day(midnight)+1, Day(midnight.Add(24*time.Hour)))
+	assert.Equal(t, Day(midnight)-1, Day(midnight.Add(-time.Second)))
+}
+
+func TestQuotas_ChargeAndRefund(t *testing.T) {
+	sender := libcommon.HexToAddress("0x1")
+	tx1 := libcommon.HexToHash("0x01")
+	tx2 := libcommon.HexToHash("0x02")
+
+	q := NewQuotas()
+	q.Charge(tx1, 10, sender, 21000)
+	q.Charge(tx1, 10, sender, 21000) // mined again after a reorg without the unwind being seen
+	q.Charge(tx2, 10, sender, 50000)
+	assert.Equal(t, uint64(71000), q.Used(10, sender))
+	assert.Equal(t, uint64(0), q.Used(11, sender))
+
+	// an unwind seen the day after refunds the day the transaction was charged on
+	q.Refund(tx2)
+	assert.Equal(t, uint64(21000), q.Used(10, sender))
+	q.Refund(tx2)
+	assert.Equal(t, uint64(21000), q.Used(10, sender))
+
+	q.Refund(tx1)
+	assert.Equal(t, uint64(0), q.Used(10, sender))
+}
+
+func TestQuotas_Prune(t *testing.T) {
+	sender := libcommon.HexToAddress("0x1")
+	q := NewQuotas()
+	q.Charge(libcommon.HexToHash("0x01"), 8, sender, 1)
+	q.Charge(libcommon.HexToHash("0x02"), 9, sender, 2)
+	q.Charge(libcommon.HexToHash("0x03"), 10, sender, 3)
+
+	q.Prune(10)
+	assert.Equal(t, uint64(0), q.Used(8, sender))
+	assert.Equal(t, uint64(2), q.Used(9, sender))
+	assert.Equal(t, uint64(3), q.Used(10, sender))
+	assert.Len(t, q.charges, 2)
+}
+
+func TestQuotas_MarshalBinary(t *testing.T) {
+	a := libcommon.HexToAddress("0x1")
+	b := libcommon.HexToAddress("0x2")
+	q := NewQuotas()
+	q.Charge(libcommon.HexToHash("0x01"), 10, a, 100)
+	q.Charge(libcommon.HexToHash("0x02"), 10, a, 200)
+	q.Charge(libcommon.HexToHash("0x03"), 10, b, 300)
+
+	data, err := q.MarshalBinary()
+	require.NoError(t, err)
+
+	restored := NewQuotas()
+	require.NoError(t, restored.UnmarshalBinary(data))
+	assert.Equal(t, uint64(300), restored.Used(10, a))
+	assert.Equal(t, uint64(300), restored.Used(10, b))
+
+	restored.Refund(libcommon.HexToHash("0x02"))
+	assert.Equal(t, uint64(100), restored.Used(10, a))
+
+	require.NoError(t, restored.UnmarshalBinary(nil))
+	assert.Equal(t, uint64(0), restored.Used(10, a))
+	assert.Error(t, restored.UnmarshalBinary(data[:len(data)-1]))
+}
@@ -28,6 +28,7 @@ const L1_INFO_TREE_UPDATES_BY_GER = "l1_info_tree_updates_by_ger"      // GER ->
 const BLOCK_L1_INFO_TREE_INDEX = "block_l1_info_tree_index"            // block number -> l1 info tree index
 const L1_INJECTED_BATCHES = "l1_injected_batches"                      // index increasing by 1 -> injected batch for the start of the chain
 const BLOCK_INFO_ROOTS = "block_info_roots"                            // block number -> block info root hash
+const BLOCK_INFO_TREES = "block_info_trees"                            // block number -> block info tree values
 const L1_BLOCK_HASHES = "l1_block_hashes"                              // l1 block hash -> true
 const BLOCK_L1_BLOCK_HASHES = "block_l1_block_hashes"                  // block number -> l1 block hash
 const L1_BLOCK_HASH_GER = "l1_block_hash_ger"                          // l1 block hash -> GER
@@ -78,6 +79,7 @@ func CreateHermezBuckets(tx kv.RwTx) error {
 		BLOCK_L1_INFO_TREE_INDEX,
 		L1_INJECTED_BATCHES,
 		BLOCK_INFO_ROOTS,
+		BLOCK_INFO_TREES,
 		L1_BLOCK_HASHES,
 		BLOCK_L1_BLOCK_HASHES,
 		L1_BLOCK_HASH_GER,
@@ -217,6 +219,17 @@ func (db *HermezDbReader) GetVerificationByBatchNo(batchNo uint64) (*types.L1Bat
 	return db.getByBatchNo(L1VERIFICATIONS, batchNo)
 }
 
+// GetSequenceCoveringBatchNo returns the sequence that contains the batch.  Sequences are stored against the last batch
+// they contain so this is the first sequence at or above the batch number.
+func (db *HermezDbReader) GetSequenceCoveringBatchNo(batchNo uint64) (*types.L1BatchInfo, error) {
+	return db.getCoveringBatchNo(L1SEQUENCES, batchNo)
+}
+
+// GetVerificationCoveringBatchNo returns the verification that proved the batch, see GetSequenceCoveringBatchNo
+func (db *HermezDbReader) GetVerificationCoveringBatchNo(batchNo uint64) (*types.L1BatchInfo, error) {
+	return db.getCoveringBatchNo(L1VERIFICATIONS, batchNo)
+}
+
 func (db *HermezDbReader) getByL1Block(table string, l1BlockNo uint64) (*types.L1BatchInfo, error) {
 	c, err := db.tx.Cursor(table)
 	if err != nil {
@@ -298,6 +311,91 @@ func (db *HermezDbReader) getByBatchNo(table string, batchNo uint64) (*types.L1B
 	return nil, nil
 }
 
+// getCoveringBatchNo returns the first entry of the table at or above the batch.  The table is keyed by l1 block then
+// batch, as batches only go up with the l1 block the entry is found by seeking to l1 blocks in a binary search rather
+// than scanning the table.
+func (db *HermezDbReader) getCoveringBatchNo(table string, batchNo uint64) (*types.L1BatchInfo, error) {
+	c, err := db.tx.Cursor(table)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	k, _, err := c.First()
+	if err != nil || k == nil {
+		return nil, err
+	}
+	lo, _, err := SplitKey(k)
+	if err != nil {
+		return nil, err
+	}
+	if k, _, err = c.Last(); err != nil {
+		return nil, err
+	}
+	hi, lastBatch, err := SplitKey(k)
+	if err != nil {
+		return nil, err
+	}
+	if lastBatch < batchNo {
+		return nil, nil
+	}
+
+	// the last l1 block whose first entry is below the batch, the covering entry is at or after it
+	from := lo
+	for lo <= hi {
+		mid := lo + (hi-lo)/2
+		if k, _, err = c.Seek(ConcatKey(mid, 0)); err != nil {
+			return nil, err
+		}
+		_, batch, err := SplitKey(k)
+		if err != nil {
+			return nil, err
+		}
+		if batch >= batchNo {
+			if mid == 0 {
+				break
+			}
+			hi = mid - 1
+			continue
+		}
+		from = mid
+		lo = mid + 1
+	}
+
+	var v []byte
+	for k, v, err = c.Seek(ConcatKey(from, 0)); k != nil; k, v, err = c.Next() {
+		if err != nil {
+			return nil, err
+		}
+
+		l1Block, batch, err := SplitKey(k)
+		if err != nil {
+			return nil, err
+		}
+
+		if batch >= batchNo {
+			if len(v) != 96 && len(v) != 64 {
+				return nil, fmt.Errorf("invalid hash length")
+			}
+
+			var l1InfoRoot common.Hash
+			if len(v) > 64 {
+				l1InfoRoot = common.BytesToHash(v[64:])
+			}
+
+			return &types.L1BatchInfo{
+				BatchNo:    batch,
+				L1BlockNo:  l1Block,
+				StateRoot:  common.BytesToHash(v[32:64]),
+				L1TxHash:   common.BytesToHash(v[:32]),
+				L1InfoRoot: l1InfoRoot,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
 func (db *HermezDbReader) GetLatestSequence() (*types.L1BatchInfo, error) {
 	return db.getLatest(L1SEQUENCES)
 }
@@ -1015,6 +1113,39 @@ func (db *HermezDbReader) GetBlockInfoRoot(blockNumber uint64) (common.Hash, err
 	return res, nil
 }
 
+func (db *HermezDb) DeleteBlockInfoRoots(fromBlockNo, toBlockNo uint64) error {
+	return db.deleteFromBucketWithUintKeysRange(BLOCK_INFO_ROOTS, fromBlockNo, toBlockNo)
+}
+
+func (db *HermezDb) WriteBlockInfoTree(blockNumber uint64, info *types.BlockInfo) error {
+	infoJson, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return db.tx.Put(BLOCK_INFO_TREES, Uint64ToBytes(blockNumber), infoJson)
+}
+
+// GetBlockInfoTree returns the values the block info tree of the block was built from, nil if they weren't stored
+func (db *HermezDbReader) GetBlockInfoTree(blockNumber uint64) (*types.BlockInfo, error) {
+	v, err := db.tx.GetOne(BLOCK_INFO_TREES, Uint64ToBytes(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+	if len(v) == 0 {
+		return nil, nil
+	}
+
+	info := &types.BlockInfo{}
+	if err = json.Unmarshal(v, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (db *HermezDb) DeleteBlockInfoTrees(fromBlockNo, toBlockNo uint64) error {
+	return db.deleteFromBucketWithUintKeysRange(BLOCK_INFO_TREES, fromBlockNo, toBlockNo)
+}
+
 func (db *HermezDb) WriteWitness(batchNumber uint64, witness []byte) error {
 	return db.tx.Put(BATCH_WITNESSES, Uint64ToBytes(batchNumber), witness)
 }
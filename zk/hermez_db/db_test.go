@@ -88,6 +88,51 @@ func TestGetSequenceByBatchNo(t *testing.T) {
 	assert.Equal(t, common.HexToHash("0xdefg"), info.StateRoot)
 }
 
+func TestGetSequenceCoveringBatchNo(t *testing.T) {
+	tx, cleanup := GetDbTx()
+	defer cleanup()
+	db := NewHermezDb(tx)
+
+	require.NoError(t, db.WriteSequence(1, 1003, common.HexToHash("0xabc"), common.HexToHash("0xabcd")))
+	require.NoError(t, db.WriteSequence(2, 1007, common.HexToHash("0xdef"), common.HexToHash("0xdefg")))
+	require.NoError(t, db.WriteVerification(3, 1003, common.HexToHash("0x123"), common.HexToHash("0xabcd")))
+
+	info, err := db.GetSequenceCoveringBatchNo(1001)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), info.L1BlockNo)
+	assert.Equal(t, uint64(1003), info.BatchNo)
+
+	info, err = db.GetSequenceCoveringBatchNo(1004)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), info.L1BlockNo)
+	assert.Equal(t, common.HexToHash("0xdef"), info.L1TxHash)
+
+	info, err = db.GetSequenceCoveringBatchNo(1008)
+	require.NoError(t, err)
+	assert.Nil(t, info)
+
+	// several sequences in one l1 block and gaps between the l1 blocks
+	require.NoError(t, db.WriteSequence(2, 1009, common.HexToHash("0x1009"), common.HexToHash("0xabcd")))
+	require.NoError(t, db.WriteSequence(50, 1012, common.HexToHash("0x1012"), common.HexToHash("0xabcd")))
+	require.NoError(t, db.WriteSequence(900, 1020, common.HexToHash("0x1020"), common.HexToHash("0xabcd")))
+	for batchNo, l1TxHash := range map[uint64]string{1000: "0xabc", 1003: "0xabc", 1005: "0xdef", 1008: "0x1009", 1009: "0x1009", 1010: "0x1012", 1013: "0x1020", 1020: "0x1020"} {
+		info, err = db.GetSequenceCoveringBatchNo(batchNo)
+		require.NoError(t, err)
+		assert.Equal(t, common.HexToHash(l1TxHash), info.L1TxHash, "batch %d", batchNo)
+	}
+	info, err = db.GetSequenceCoveringBatchNo(1021)
+	require.NoError(t, err)
+	assert.Nil(t, info)
+
+	info, err = db.GetVerificationCoveringBatchNo(1002)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0x123"), info.L1TxHash)
+
+	info, err = db.GetVerificationCoveringBatchNo(1004)
+	require.NoError(t, err)
+	assert.Nil(t, info)
+}
+
 func TestGetVerificationByL1BlockAndBatchNo(t *testing.T) {
 	tx, cleanup := GetDbTx()
 	defer cleanup()
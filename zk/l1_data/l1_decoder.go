@@ -16,48 +16,87 @@ type RollupBaseEtrogBatchData struct {
 	ForcedBlockHashL1    [32]byte
 }
 
+// L1Sequence is the content of a sequenceBatches call on the L1 rollup contract.  Etrog sequences don't carry the max
+// sequence timestamp nor the init sequenced batch, they are zero: the contract used the timestamp of the L1 block and
+// the sequence starts after the last batch sequenced before it.
+type L1Sequence struct {
+	Batches              []RollupBaseEtrogBatchData
+	MaxSequenceTimestamp uint64
+	InitSequencedBatch   uint64
+	Coinbase             common.Address
+	Etrog                bool
+}
+
 func DecodeL1BatchData(txData []byte) (uint64, [][]byte, common.Address, error) {
-	smcAbi, err := abi.JSON(strings.NewReader(contracts.SequenceBatchesAbi))
+	sequence, err := DecodeL1Sequence(txData)
 	if err != nil {
 		return 0, nil, common.Address{}, err
 	}
+	if sequence.Etrog {
+		return 0, nil, common.Address{}, fmt.Errorf("etrog sequences have no init sequenced batch")
+	}
+
+	batchL2Datas := make([][]byte, len(sequence.Batches))
+	for idx, batch := range sequence.Batches {
+		batchL2Datas[idx] = batch.Transactions
+	}
+
+	return sequence.InitSequencedBatch, batchL2Datas, sequence.Coinbase, nil
+}
+
+func DecodeL1Sequence(txData []byte) (*L1Sequence, error) {
+	if len(txData) < 4 {
+		return nil, fmt.Errorf("l1 call data is too short")
+	}
 
+	smcAbi, err := abi.JSON(strings.NewReader(contracts.SequenceBatchesAbi))
+	if err != nil {
+		return nil, err
+	}
+	etrog := false
 	method, err := smcAbi.MethodById(txData[:4])
 	if err != nil {
-		return 0, nil, common.Address{}, err
+		if smcAbi, err = abi.JSON(strings.NewReader(contracts.SequenceBatchesEtrogAbi)); err != nil {
+			return nil, err
+		}
+		if method, err = smcAbi.MethodById(txData[:4]); err != nil {
+			return nil, err
+		}
+		etrog = true
 	}
 
 	// Unpack method inputs
 	data, err := method.Inputs.Unpack(txData[4:])
 	if err != nil {
-		return 0, nil, common.Address{}, err
+		return nil, err
 	}
 
-	initialSequence, ok := data[2].(uint64)
-	if !ok {
-		return 0, nil, common.Address{}, fmt.Errorf("expected position 2 in the l1 call data to be uint64")
+	sequence := &L1Sequence{Etrog: etrog}
+	coinbaseIdx := 1
+	if !etrog {
+		var ok bool
+		if sequence.MaxSequenceTimestamp, ok = data[1].(uint64); !ok {
+			return nil, fmt.Errorf("expected position 1 in the l1 call data to be uint64")
+		}
+		if sequence.InitSequencedBatch, ok = data[2].(uint64); !ok {
+			return nil, fmt.Errorf("expected position 2 in the l1 call data to be uint64")
+		}
+		coinbaseIdx = 3
 	}
 
-	coinbase, ok := data[3].(common.Address)
+	coinbase, ok := data[coinbaseIdx].(common.Address)
 	if !ok {
-		return 0, nil, common.Address{}, fmt.Errorf("expected position 3 in the l1 call data to be address")
+		return nil, fmt.Errorf("expected position %d in the l1 call data to be address", coinbaseIdx)
 	}
-
-	var sequences []RollupBaseEtrogBatchData
+	sequence.Coinbase = coinbase
 
 	bytedata, err := json.Marshal(data[0])
 	if err != nil {
-		return 0, nil, coinbase, err
+		return nil, err
 	}
-	err = json.Unmarshal(bytedata, &sequences)
-	if err != nil {
-		return 0, nil, coinbase, err
-	}
-
-	batchL2Datas := make([][]byte, len(sequences))
-	for idx, sequence := range sequences {
-		batchL2Datas[idx] = sequence.Transactions
+	if err = json.Unmarshal(bytedata, &sequence.Batches); err != nil {
+		return nil, err
 	}
 
-	return initialSequence, batchL2Datas, coinbase, err
+	return sequence, nil
 }
@@ -0,0 +1,71 @@
+package pending
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/zkevm/jsonrpc/client"
+)
+
+// Poller keeps the store of an rpc node in line with the pending blocks of the sequencer
+type Poller struct {
+	url      string
+	interval time.Duration
+	store    *Store
+}
+
+func NewPoller(url string, interval time.Duration, store *Store) *Poller {
+	return &Poller{
+		url:      url,
+		interval: interval,
+		store:    store,
+	}
+}
+
+func (p *Poller) Start(ctx context.Context) {
+	go p.run(ctx)
+}
+
+func (p *Poller) run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.poll(); err != nil {
+				// stale pre-confirmations are worse than none so drop what we have until the sequencer answers again
+				p.store.Clear()
+				log.Debug("[pending] failed to fetch pending blocks from the sequencer", "err", err)
+			}
+		}
+	}
+}
+
+func (p *Poller) poll() error {
+	res, err := client.JSONRPCCall(p.url, "zkevm_getPendingBlocks")
+	if err != nil {
+		return err
+	}
+	if res.Error != nil {
+		return fmt.Errorf("%d - %s", res.Error.Code, res.Error.Message)
+	}
+
+	var rpcBlocks []*RpcBlock
+	if err = json.Unmarshal(res.Result, &rpcBlocks); err != nil {
+		return err
+	}
+	blocks, err := FromRpcBlocks(rpcBlocks)
+	if err != nil {
+		return err
+	}
+
+	p.store.Set(blocks)
+	return nil
+}
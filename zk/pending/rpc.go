@@ -0,0 +1,61 @@
+package pending
+
+import (
+	"github.com/gateway-fm/cdk-erigon-lib/common/hexutility"
+
+	"github.com/ledgerwatch/erigon/core/types"
+)
+
+// RpcBlock is the wire format of a pending block as returned by zkevm_getPendingBlocks
+type RpcBlock struct {
+	Header                       *types.Header      `json:"header"`
+	Transactions                 []hexutility.Bytes `json:"transactions"`
+	Receipts                     []*types.Receipt   `json:"receipts"`
+	EffectiveGasPricePercentages hexutility.Bytes   `json:"effectiveGasPricePercentages"`
+}
+
+func ToRpcBlocks(blocks []*Block) ([]*RpcBlock, error) {
+	result := make([]*RpcBlock, 0, len(blocks))
+	for _, b := range blocks {
+		encoded, err := types.MarshalTransactionsBinary(b.Transactions)
+		if err != nil {
+			return nil, err
+		}
+		txs := make([]hexutility.Bytes, len(encoded))
+		for i, e := range encoded {
+			txs[i] = e
+		}
+		receipts := b.Receipts
+		if receipts == nil {
+			receipts = types.Receipts{}
+		}
+		result = append(result, &RpcBlock{
+			Header:                       b.Header,
+			Transactions:                 txs,
+			Receipts:                     receipts,
+			EffectiveGasPricePercentages: b.EffectiveGasPricePercentages,
+		})
+	}
+	return result, nil
+}
+
+func FromRpcBlocks(blocks []*RpcBlock) ([]*Block, error) {
+	result := make([]*Block, 0, len(blocks))
+	for _, b := range blocks {
+		encoded := make([][]byte, len(b.Transactions))
+		for i, e := range b.Transactions {
+			encoded[i] = e
+		}
+		txs, err := types.DecodeTransactions(encoded)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &Block{
+			Header:                       b.Header,
+			Transactions:                 txs,
+			Receipts:                     b.Receipts,
+			EffectiveGasPricePercentages: b.EffectiveGasPricePercentages,
+		})
+	}
+	return result, nil
+}
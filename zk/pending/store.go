@@ -0,0 +1,159 @@
+package pending
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+
+	"github.com/ledgerwatch/erigon/core/types"
+)
+
+// Block is a block the sequencer is still working on, or has closed but not yet written to the datastream.  The header
+// only carries the fields known before execution so the block has no state root and its hash is not final.
+type Block struct {
+	Header                       *types.Header
+	Transactions                 types.Transactions
+	Receipts                     types.Receipts
+	EffectiveGasPricePercentages []uint8
+}
+
+func (b *Block) Number() uint64 {
+	return b.Header.Number.Uint64()
+}
+
+// ToBlock converts the pending block into a block that can be rendered by the rpc layer
+func (b *Block) ToBlock() *types.Block {
+	header := types.CopyHeader(b.Header)
+	header.GasUsed = 0
+	if len(b.Receipts) > 0 {
+		header.GasUsed = b.Receipts[len(b.Receipts)-1].CumulativeGasUsed
+	}
+	return types.NewBlock(header, b.Transactions, nil, b.Receipts, nil)
+}
+
+// Store holds the blocks that are known to the sequencer but not yet to the rest of the network.  On the sequencer it
+// is fed by the sequencing stage, on an rpc node it is a copy of the sequencer store refreshed by the Poller.
+type Store struct {
+	mtx    sync.RWMutex
+	blocks []*Block
+}
+
+func NewStore() *Store {
+	return &Store{}
+}
+
+// StartBlock opens a new pending block, any block opened before stays pending until the store is cleared
+func (s *Store) StartBlock(header *types.Header) {
+	if s == nil {
+		return
+	}
+	h := types.CopyHeader(header)
+	if h.Difficulty == nil {
+		h.Difficulty = new(big.Int)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.blocks = append(s.blocks, &Block{Header: h})
+}
+
+// AddTransaction adds an executed transaction to the most recently started block
+func (s *Store) AddTransaction(transaction types.Transaction, receipt *types.Receipt, effectiveGasPricePercentage uint8) {
+	if s == nil {
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if len(s.blocks) == 0 {
+		return
+	}
+	last := s.blocks[len(s.blocks)-1]
+
+	r := *receipt
+	r.TxHash = transaction.Hash()
+	r.BlockNumber = new(big.Int).Set(last.Header.Number)
+	r.TransactionIndex = uint(len(last.Transactions))
+	r.Logs = make([]*types.Log, len(receipt.Logs))
+	for i, l := range receipt.Logs {
+		lc := *l
+		lc.TxHash = r.TxHash
+		lc.BlockNumber = last.Number()
+		lc.TxIndex = r.TransactionIndex
+		if lc.Topics == nil {
+			lc.Topics = []common.Hash{}
+		}
+		r.Logs[i] = &lc
+	}
+
+	// readers may hold on to the previous version of the block so never append in place
+	next := &Block{
+		Header:                       last.Header,
+		Transactions:                 append(last.Transactions[:len(last.Transactions):len(last.Transactions)], transaction),
+		Receipts:                     append(last.Receipts[:len(last.Receipts):len(last.Receipts)], &r),
+		EffectiveGasPricePercentages: append(last.EffectiveGasPricePercentages[:len(last.EffectiveGasPricePercentages):len(last.EffectiveGasPricePercentages)], effectiveGasPricePercentage),
+	}
+	s.blocks[len(s.blocks)-1] = next
+}
+
+// Set replaces the content of the store, used by rpc nodes with the blocks fetched from the sequencer
+func (s *Store) Set(blocks []*Block) {
+	if s == nil {
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.blocks = blocks
+}
+
+// Clear drops every pending block, called once the blocks have been committed or the batch they were in abandoned
+func (s *Store) Clear() {
+	s.Set(nil)
+}
+
+// Blocks returns the pending blocks after the given block number in ascending order.  They have to carry on from that
+// block without a gap: when the local chain is behind the sequencer's, or the store is stale, the pending state cannot be
+// built on top of it and no blocks are returned.
+func (s *Store) Blocks(after uint64) []*Block {
+	if s == nil {
+		return nil
+	}
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	result := make([]*Block, 0, len(s.blocks))
+	next := after + 1
+	for _, b := range s.blocks {
+		if b.Number() <= after {
+			continue
+		}
+		if b.Number() != next {
+			return nil
+		}
+		result = append(result, b)
+		next++
+	}
+	return result
+}
+
+// Latest returns the highest pending block after the given block number or nil if there is none
+func (s *Store) Latest(after uint64) *Block {
+	blocks := s.Blocks(after)
+	if len(blocks) == 0 {
+		return nil
+	}
+	return blocks[len(blocks)-1]
+}
+
+// Transaction looks up a pre-confirmed transaction in the pending blocks after the given block number
+func (s *Store) Transaction(hash common.Hash, after uint64) (*Block, int, bool) {
+	for _, b := range s.Blocks(after) {
+		for i, txn := range b.Transactions {
+			if txn.Hash() == hash {
+				return b, i, true
+			}
+		}
+	}
+	return nil, 0, false
+}
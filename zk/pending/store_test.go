@@ -0,0 +1,117 @@
+package pending
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/core/types"
+)
+
+func newTestTx(nonce uint64) types.Transaction {
+	to := common.HexToAddress("0x1")
+	return types.NewTransaction(nonce, to, uint256.NewInt(1), 21000, uint256.NewInt(1), nil)
+}
+
+func newTestHeader(number int64) *types.Header {
+	return &types.Header{
+		Number:   big.NewInt(number),
+		GasLimit: 1_000_000,
+		Time:     uint64(number),
+	}
+}
+
+func TestStore_Blocks(t *testing.T) {
+	store := NewStore()
+
+	store.StartBlock(newTestHeader(10))
+	store.AddTransaction(newTestTx(0), &types.Receipt{GasUsed: 21000, CumulativeGasUsed: 21000}, 255)
+	held := store.Latest(9)
+
+	store.AddTransaction(newTestTx(1), &types.Receipt{GasUsed: 21000, CumulativeGasUsed: 42000}, 255)
+	store.StartBlock(newTestHeader(11))
+	store.AddTransaction(newTestTx(2), &types.Receipt{GasUsed: 21000, CumulativeGasUsed: 21000}, 128)
+
+	// readers keep a consistent view of a block while it grows
+	assert.Len(t, held.Transactions, 1)
+
+	blocks := store.Blocks(9)
+	require.Len(t, blocks, 2)
+	assert.Len(t, blocks[0].Transactions, 2)
+	assert.Equal(t, uint(1), blocks[0].Receipts[1].TransactionIndex)
+	assert.Equal(t, uint64(10), blocks[0].Receipts[1].BlockNumber.Uint64())
+
+	// blocks already known locally are not pending
+	assert.Len(t, store.Blocks(10), 1)
+	assert.Nil(t, store.Latest(11))
+
+	block, idx, ok := store.Transaction(newTestTx(2).Hash(), 9)
+	require.True(t, ok)
+	assert.Equal(t, uint64(11), block.Number())
+	assert.Equal(t, 0, idx)
+	assert.Equal(t, uint64(42000), blocks[0].ToBlock().GasUsed())
+
+	store.Clear()
+	assert.Empty(t, store.Blocks(9))
+}
+
+func TestStore_BlocksFollowTheLocalChain(t *testing.T) {
+	store := NewStore()
+	store.StartBlock(newTestHeader(10))
+	store.AddTransaction(newTestTx(0), &types.Receipt{GasUsed: 21000, CumulativeGasUsed: 21000}, 255)
+	store.StartBlock(newTestHeader(11))
+
+	// the local chain is behind the sequencer, the pending blocks cannot be applied on top of it
+	assert.Empty(t, store.Blocks(8))
+	assert.Nil(t, store.Latest(8))
+	_, _, ok := store.Transaction(newTestTx(0).Hash(), 8)
+	assert.False(t, ok)
+
+	assert.Len(t, store.Blocks(9), 2)
+
+	// a gap between the pending blocks
+	store.Set([]*Block{{Header: newTestHeader(10)}, {Header: newTestHeader(12)}})
+	assert.Empty(t, store.Blocks(9))
+	assert.Len(t, store.Blocks(11), 1)
+}
+
+func TestStore_NilIsEmpty(t *testing.T) {
+	var store *Store
+	store.StartBlock(newTestHeader(1))
+	store.AddTransaction(newTestTx(0), &types.Receipt{}, 255)
+	assert.Nil(t, store.Latest(0))
+}
+
+func TestRpcBlocks_RoundTrip(t *testing.T) {
+	store := NewStore()
+	store.StartBlock(newTestHeader(5))
+	store.AddTransaction(newTestTx(0), &types.Receipt{
+		Status:            types.ReceiptStatusSuccessful,
+		GasUsed:           21000,
+		CumulativeGasUsed: 21000,
+		Logs:              []*types.Log{{Address: common.HexToAddress("0x2"), Data: []byte{1}}},
+	}, 200)
+
+	rpcBlocks, err := ToRpcBlocks(store.Blocks(4))
+	require.NoError(t, err)
+	encoded, err := json.Marshal(rpcBlocks)
+	require.NoError(t, err)
+
+	var decodedRpc []*RpcBlock
+	require.NoError(t, json.Unmarshal(encoded, &decodedRpc))
+	decoded, err := FromRpcBlocks(decodedRpc)
+	require.NoError(t, err)
+
+	require.Len(t, decoded, 1)
+	assert.Equal(t, uint64(5), decoded[0].Number())
+	assert.Equal(t, newTestTx(0).Hash(), decoded[0].Transactions[0].Hash())
+	assert.Equal(t, newTestTx(0).Hash(), decoded[0].Receipts[0].TxHash)
+	assert.Equal(t, uint64(21000), decoded[0].Receipts[0].GasUsed)
+	assert.Len(t, decoded[0].Receipts[0].Logs, 1)
+	assert.Equal(t, []uint8{200}, decoded[0].EffectiveGasPricePercentages)
+}
@@ -0,0 +1,227 @@
+package poolmanager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/hexutility"
+	txpool_proto "github.com/gateway-fm/cdk-erigon-lib/gointerfaces/txpool"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/holiman/uint256"
+
+	"github.com/ledgerwatch/erigon/chain"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/zk/acl"
+	"github.com/ledgerwatch/erigon/zk/ratelimit"
+	zktxpool "github.com/ledgerwatch/erigon/zk/txpool"
+)
+
+const (
+	// seenTxsSize is how many of the transactions handed to the sequencer are remembered, the same transaction is
+	// usually forwarded by several rpc nodes
+	seenTxsSize = 100_000
+	// rateLimiterSize is how many senders and clients the rate limiters keep track of
+	rateLimiterSize = 10_000
+)
+
+var (
+	forwardedCounter = metrics.GetOrCreateCounter(`pool_manager_forwarded`)
+	duplicateCounter = metrics.GetOrCreateCounter(`pool_manager_duplicates`)
+	rejectedCounter  = metrics.GetOrCreateCounter(`pool_manager_rejected`)
+	limitedCounter   = metrics.GetOrCreateCounter(`pool_manager_rate_limited`)
+)
+
+var (
+	ErrClientNotAllowed = errors.New("client not allowed")
+	ErrSenderNotAllowed = errors.New("sender not allowed")
+)
+
+// Config is what the pool manager needs besides the sequencer
+type Config struct {
+	ACL             *acl.ACL
+	SenderRateLimit int // transactions per second of each sender, 0 when there is no limit
+	ClientRateLimit int // requests per second of each rpc node, 0 when there is no limit
+}
+
+// PoolManager takes the transactions rpc nodes forward to it, checks them against a read-only view of the state of
+// the sequencer and adds those that pass to the txpool of the sequencer
+type PoolManager struct {
+	cfg         Config
+	chainConfig *chain.Config
+	db          kv.RoDB
+	txPool      txpool_proto.TxpoolClient
+
+	mu       sync.Mutex
+	seen     *lru.Cache[common.Hash, struct{}]
+	inFlight map[common.Hash]*inFlightTx // transactions being checked and sent
+
+	senders *ratelimit.Limiter[common.Address]
+	clients *ratelimit.Limiter[string]
+}
+
+// inFlightTx is a transaction being checked and sent, err is set once done is closed
+type inFlightTx struct {
+	done chan struct{}
+	err  error
+}
+
+func New(cfg Config, chainConfig *chain.Config, db kv.RoDB, txPool txpool_proto.TxpoolClient) (*PoolManager, error) {
+	seen, err := lru.New[common.Hash, struct{}](seenTxsSize)
+	if err != nil {
+		return nil, err
+	}
+	senders, err := ratelimit.New[common.Address](cfg.SenderRateLimit, rateLimiterSize)
+	if err != nil {
+		return nil, err
+	}
+	clients, err := ratelimit.New[string](cfg.ClientRateLimit, rateLimiterSize)
+	if err != nil {
+		return nil, err
+	}
+	return &PoolManager{
+		cfg:         cfg,
+		chainConfig: chainConfig,
+		db:          db,
+		txPool:      txPool,
+		seen:        seen,
+		inFlight:    make(map[common.Hash]*inFlightTx),
+		senders:     senders,
+		clients:     clients,
+	}, nil
+}
+
+// SendRawTransaction validates a signed transaction and adds it to the pool of the sequencer, a transaction already
+// handed over is not sent again and one being handed over is waited for
+func (m *PoolManager) SendRawTransaction(ctx context.Context, encodedTx hexutility.Bytes) (common.Hash, error) {
+	txn, err := types.DecodeTransaction(rlp.NewStream(bytes.NewReader(encodedTx), uint64(len(encodedTx))))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if !txn.Protected() {
+		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed")
+	}
+	if m.chainConfig.ChainID.Cmp(txn.GetChainID().ToBig()) != 0 {
+		return common.Hash{}, fmt.Errorf("invalid chain id, expected: %d got: %d", m.chainConfig.ChainID, txn.GetChainID())
+	}
+
+	hash := txn.Hash()
+	for {
+		m.mu.Lock()
+		if m.seen.Contains(hash) {
+			m.mu.Unlock()
+			duplicateCounter.Inc()
+			return hash, nil
+		}
+		// the copies forwarded by other rpc nodes while the transaction is checked get the same result rather than
+		// being sent too
+		if first, ok := m.inFlight[hash]; ok {
+			m.mu.Unlock()
+			duplicateCounter.Inc()
+			select {
+			case <-first.done:
+			case <-ctx.Done():
+				return common.Hash{}, ctx.Err()
+			}
+			// the request checking it gave up, this one checks it again
+			if errors.Is(first.err, context.Canceled) || errors.Is(first.err, context.DeadlineExceeded) {
+				continue
+			}
+			if first.err != nil {
+				return common.Hash{}, first.err
+			}
+			return hash, nil
+		}
+		send := &inFlightTx{done: make(chan struct{})}
+		m.inFlight[hash] = send
+		m.mu.Unlock()
+
+		send.err = m.send(ctx, txn, encodedTx)
+
+		m.mu.Lock()
+		delete(m.inFlight, hash)
+		if send.err == nil {
+			m.seen.Add(hash, struct{}{})
+		}
+		m.mu.Unlock()
+		close(send.done)
+
+		if send.err != nil {
+			return common.Hash{}, send.err
+		}
+		return hash, nil
+	}
+}
+
+// send validates the transaction and adds it to the pool of the sequencer
+func (m *PoolManager) send(ctx context.Context, txn types.Transaction, encodedTx hexutility.Bytes) error {
+	if err := m.validate(ctx, txn); err != nil {
+		rejectedCounter.Inc()
+		return err
+	}
+
+	reply, err := m.txPool.Add(ctx, &txpool_proto.AddRequest{RlpTxs: [][]byte{encodedTx}})
+	if err != nil {
+		return err
+	}
+	if len(reply.Imported) != 1 || len(reply.Errors) != 1 {
+		return fmt.Errorf("txpool replied with %d results and %d errors to 1 transaction", len(reply.Imported), len(reply.Errors))
+	}
+	switch reply.Imported[0] {
+	case txpool_proto.ImportResult_SUCCESS:
+		forwardedCounter.Inc()
+	case txpool_proto.ImportResult_ALREADY_EXISTS:
+		duplicateCounter.Inc()
+	default:
+		rejectedCounter.Inc()
+		return errors.New(reply.Errors[0])
+	}
+	return nil
+}
+
+// validate runs the checks of the pool that only need the state of the sequencer, so the sequencer isn't bothered
+// with transactions it would reject anyway
+func (m *PoolManager) validate(ctx context.Context, txn types.Transaction) error {
+	return m.db.View(ctx, func(tx kv.Tx) error {
+		header := rawdb.ReadCurrentHeader(tx)
+		if header == nil {
+			return errors.New("current header not found")
+		}
+		from, err := txn.Sender(*types.MakeSigner(m.chainConfig, header.Number.Uint64()))
+		if err != nil {
+			return err
+		}
+		if !m.cfg.ACL.AllowsSender(from) {
+			return fmt.Errorf("%w: %s", ErrSenderNotAllowed, from)
+		}
+		if !m.senders.Allow(from) {
+			limitedCounter.Inc()
+			return fmt.Errorf("too many transactions from %s, limit is %d per second", from, m.senders.PerSec())
+		}
+		if reason := zktxpool.ValidateTxStateless(txn, m.chainConfig.IsShanghai(header.Time), m.chainConfig.IsLondon(header.Number.Uint64())); reason != zktxpool.Success {
+			return errors.New(reason.String())
+		}
+
+		account, err := state.NewPlainStateReader(tx).ReadAccountData(from)
+		if err != nil {
+			return err
+		}
+		var nonce uint64
+		balance := uint256.NewInt(0)
+		if account != nil {
+			nonce, balance = account.Nonce, &account.Balance
+		}
+		if reason := zktxpool.ValidateTxFunds(txn, nonce, balance); reason != zktxpool.Success {
+			return errors.New(reason.String())
+		}
+		return nil
+	})
+}
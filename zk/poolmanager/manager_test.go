@@ -0,0 +1,248 @@
+package poolmanager
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	txpool_proto "github.com/gateway-fm/cdk-erigon-lib/gointerfaces/txpool"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/memdb"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/zk/acl"
+)
+
+// fakeTxPool is the txpool of the sequencer, it takes every transaction once
+type fakeTxPool struct {
+	txpool_proto.TxpoolClient
+	added   [][]byte
+	reject  string        // rejects every transaction with this error when set
+	adding  chan struct{} // signalled when a transaction is being added, when set
+	release chan struct{} // adding waits for it when set
+	noReply bool          // replies without any result when set
+}
+
+func (p *fakeTxPool) Add(_ context.Context, in *txpool_proto.AddRequest, _ ...grpc.CallOption) (*txpool_proto.AddReply, error) {
+	if p.adding != nil {
+		p.adding <- struct{}{}
+		<-p.release
+	}
+	reply := &txpool_proto.AddReply{}
+	if p.noReply {
+		return reply, nil
+	}
+	for _, rlpTx := range in.RlpTxs {
+		if p.reject != "" {
+			reply.Imported = append(reply.Imported, txpool_proto.ImportResult_INVALID)
+			reply.Errors = append(reply.Errors, p.reject)
+			continue
+		}
+		result := txpool_proto.ImportResult_SUCCESS
+		for _, added := range p.added {
+			if string(added) == string(rlpTx) {
+				result = txpool_proto.ImportResult_ALREADY_EXISTS
+			}
+		}
+		if result == txpool_proto.ImportResult_SUCCESS {
+			p.added = append(p.added, rlpTx)
+		}
+		reply.Imported = append(reply.Imported, result)
+		reply.Errors = append(reply.Errors, "")
+	}
+	return reply, nil
+}
+
+type testManager struct {
+	t       *testing.T
+	manager *PoolManager
+	txPool  *fakeTxPool
+	key     *ecdsa.PrivateKey
+	sender  libcommon.Address
+}
+
+func newTestManager(t *testing.T, cfg Config) *testManager {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	m := &testManager{t: t, txPool: &fakeTxPool{}, key: key, sender: crypto.PubkeyToAddress(key.PublicKey)}
+
+	db := memdb.NewTestDB(t)
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		header := &types.Header{Number: big.NewInt(0)}
+		rawdb.WriteHeader(tx, header)
+		if err := rawdb.WriteCanonicalHash(tx, header.Hash(), 0); err != nil {
+			return err
+		}
+		if err := rawdb.WriteHeadHeaderHash(tx, header.Hash()); err != nil {
+			return err
+		}
+		account := &accounts.Account{Nonce: 1, Balance: *uint256.NewInt(1e18), Initialised: true}
+		return state.NewPlainStateWriterNoHistory(tx).UpdateAccountData(m.sender, &accounts.Account{}, account)
+	}))
+
+	m.manager, err = New(cfg, params.TestChainConfig, db, m.txPool)
+	require.NoError(t, err)
+	return m
+}
+
+func (m *testManager) sign(nonce uint64, value uint64) []byte {
+	txn, err := types.SignTx(types.NewTransaction(nonce, libcommon.HexToAddress("0xaa"), uint256.NewInt(value), 21000, uint256.NewInt(1e9), nil), *types.LatestSignerForChainID(params.TestChainConfig.ChainID), m.key)
+	require.NoError(m.t, err)
+	rlpTxs, err := types.MarshalTransactionsBinary(types.Transactions{txn})
+	require.NoError(m.t, err)
+	return rlpTxs[0]
+}
+
+func TestPoolManager_Forwards(t *testing.T) {
+	m := newTestManager(t, Config{})
+	ctx := context.Background()
+
+	rlpTx := m.sign(1, 1)
+	hash, err := m.manager.SendRawTransaction(ctx, rlpTx)
+	require.NoError(t, err)
+	require.Equal(t, crypto.Keccak256Hash(rlpTx), hash)
+	require.Len(t, m.txPool.added, 1)
+
+	// forwarded again by another rpc node
+	again, err := m.manager.SendRawTransaction(ctx, rlpTx)
+	require.NoError(t, err)
+	require.Equal(t, hash, again)
+	require.Len(t, m.txPool.added, 1)
+}
+
+func TestPoolManager_ValidatesAgainstState(t *testing.T) {
+	m := newTestManager(t, Config{})
+	ctx := context.Background()
+
+	_, err := m.manager.SendRawTransaction(ctx, m.sign(0, 1))
+	require.EqualError(t, err, "nonce too low")
+	// a rejected transaction isn't taken for one already sent
+	_, err = m.manager.SendRawTransaction(ctx, m.sign(0, 1))
+	require.EqualError(t, err, "nonce too low")
+	_, err = m.manager.SendRawTransaction(ctx, m.sign(1, 2e18))
+	require.EqualError(t, err, "insufficient funds")
+
+	other, err := types.SignTx(types.NewTransaction(1, libcommon.HexToAddress("0xaa"), uint256.NewInt(1), 21000, uint256.NewInt(1e9), nil), *types.LatestSignerForChainID(big.NewInt(1)), m.key)
+	require.NoError(t, err)
+	rlpTxs, err := types.MarshalTransactionsBinary(types.Transactions{other})
+	require.NoError(t, err)
+	_, err = m.manager.SendRawTransaction(ctx, rlpTxs[0])
+	require.ErrorContains(t, err, "invalid chain id")
+
+	require.Empty(t, m.txPool.added)
+}
+
+func TestPoolManager_RejectedBySequencer(t *testing.T) {
+	m := newTestManager(t, Config{})
+	ctx := context.Background()
+	rlpTx := m.sign(1, 1)
+
+	m.txPool.reject = "fee cap too low"
+	_, err := m.manager.SendRawTransaction(ctx, rlpTx)
+	require.EqualError(t, err, "fee cap too low")
+
+	// it can be sent again once the sequencer takes it
+	m.txPool.reject = ""
+	_, err = m.manager.SendRawTransaction(ctx, rlpTx)
+	require.NoError(t, err)
+	require.Len(t, m.txPool.added, 1)
+}
+
+func TestPoolManager_DuplicateWaitsForFirst(t *testing.T) {
+	m := newTestManager(t, Config{})
+	ctx := context.Background()
+	rlpTx := m.sign(1, 1)
+
+	m.txPool.reject = "fee cap too low"
+	m.txPool.adding, m.txPool.release = make(chan struct{}), make(chan struct{})
+	firstErr := make(chan error)
+	go func() {
+		_, err := m.manager.SendRawTransaction(ctx, rlpTx)
+		firstErr <- err
+	}()
+	<-m.txPool.adding
+
+	// forwarded by another rpc node while the sequencer is still adding the first copy
+	dupErr := make(chan error)
+	go func() {
+		_, err := m.manager.SendRawTransaction(ctx, rlpTx)
+		dupErr <- err
+	}()
+	select {
+	case err := <-dupErr:
+		t.Fatalf("duplicate returned before the first copy was added: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(m.txPool.release)
+	require.EqualError(t, <-firstErr, "fee cap too low")
+	require.EqualError(t, <-dupErr, "fee cap too low")
+
+	// a cancelled duplicate stops waiting
+	m.txPool.reject = ""
+	m.txPool.release = make(chan struct{})
+	go func() {
+		_, err := m.manager.SendRawTransaction(ctx, rlpTx)
+		firstErr <- err
+	}()
+	<-m.txPool.adding
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err := m.manager.SendRawTransaction(cancelled, rlpTx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	close(m.txPool.release)
+	require.NoError(t, <-firstErr)
+	require.Len(t, m.txPool.added, 1)
+}
+
+func TestPoolManager_MalformedReply(t *testing.T) {
+	m := newTestManager(t, Config{})
+	m.txPool.noReply = true
+
+	_, err := m.manager.SendRawTransaction(context.Background(), m.sign(1, 1))
+	require.EqualError(t, err, "txpool replied with 0 results and 0 errors to 1 transaction")
+}
+
+func TestPoolManager_ACLAndRateLimits(t *testing.T) {
+	senderACL, err := acl.Parse([]byte(`{"allowedClients": ["10.0.0.0/8"], "deniedSenders": ["0x00000000000000000000000000000000000000bb"]}`))
+	require.NoError(t, err)
+	m := newTestManager(t, Config{ACL: senderACL, SenderRateLimit: 1, ClientRateLimit: 1})
+	ctx := context.Background()
+
+	_, err = m.manager.SendRawTransaction(ctx, m.sign(1, 1))
+	require.NoError(t, err)
+	_, err = m.manager.SendRawTransaction(ctx, m.sign(2, 1))
+	require.ErrorContains(t, err, "too many transactions")
+
+	require.True(t, senderACL.AllowsSender(m.sender))
+	require.False(t, senderACL.AllowsSender(libcommon.HexToAddress("0xbb")))
+
+	send := func(remoteAddr string) int {
+		body := `{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["0x00"]}`
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		m.manager.Handler().ServeHTTP(rec, req)
+		return rec.Code
+	}
+	require.Equal(t, http.StatusForbidden, send("192.168.0.1:1234"))
+	require.Equal(t, http.StatusOK, send("10.0.0.1:1234"))
+	require.Equal(t, http.StatusTooManyRequests, send("10.0.0.1:1234"))
+	require.Equal(t, http.StatusOK, send("10.0.0.2:1234"))
+}
@@ -0,0 +1,120 @@
+package poolmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/hexutility"
+	"github.com/gateway-fm/cdk-erigon-lib/gointerfaces"
+	"github.com/gateway-fm/cdk-erigon-lib/gointerfaces/grpcutil"
+	"github.com/gateway-fm/cdk-erigon-lib/gointerfaces/remote"
+	txpool_proto "github.com/gateway-fm/cdk-erigon-lib/gointerfaces/txpool"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/remotedb"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/remotedbserver"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/node"
+	"github.com/ledgerwatch/erigon/rpc"
+	"github.com/ledgerwatch/erigon/rpc/rpccfg"
+)
+
+// ServerConfig is where the pool manager listens and how it reaches the sequencer
+type ServerConfig struct {
+	Config
+	HttpAddr      string // where rpc nodes send eth_sendRawTransaction, the url they have as zkevm.pool-manager-url
+	SequencerAddr string // the private api of the sequencer, it serves both the state and the txpool
+	TLSCACert     string
+	TLSCertFile   string
+	TLSKeyFile    string
+}
+
+// Run connects to the sequencer and serves the rpc nodes until the context is done
+func Run(ctx context.Context, cfg ServerConfig) error {
+	creds, err := grpcutil.TLS(cfg.TLSCACert, cfg.TLSCertFile, cfg.TLSKeyFile)
+	if err != nil {
+		return fmt.Errorf("open tls cert: %w", err)
+	}
+	conn, err := grpcutil.Connect(creds, cfg.SequencerAddr)
+	if err != nil {
+		return fmt.Errorf("could not connect to the sequencer private api: %w", err)
+	}
+	defer conn.Close()
+
+	db, err := remotedb.NewRemote(gointerfaces.VersionFromProto(remotedbserver.KvServiceAPIVersion), log.New(), remote.NewKVClient(conn)).Open()
+	if err != nil {
+		return fmt.Errorf("could not connect to the sequencer state: %w", err)
+	}
+	defer db.Close()
+
+	var manager *PoolManager
+	if err := db.View(ctx, func(tx kv.Tx) error {
+		genesis, err := rawdb.ReadCanonicalHash(tx, 0)
+		if err != nil {
+			return err
+		}
+		chainConfig, err := rawdb.ReadChainConfig(tx, genesis)
+		if err != nil {
+			return err
+		}
+		if chainConfig == nil {
+			return errors.New("the sequencer has no chain config yet")
+		}
+		manager, err = New(cfg.Config, chainConfig, db, txpool_proto.NewTxpoolClient(conn))
+		return err
+	}); err != nil {
+		return fmt.Errorf("reading the chain config of the sequencer: %w", err)
+	}
+
+	server, addr, err := node.StartHTTPEndpoint(cfg.HttpAddr, rpccfg.DefaultHTTPTimeouts, manager.Handler())
+	if err != nil {
+		return fmt.Errorf("could not start the pool manager rpc: %w", err)
+	}
+	log.Info("[pool-manager] started", "url", addr, "sequencer", cfg.SequencerAddr)
+
+	<-ctx.Done()
+	if err := server.Shutdown(context.Background()); err != nil {
+		log.Warn("[pool-manager] stopping the rpc server", "err", err)
+	}
+	return nil
+}
+
+// Handler serves eth_sendRawTransaction to the rpc nodes the acl allows, within their rate limit
+func (m *PoolManager) Handler() http.Handler {
+	srv := rpc.NewServer(1, false /* traceRequests */, true /* disableStreaming */)
+	if err := srv.RegisterName("eth", &ethAPI{m}); err != nil {
+		panic(err) // the api is fixed, it can't fail
+	}
+	handler := node.NewHTTPHandlerStack(srv, nil /* cors */, []string{"*"} /* vhosts */, false /* compression */)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		if !m.cfg.ACL.AllowsClient(net.ParseIP(host)) {
+			http.Error(w, ErrClientNotAllowed.Error(), http.StatusForbidden)
+			return
+		}
+		if !m.clients.Allow(host) {
+			limitedCounter.Inc()
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// ethAPI is the part of the eth namespace rpc nodes use to forward transactions
+type ethAPI struct {
+	manager *PoolManager
+}
+
+func (api *ethAPI) SendRawTransaction(ctx context.Context, encodedTx hexutility.Bytes) (common.Hash, error) {
+	return api.manager.SendRawTransaction(ctx, encodedTx)
+}
@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/time/rate"
+)
+
+// Limiter limits how often each key can do something, a nil limiter allows everything
+type Limiter[K comparable] struct {
+	mu       sync.Mutex
+	perSec   int
+	limiters *lru.Cache[K, *rate.Limiter]
+}
+
+// New returns a limiter allowing perSec events a second to each of the last size keys seen, or nil when perSec is 0
+func New[K comparable](perSec, size int) (*Limiter[K], error) {
+	if perSec <= 0 {
+		return nil, nil
+	}
+	limiters, err := lru.New[K, *rate.Limiter](size)
+	if err != nil {
+		return nil, err
+	}
+	return &Limiter[K]{perSec: perSec, limiters: limiters}, nil
+}
+
+func (l *Limiter[K]) Allow(key K) bool {
+	if l == nil {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limiter, ok := l.limiters.Get(key)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(l.perSec), l.perSec)
+		l.limiters.Add(key, limiter)
+	}
+	return limiter.Allow()
+}
+
+// PerSec is the number of events a second allowed to each key
+func (l *Limiter[K]) PerSec() int {
+	if l == nil {
+		return 0
+	}
+	return l.perSec
+}
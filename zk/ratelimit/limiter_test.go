@@ -0,0 +1,35 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	l, err := New[string](0, 10)
+	require.NoError(t, err)
+	require.Nil(t, l)
+	// a nil limiter allows everything
+	require.True(t, l.Allow("a"))
+
+	l, err = New[string](2, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, l.PerSec())
+	require.True(t, l.Allow("a"))
+	require.True(t, l.Allow("a"))
+	require.False(t, l.Allow("a"))
+	// the limit is per key
+	require.True(t, l.Allow("b"))
+}
+
+func TestLimiter_ForgetsOldestKey(t *testing.T) {
+	l, err := New[string](1, 1)
+	require.NoError(t, err)
+	require.True(t, l.Allow("a"))
+	require.False(t, l.Allow("a"))
+
+	// only the last key seen is kept track of
+	require.True(t, l.Allow("b"))
+	require.True(t, l.Allow("a"))
+}
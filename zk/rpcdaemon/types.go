@@ -333,7 +333,16 @@ type Batch struct {
 	Closed              bool           `json:"closed"`
 	Blocks              []interface{}  `json:"blocks"`
 	Transactions        []interface{}  `json:"transactions"`
-	BatchL2Data         ArgBytes       `json:"batchL2Data"`
+	BatchL2Data         ArgBytes       `json:"batchL2Data,omitempty"`
+
+	// L1 reconciliation data, only populated on request once the batch has been sequenced
+	L1InfoRoot             *common.Hash `json:"l1InfoRoot,omitempty"`
+	TimestampLimit         *ArgUint64   `json:"timestampLimit,omitempty"`
+	Forced                 *bool        `json:"forced,omitempty"`
+	OldAccInputHash        *common.Hash `json:"oldAccInputHash,omitempty"`
+	L1SequenceAccInputHash *common.Hash `json:"l1SequenceAccInputHash,omitempty"`
+	AccInputHashMatchesL1  *bool        `json:"accInputHashMatchesL1,omitempty"`
+	BatchL2DataMatchesL1   *bool        `json:"batchL2DataMatchesL1,omitempty"`
 }
 
 // TransactionOrHash for union type of transaction and types.Hash
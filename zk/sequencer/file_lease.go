@@ -0,0 +1,165 @@
+package sequencer
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gofrs/flock"
+)
+
+const LeaseBackendFile = "file"
+
+// FileLeaseBackend stores the lease as a json document next to a lock file.  It is meant for running several
+// sequencers on the same host or a shared volume, e.g. for local testing of failover.
+type FileLeaseBackend struct {
+	path string
+	// the file lock is shared by the goroutines of the process, locking it twice doesn't wait
+	mu   sync.Mutex
+	lock *flock.Flock
+}
+
+func NewFileLeaseBackend(path string) *FileLeaseBackend {
+	return &FileLeaseBackend{
+		path: path,
+		lock: flock.New(path + ".lock"),
+	}
+}
+
+func (f *FileLeaseBackend) TryAcquire(nodeId, streamUrl string, ttl time.Duration, now time.Time) (Lease, error) {
+	unlock, err := f.acquireLock()
+	if err != nil {
+		return Lease{}, err
+	}
+	defer unlock()
+
+	current, err := f.read()
+	if err != nil {
+		return Lease{}, err
+	}
+
+	if current.Holder != nodeId && now.Before(current.Expires) {
+		return current, nil
+	}
+
+	next := Lease{
+		Holder:    nodeId,
+		StreamUrl: streamUrl,
+		Term:      current.Term,
+		Expires:   now.Add(ttl),
+	}
+	if current.Holder != nodeId || now.After(current.Expires) {
+		next.Term++
+	}
+
+	if err = f.write(next); err != nil {
+		return Lease{}, err
+	}
+
+	return next, nil
+}
+
+func (f *FileLeaseBackend) Release(nodeId string) error {
+	unlock, err := f.acquireLock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	current, err := f.read()
+	if err != nil {
+		return err
+	}
+	if current.Holder != nodeId {
+		return nil
+	}
+
+	current.Expires = time.Time{}
+	return f.write(current)
+}
+
+func (f *FileLeaseBackend) Current() (Lease, error) {
+	unlock, err := f.acquireLock()
+	if err != nil {
+		return Lease{}, err
+	}
+	defer unlock()
+
+	return f.read()
+}
+
+func (f *FileLeaseBackend) WhileHeld(nodeId string, term uint64, fn func() error) (Lease, bool, error) {
+	unlock, err := f.acquireLock()
+	if err != nil {
+		return Lease{}, false, err
+	}
+	defer unlock()
+
+	current, err := f.read()
+	if err != nil {
+		return Lease{}, false, err
+	}
+	if !current.HeldBy(nodeId, time.Now()) || current.Term != term {
+		return current, false, nil
+	}
+	return current, true, fn()
+}
+
+func (f *FileLeaseBackend) acquireLock() (func(), error) {
+	f.mu.Lock()
+	if err := f.lock.Lock(); err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	return func() {
+		f.lock.Unlock()
+		f.mu.Unlock()
+	}, nil
+}
+
+func (f *FileLeaseBackend) read() (Lease, error) {
+	var lease Lease
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return lease, nil
+	}
+	if err != nil {
+		return lease, err
+	}
+	if len(data) == 0 {
+		return lease, nil
+	}
+	err = json.Unmarshal(data, &lease)
+	return lease, err
+}
+
+func (f *FileLeaseBackend) write(lease Lease) error {
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return err
+	}
+
+	// write to a temp file and rename so a crash never leaves a half written lease behind
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
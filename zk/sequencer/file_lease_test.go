@@ -0,0 +1,169 @@
+package sequencer
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileLeaseBackend_SingleHolder(t *testing.T) {
+	backend := NewFileLeaseBackend(filepath.Join(t.TempDir(), "lease"))
+	now := time.Now()
+	ttl := 10 * time.Second
+
+	lease, err := backend.TryAcquire("a", "", ttl, now)
+	require.NoError(t, err)
+	assert.True(t, lease.HeldBy("a", now))
+	assert.Equal(t, uint64(1), lease.Term)
+
+	// another node cannot take a lease that has not expired
+	lease, err = backend.TryAcquire("b", "", ttl, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, "a", lease.Holder)
+	assert.False(t, lease.HeldBy("b", now.Add(time.Second)))
+
+	// renewal by the holder keeps the same term
+	lease, err = backend.TryAcquire("a", "", ttl, now.Add(2*time.Second))
+	require.NoError(t, err)
+	assert.True(t, lease.HeldBy("a", now.Add(2*time.Second)))
+	assert.Equal(t, uint64(1), lease.Term)
+}
+
+func TestFileLeaseBackend_Failover(t *testing.T) {
+	backend := NewFileLeaseBackend(filepath.Join(t.TempDir(), "lease"))
+	now := time.Now()
+	ttl := 10 * time.Second
+
+	_, err := backend.TryAcquire("a", "", ttl, now)
+	require.NoError(t, err)
+
+	// once the lease expires the standby takes over with a new term
+	later := now.Add(ttl + time.Second)
+	lease, err := backend.TryAcquire("b", "", ttl, later)
+	require.NoError(t, err)
+	assert.True(t, lease.HeldBy("b", later))
+	assert.Equal(t, uint64(2), lease.Term)
+
+	// the old leader sees it has lost the lease
+	lease, err = backend.TryAcquire("a", "", ttl, later.Add(time.Second))
+	require.NoError(t, err)
+	assert.False(t, lease.HeldBy("a", later.Add(time.Second)))
+}
+
+func TestFileLeaseBackend_Release(t *testing.T) {
+	backend := NewFileLeaseBackend(filepath.Join(t.TempDir(), "lease"))
+	now := time.Now()
+	ttl := time.Minute
+
+	_, err := backend.TryAcquire("a", "", ttl, now)
+	require.NoError(t, err)
+
+	// releasing as a non holder is a no-op
+	require.NoError(t, backend.Release("b"))
+	lease, err := backend.TryAcquire("b", "", ttl, now)
+	require.NoError(t, err)
+	assert.Equal(t, "a", lease.Holder)
+
+	require.NoError(t, backend.Release("a"))
+	lease, err = backend.TryAcquire("b", "", ttl, now)
+	require.NoError(t, err)
+	assert.True(t, lease.HeldBy("b", now))
+}
+
+func TestElector_StepsDown(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lease")
+	ttl := 300 * time.Millisecond
+
+	a := NewElector(NewFileLeaseBackend(path), "a", "a:6900", ttl)
+	b := NewElector(NewFileLeaseBackend(path), "b", "b:6900", ttl)
+
+	a.campaign()
+	b.campaign()
+	assert.True(t, a.IsLeader())
+	assert.False(t, b.IsLeader())
+
+	changes := b.Subscribe()
+	require.NoError(t, a.Resign())
+	assert.False(t, a.IsLeader())
+
+	b.campaign()
+	assert.True(t, b.IsLeader())
+	assert.Equal(t, true, <-changes)
+	assert.Equal(t, uint64(2), b.Term())
+}
+
+func TestElector_Fenced(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lease")
+	ttl := 300 * time.Millisecond
+	published := 0
+	publish := func() error {
+		published++
+		return nil
+	}
+
+	a := NewElector(NewFileLeaseBackend(path), "a", "a:6900", ttl)
+	a.campaign()
+	require.True(t, a.IsLeader())
+	term := a.Term()
+	require.NoError(t, a.Fenced(term, publish))
+	require.ErrorIs(t, a.Fenced(term+1, publish), ErrFenced)
+	require.Equal(t, 1, published)
+
+	// b takes the lease over before a has noticed, a still believes it is the leader but is fenced off
+	later := time.Now().Add(ttl + time.Second)
+	_, err := NewFileLeaseBackend(path).TryAcquire("b", "b:6900", time.Hour, later)
+	require.NoError(t, err)
+	assert.True(t, a.IsLeader())
+	require.ErrorIs(t, a.Fenced(term, publish), ErrFenced)
+	require.Equal(t, 1, published)
+}
+
+func TestElector_FencedHoldsTheLease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lease")
+	ttl := 300 * time.Millisecond
+
+	a := NewElector(NewFileLeaseBackend(path), "a", "a:6900", ttl)
+	a.campaign()
+	require.True(t, a.IsLeader())
+
+	// the lease can't be taken over while what was sequenced in the term is published
+	taken := make(chan Lease)
+	require.NoError(t, a.Fenced(a.Term(), func() error {
+		go func() {
+			lease, err := NewFileLeaseBackend(path).TryAcquire("b", "b:6900", time.Hour, time.Now().Add(time.Hour))
+			assert.NoError(t, err)
+			taken <- lease
+		}()
+		select {
+		case <-taken:
+			t.Error("the lease changed hands while it was held")
+		case <-time.After(100 * time.Millisecond):
+		}
+		return nil
+	}))
+	assert.Equal(t, "b", (<-taken).Holder)
+}
+
+func TestElector_LeaderStreamUrl(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lease")
+	ttl := 300 * time.Millisecond
+
+	a := NewElector(NewFileLeaseBackend(path), "a", "a:6900", ttl)
+	b := NewElector(NewFileLeaseBackend(path), "b", "b:6900", ttl)
+	c := NewElector(NewFileLeaseBackend(path), "c", "c:6900", ttl)
+	a.campaign()
+	b.campaign()
+	c.campaign()
+	assert.Equal(t, "a:6900", c.LeaderStreamUrl())
+
+	// once b takes over c follows b, b keeps following a to take over what a streamed
+	require.NoError(t, a.Resign())
+	b.campaign()
+	c.campaign()
+	require.True(t, b.IsLeader())
+	assert.Equal(t, "b:6900", c.LeaderStreamUrl())
+	assert.Equal(t, "a:6900", b.LeaderStreamUrl())
+}
@@ -0,0 +1,217 @@
+package sequencer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ledgerwatch/log/v3"
+)
+
+// Lease describes the current holder of the sequencer leadership lease.  Term is increased every time the lease
+// changes hands so that a node can tell if it has lost and regained the lease in between two checks.  StreamUrl is the
+// datastream of the holder, the one the standbys follow.
+//
+// Expires is judged by the clock of each node, so the clocks of the nodes have to be kept in sync: a node whose clock
+// runs ahead takes over a lease that is still valid for the holder by as much as the skew.  Nothing is lost when it
+// happens, the term fences whatever the old holder sequenced, but the time a standby takes to fail over moves by as
+// much.
+type Lease struct {
+	Holder    string    `json:"holder"`
+	StreamUrl string    `json:"streamUrl,omitempty"`
+	Term      uint64    `json:"term"`
+	Expires   time.Time `json:"expires"`
+}
+
+func (l Lease) HeldBy(nodeId string, now time.Time) bool {
+	return l.Holder == nodeId && now.Before(l.Expires)
+}
+
+// LeaseBackend is the storage used for leader election.  Implementations must make TryAcquire atomic across all the
+// nodes taking part in the election.
+type LeaseBackend interface {
+	// TryAcquire takes the lease for nodeId if it is free, expired or already held by nodeId and extends it by ttl.
+	// The lease returned is the one in place after the call, which may be held by another node.
+	TryAcquire(nodeId, streamUrl string, ttl time.Duration, now time.Time) (Lease, error)
+	// Release gives up the lease if it is held by nodeId so that a standby can take over without waiting for expiry.
+	Release(nodeId string) error
+	// Current returns the lease in place without changing it
+	Current() (Lease, error)
+	// WhileHeld runs fn if nodeId holds the lease in term, the lease can't change hands until fn returns.  It returns
+	// the lease in place and whether fn was run.
+	WhileHeld(nodeId string, term uint64, fn func() error) (Lease, bool, error)
+}
+
+// ErrFenced is returned by Fence once the lease has moved on from the term something was sequenced in
+var ErrFenced = errors.New("sequencer lease term has moved on")
+
+// Elector keeps trying to acquire the sequencer lease and renews it while held.  Only the node reporting IsLeader
+// may sequence new blocks, every other node stays in standby following the leader.
+type Elector struct {
+	backend   LeaseBackend
+	nodeId    string
+	streamUrl string
+	ttl       time.Duration
+
+	isLeader atomic.Bool
+	term     atomic.Uint64
+
+	mtx             sync.Mutex
+	lastRenewal     time.Time
+	leaderStreamUrl string
+	changes         []chan bool
+}
+
+// NewElector returns an elector for the node, streamUrl is the address the other nodes reach its datastream at
+func NewElector(backend LeaseBackend, nodeId, streamUrl string, ttl time.Duration) *Elector {
+	return &Elector{
+		backend:   backend,
+		nodeId:    nodeId,
+		streamUrl: streamUrl,
+		ttl:       ttl,
+	}
+}
+
+func (e *Elector) NodeId() string {
+	return e.nodeId
+}
+
+// IsLeader reports whether this node currently holds a valid lease.  It turns false as soon as the lease could not
+// be renewed before its expiry, even if the backend is unreachable.
+func (e *Elector) IsLeader() bool {
+	if !e.isLeader.Load() {
+		return false
+	}
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return time.Since(e.lastRenewal) < e.ttl
+}
+
+// Term returns the lease term at the time this node last acquired it
+func (e *Elector) Term() uint64 {
+	return e.term.Load()
+}
+
+// LeaderStreamUrl is the datastream of the last other node seen holding the lease, empty until one advertised it.  A
+// node keeps following the previous leader after a promotion, it still has to take over what that one streamed.
+func (e *Elector) LeaderStreamUrl() string {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return e.leaderStreamUrl
+}
+
+// Fenced runs fn, usually the publishing of what was sequenced in term, only if this node still holds the lease in
+// the given term.  The term is the fencing token of whatever was sequenced in it, a node that lost the lease, even for
+// a moment, must not publish it.  The lease can't change hands while fn runs.
+func (e *Elector) Fenced(term uint64, fn func() error) error {
+	if !e.IsLeader() || e.Term() != term {
+		return fmt.Errorf("%w: node %s is no longer the leader of term %d", ErrFenced, e.nodeId, term)
+	}
+	lease, held, err := e.backend.WhileHeld(e.nodeId, term, fn)
+	if err != nil {
+		return err
+	}
+	if !held {
+		return fmt.Errorf("%w: lease of term %d is held by %s, node %s sequenced in term %d", ErrFenced, lease.Term, lease.Holder, e.nodeId, term)
+	}
+	return nil
+}
+
+// Subscribe returns a channel that receives the new leadership state every time it changes
+func (e *Elector) Subscribe() <-chan bool {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	ch := make(chan bool, 1)
+	e.changes = append(e.changes, ch)
+	return ch
+}
+
+// Start runs the election loop until ctx is cancelled, renewing the lease three times per ttl
+func (e *Elector) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(e.ttl / 3)
+		defer ticker.Stop()
+
+		e.campaign()
+		for {
+			select {
+			case <-ctx.Done():
+				if err := e.Resign(); err != nil {
+					log.Warn("[sequencer-ha] failed to release the lease on shutdown", "err", err)
+				}
+				return
+			case <-ticker.C:
+				e.campaign()
+			}
+		}
+	}()
+}
+
+// Resign gives up leadership straight away and releases the lease in the backend
+func (e *Elector) Resign() error {
+	e.setLeader(false, 0)
+	return e.backend.Release(e.nodeId)
+}
+
+func (e *Elector) campaign() {
+	now := time.Now()
+	lease, err := e.backend.TryAcquire(e.nodeId, e.streamUrl, e.ttl, now)
+	if err != nil {
+		// we keep our leadership until the lease we already hold runs out, IsLeader takes care of the expiry
+		log.Warn("[sequencer-ha] failed to acquire the lease", "node", e.nodeId, "err", err)
+		return
+	}
+
+	held := lease.HeldBy(e.nodeId, now)
+	e.mtx.Lock()
+	if held {
+		e.lastRenewal = now
+	} else if lease.Holder != "" && lease.StreamUrl != "" {
+		e.leaderStreamUrl = lease.StreamUrl
+	}
+	e.mtx.Unlock()
+	e.setLeader(held, lease.Term)
+
+	if !held {
+		log.Debug("[sequencer-ha] standing by", "node", e.nodeId, "leader", lease.Holder, "term", lease.Term)
+	}
+}
+
+func (e *Elector) setLeader(leader bool, term uint64) {
+	previous := e.isLeader.Swap(leader)
+	if leader {
+		e.term.Store(term)
+	}
+	if previous == leader {
+		return
+	}
+
+	if leader {
+		log.Info("[sequencer-ha] acquired the sequencer lease", "node", e.nodeId, "term", term)
+	} else {
+		log.Warn("[sequencer-ha] lost the sequencer lease", "node", e.nodeId)
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	for _, ch := range e.changes {
+		// drop the stale state if the subscriber has not picked it up yet
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- leader
+	}
+}
+
+func NewLeaseBackend(kind, path string) (LeaseBackend, error) {
+	switch kind {
+	case LeaseBackendFile:
+		return NewFileLeaseBackend(path), nil
+	default:
+		return nil, fmt.Errorf("unknown sequencer lease backend: %s", kind)
+	}
+}
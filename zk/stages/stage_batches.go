@@ -17,6 +17,7 @@ import (
 	"github.com/ledgerwatch/erigon/zk"
 	"github.com/ledgerwatch/erigon/zk/datastream/types"
 	"github.com/ledgerwatch/erigon/zk/erigon_db"
+	"github.com/ledgerwatch/erigon/zk/events"
 	"github.com/ledgerwatch/erigon/zk/hermez_db"
 	"github.com/ledgerwatch/erigon/zk/sequencer"
 	txtype "github.com/ledgerwatch/erigon/zk/tx"
@@ -85,14 +86,16 @@ type BatchesCfg struct {
 	blockRoutineStarted bool
 	dsClient            DatastreamClient
 	zkCfg               *ethconfig.Zk
+	events              *events.Events
 }
 
-func StageBatchesCfg(db kv.RwDB, dsClient DatastreamClient, zkCfg *ethconfig.Zk) BatchesCfg {
+func StageBatchesCfg(db kv.RwDB, dsClient DatastreamClient, zkCfg *ethconfig.Zk, events *events.Events) BatchesCfg {
 	return BatchesCfg{
 		db:                  db,
 		blockRoutineStarted: false,
 		dsClient:            dsClient,
 		zkCfg:               zkCfg,
+		events:              events,
 	}
 }
 
@@ -231,6 +234,7 @@ LOOP:
 			// batch boundary - record the highest hashable block number (last block in last full batch)
 			if l2Block.BatchNumber > highestSeenBatchNo {
 				highestHashableL2BlockNo = l2Block.L2BlockNumber - 1
+				cfg.events.OnBatchClosed(highestSeenBatchNo, l2Block.L2BlockNumber-1)
 			}
 			highestSeenBatchNo = l2Block.BatchNumber
 
@@ -485,6 +489,12 @@ func UnwindBatchesStage(u *stagedsync.UnwindState, tx kv.RwTx, cfg BatchesCfg, c
 	if err := hermezDb.DeleteIntermediateTxStateRoots(fromBlock, toBlock); err != nil {
 		return fmt.Errorf("delete intermediate tx state roots error: %v", err)
 	}
+	if err := hermezDb.DeleteBlockInfoRoots(fromBlock, toBlock); err != nil {
+		return fmt.Errorf("delete block info roots error: %v", err)
+	}
+	if err := hermezDb.DeleteBlockInfoTrees(fromBlock, toBlock); err != nil {
+		return fmt.Errorf("delete block info trees error: %v", err)
+	}
 	if err := eriDb.DeleteHeaders(fromBlock); err != nil {
 		return fmt.Errorf("delete headers error: %v", err)
 	}
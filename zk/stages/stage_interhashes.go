@@ -6,6 +6,7 @@ import (
 	"github.com/gateway-fm/cdk-erigon-lib/common"
 	"github.com/gateway-fm/cdk-erigon-lib/common/length"
 	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/memdb"
 	"github.com/gateway-fm/cdk-erigon-lib/state"
 	"github.com/holiman/uint256"
 	state2 "github.com/ledgerwatch/erigon/core/state"
@@ -49,6 +50,10 @@ type ZkInterHashesCfg struct {
 	historyV3 bool
 	agg       *state.AggregatorV3
 	zk        *ethconfig.Zk
+
+	// smtCache is kept across stage runs, the stage loop flushes it once it has committed.  nil when
+	// zkevm.smt-cache-size is 0
+	smtCache *db2.NodeCache
 }
 
 func StageZkInterHashesCfg(
@@ -60,6 +65,7 @@ func StageZkInterHashesCfg(
 	historyV3 bool,
 	agg *state.AggregatorV3,
 	zk *ethconfig.Zk,
+	smtCache *db2.NodeCache,
 ) ZkInterHashesCfg {
 	return ZkInterHashesCfg{
 		db:                db,
@@ -73,7 +79,23 @@ func StageZkInterHashesCfg(
 		historyV3: historyV3,
 		agg:       agg,
 		zk:        zk,
+		smtCache:  smtCache,
+	}
+}
+
+// keepStaleNodes is whether the nodes a batch insert replaces are left in the db, for the garbage collector or the
+// history window, the witness generator runs the stage without a zk config
+func (cfg ZkInterHashesCfg) keepStaleNodes() bool {
+	return cfg.zk != nil && (cfg.zk.SmtGcBatchSize > 0 || cfg.zk.SmtHistoryBlocks > 0)
+}
+
+// smtHistoryBlocks is the number of blocks the SMT can be read at, the witness generator runs the stage without a zk
+// config
+func (cfg ZkInterHashesCfg) smtHistoryBlocks() uint64 {
+	if cfg.zk == nil {
+		return 0
 	}
+	return cfg.zk.SmtHistoryBlocks
 }
 
 func SpawnZkIntermediateHashesStage(s *stagedsync.StageState, u stagedsync.Unwinder, tx kv.RwTx, cfg ZkInterHashesCfg, ctx context.Context, quiet bool) (root common.Hash, err error) {
@@ -122,19 +144,39 @@ func SpawnZkIntermediateHashesStage(s *stagedsync.StageState, u stagedsync.Unwin
 	}
 
 	shouldRegenerate := to > s.BlockNumber && to-s.BlockNumber > cfg.zk.RebuildTreeAfter
+	historyBlocks := cfg.smtHistoryBlocks()
 	eridb := db2.NewEriDb(tx)
-	smt := smt.NewSMT(eridb)
+	smtDb := newSmtDb(eridb, cfg.smtCache)
+	smt := smt.NewSMT(smtDb)
+	smt.KeepStaleNodes = cfg.keepStaleNodes()
 
-	eridb.OpenBatch(quit)
+	smtDb.OpenBatch(quit)
 
 	if s.BlockNumber == 0 || shouldRegenerate {
 		if root, err = regenerateIntermediateHashes(logPrefix, tx, eridb, smt); err != nil {
 			return trie.EmptyRoot, err
 		}
+		// the nodes of earlier roots are gone after a rebuild
+		if err = eridb.DeleteRootsFrom(0); err != nil {
+			return trie.EmptyRoot, err
+		}
+		if historyBlocks > 0 {
+			if err = eridb.SetRootAt(to, root.Big()); err != nil {
+				return trie.EmptyRoot, err
+			}
+		}
+	} else if historyBlocks > 0 {
+		if root, err = zkIncrementIntermediateHashesWithHistory(logPrefix, s, tx, eridb, smt, s.BlockNumber, to, historyBlocks); err != nil {
+			return trie.EmptyRoot, err
+		}
 	} else {
 		if root, err = zkIncrementIntermediateHashes(logPrefix, s, tx, eridb, smt, s.BlockNumber, to); err != nil {
 			return trie.EmptyRoot, err
 		}
+		// replaced nodes were deleted so any recorded roots can't be read any more
+		if err = eridb.DeleteRootsFrom(0); err != nil {
+			return trie.EmptyRoot, err
+		}
 	}
 
 	log.Info(fmt.Sprintf("[%s] Trie root", logPrefix), "hash", root.Hex())
@@ -154,13 +196,9 @@ func SpawnZkIntermediateHashesStage(s *stagedsync.StageState, u stagedsync.Unwin
 		headerHash = syncHeadHeader.Hash()
 
 		if root != expectedRootHash {
-			eridb.RollbackBatch()
-			panic(fmt.Sprintf("[%s] Wrong trie root of block %d: %x, expected (from header): %x. Block hash: %x", logPrefix, to, root, expectedRootHash, headerHash))
-
-			if cfg.badBlockHalt {
-				return trie.EmptyRoot, fmt.Errorf("wrong trie root")
-			}
-			if cfg.hd != nil {
+			// the batch holds the nodes of the wrong root, they must not reach the db or the node cache
+			smtDb.RollbackBatch()
+			if !cfg.badBlockHalt && cfg.hd != nil {
 				cfg.hd.ReportBadHeaderPoS(headerHash, syncHeadHeader.ParentHash)
 			}
 			// if to > s.BlockNumber {
@@ -168,15 +206,20 @@ func SpawnZkIntermediateHashesStage(s *stagedsync.StageState, u stagedsync.Unwin
 			//log.Warn("Unwinding due to incorrect root hash", "to", unwindTo)
 			//u.UnwindTo(unwindTo, headerHash)
 			// }
-		} else {
-			log.Info(fmt.Sprintf("[%s] State root matches", logPrefix))
+			return trie.EmptyRoot, fmt.Errorf("[%s] wrong trie root of block %d: %x, expected (from header): %x. Block hash: %x", logPrefix, to, root, expectedRootHash, headerHash)
 		}
+		log.Info(fmt.Sprintf("[%s] State root matches", logPrefix))
 	}
 
-	if err := eridb.CommitBatch(); err != nil {
+	if err := smtDb.CommitBatch(); err != nil {
 		return trie.EmptyRoot, err
 	}
 
+	if cfg.smtCache != nil {
+		hits, misses := cfg.smtCache.Stats()
+		log.Debug(fmt.Sprintf("[%s] SMT node cache", logPrefix), "nodes", cfg.smtCache.Len(), "hits", hits, "misses", misses)
+	}
+
 	if err = s.Update(tx, to); err != nil {
 		return trie.EmptyRoot, err
 	}
@@ -185,11 +228,21 @@ func SpawnZkIntermediateHashesStage(s *stagedsync.StageState, u stagedsync.Unwin
 		if err := tx.Commit(); err != nil {
 			return trie.EmptyRoot, err
 		}
+		cfg.smtCache.Flush()
 	}
 
 	return root, err
 }
 
+// newSmtDb reads and writes the SMT through the node cache when there is one.  Whoever commits the transaction has to
+// flush the cache afterwards
+func newSmtDb(eridb *db2.EriDb, cache *db2.NodeCache) smt.DB {
+	if cache == nil {
+		return eridb
+	}
+	return db2.NewCachedDb(eridb, cache)
+}
+
 func UnwindZkIntermediateHashesStage(u *stagedsync.UnwindState, s *stagedsync.StageState, tx kv.RwTx, cfg ZkInterHashesCfg, ctx context.Context) (err error) {
 	quit := ctx.Done()
 	useExternalTx := tx != nil
@@ -213,11 +266,39 @@ func UnwindZkIntermediateHashesStage(u *stagedsync.UnwindState, s *stagedsync.St
 		expectedRootHash = syncHeadHeader.Root
 	}
 
-	root, err := unwindZkSMT(s.LogPrefix(), s.BlockNumber, u.UnwindPoint, tx, true, &expectedRootHash, quit)
+	eridb := db2.NewEriDb(tx)
+	historicRoot, err := eridb.GetRootAt(u.UnwindPoint)
 	if err != nil {
 		return err
 	}
-	_ = root
+
+	if historicRoot != nil {
+		// every node under a root in the history window is still in the db so there is nothing to replay
+		if syncHeadHeader != nil && common.BigToHash(historicRoot) != expectedRootHash {
+			return fmt.Errorf("recorded smt root %x for block %d does not match header root %x", common.BigToHash(historicRoot), u.UnwindPoint, expectedRootHash)
+		}
+		sources, err := smtKeySourcesChanged(tx, u.UnwindPoint, s.BlockNumber)
+		if err != nil {
+			return err
+		}
+		if err = eridb.SetLastRoot(historicRoot); err != nil {
+			return err
+		}
+		// the key sources and hash keys must describe the leaves of the historic root again
+		if err = smt.NewSMT(newSmtDb(eridb, cfg.smtCache)).RevertKeys(utils.ScalarToRoot(historicRoot), sources); err != nil {
+			return err
+		}
+	} else {
+		root, err := unwindZkSMT(s.LogPrefix(), s.BlockNumber, u.UnwindPoint, tx, true, &expectedRootHash, cfg.keepStaleNodes(), cfg.smtCache, quit)
+		if err != nil {
+			return err
+		}
+		_ = root
+	}
+
+	if err = eridb.DeleteRootsFrom(u.UnwindPoint + 1); err != nil {
+		return err
+	}
 
 	if err := u.Done(tx); err != nil {
 		return err
@@ -226,10 +307,129 @@ func UnwindZkIntermediateHashesStage(u *stagedsync.UnwindState, s *stagedsync.St
 		if err := tx.Commit(); err != nil {
 			return err
 		}
+		cfg.smtCache.Flush()
+	}
+	return nil
+}
+
+// smtKeySourcesChanged returns the key source of every SMT key of the accounts and storage changed by the blocks after
+// from up to and including to
+func smtKeySourcesChanged(tx kv.Tx, from, to uint64) (map[utils.NodeKey][]byte, error) {
+	sources := make(map[utils.NodeKey][]byte)
+	accountKeys := []struct {
+		keyType int
+		key     func(ethAddr string) (utils.NodeKey, error)
+	}{
+		{utils.KEY_BALANCE, utils.KeyEthAddrBalance},
+		{utils.KEY_NONCE, utils.KeyEthAddrNonce},
+		{utils.SC_CODE, utils.KeyContractCode},
+		{utils.SC_LENGTH, utils.KeyContractLength},
+	}
+
+	for blockNo := from + 1; blockNo <= to; blockNo++ {
+		dupSortKey := dbutils.EncodeBlockNumber(blockNo)
+
+		if err := tx.ForPrefix(kv.AccountChangeSet, dupSortKey, func(_, v []byte) error {
+			addr := common.BytesToAddress(v[:length.Addr])
+			for _, k := range accountKeys {
+				key, err := k.key(addr.String())
+				if err != nil {
+					return err
+				}
+				sources[key] = utils.EncodeKeySource(k.keyType, addr, common.Hash{})
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		if err := tx.ForPrefix(kv.StorageChangeSet, dupSortKey, func(sk, sv []byte) error {
+			addr, _ := dbutils.PlainParseStoragePrefix(sk[length.BlockNum:])
+			position := common.BytesToHash(sv[:length.Hash])
+			key, err := utils.KeyContractStorage(utils.ScalarToArrayBig(utils.ConvertHexToBigInt(addr.String())), fmt.Sprintf("0x%032x", position))
+			if err != nil {
+				return err
+			}
+			sources[key] = utils.EncodeKeySource(utils.SC_STORAGE, addr, position)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return sources, nil
+}
+
+// PruneZkIntermediateHashesStage runs a step of the SMT garbage collector.  Nodes reachable from the state root of
+// any block in the retain window are kept so the tree can still be read at those blocks.
+func PruneZkIntermediateHashesStage(p *stagedsync.PruneState, tx kv.RwTx, cfg ZkInterHashesCfg, ctx context.Context) (err error) {
+	if cfg.zk == nil || cfg.zk.SmtGcBatchSize == 0 {
+		return nil
+	}
+
+	useExternalTx := tx != nil
+	if !useExternalTx {
+		tx, err = cfg.db.BeginRw(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+	}
+	logPrefix := p.LogPrefix()
+
+	retainBlocks := cfg.zk.SmtGcRetainBlocks
+	if cfg.zk.SmtHistoryBlocks > retainBlocks {
+		retainBlocks = cfg.zk.SmtHistoryBlocks
+	}
+	roots, err := SmtRetainedRoots(tx, p.ForwardProgress, retainBlocks)
+	if err != nil {
+		return err
+	}
+
+	dbSmt := smt.NewSMT(newSmtDb(db2.NewEriDb(tx), cfg.smtCache))
+	result, err := dbSmt.CollectGarbage(ctx, roots, int(cfg.zk.SmtGcBatchSize))
+	if err != nil {
+		return err
+	}
+	if result.Finished {
+		log.Info(fmt.Sprintf("[%s] SMT garbage collection pass finished", logPrefix), "marked", result.Marked, "swept", result.Swept)
+	} else {
+		log.Debug(fmt.Sprintf("[%s] SMT garbage collection", logPrefix), "marked", result.Marked, "swept", result.Swept)
+	}
+
+	if err = p.Done(tx); err != nil {
+		return err
+	}
+	if !useExternalTx {
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+		cfg.smtCache.Flush()
 	}
 	return nil
 }
 
+// SmtRetainedRoots returns the distinct state roots of the retainBlocks blocks up to and including progress
+func SmtRetainedRoots(tx kv.Tx, progress, retainBlocks uint64) ([]utils.NodeKey, error) {
+	from := uint64(0)
+	if progress > retainBlocks {
+		from = progress - retainBlocks
+	}
+
+	roots := make([]utils.NodeKey, 0, progress-from+1)
+	var last common.Hash
+	for blockNo := from; blockNo <= progress; blockNo++ {
+		header := rawdb.ReadHeaderByNumber(tx, blockNo)
+		if header == nil || header.Root == last {
+			continue
+		}
+		last = header.Root
+		roots = append(roots, utils.ScalarToRoot(header.Root.Big()))
+	}
+
+	return roots, nil
+}
+
 func regenerateIntermediateHashes(logPrefix string, db kv.RwTx, eridb *db2.EriDb, smtIn *smt.SMT) (common.Hash, error) {
 	log.Info(fmt.Sprintf("[%s] Regeneration trie hashes started", logPrefix))
 	defer log.Info(fmt.Sprintf("[%s] Regeneration ended", logPrefix))
@@ -238,6 +438,40 @@ func regenerateIntermediateHashes(logPrefix string, db kv.RwTx, eridb *db2.EriDb
 		log.Warn(fmt.Sprint("regenerate SaveStageProgress to zero error: ", err))
 	}
 
+	root, err := generateSmtFromPlainState(logPrefix, db, eridb, smtIn)
+	if err != nil {
+		return trie.EmptyRoot, err
+	}
+
+	err2 := db.ClearBucket("HermezSmtAccountValues")
+	if err2 != nil {
+		log.Warn(fmt.Sprint("regenerate SaveStageProgress to zero error: ", err2))
+	}
+
+	return root, nil
+}
+
+// GenerateSmtRoot builds the SMT for the plain state in a temporary db and returns its root, leaving the SMT in tx
+// untouched
+func GenerateSmtRoot(ctx context.Context, logPrefix string, tx kv.Tx, tmpDir string) (common.Hash, error) {
+	tmpDb := memdb.New(tmpDir)
+	defer tmpDb.Close()
+
+	tmpTx, err := tmpDb.BeginRw(ctx)
+	if err != nil {
+		return trie.EmptyRoot, err
+	}
+	defer tmpTx.Rollback()
+
+	if err = db2.CreateEriDbBuckets(tmpTx); err != nil {
+		return trie.EmptyRoot, err
+	}
+
+	eridb := db2.NewEriDb(tmpTx)
+	return generateSmtFromPlainState(logPrefix, tx, eridb, smt.NewSMT(eridb))
+}
+
+func generateSmtFromPlainState(logPrefix string, db kv.Tx, eridb *db2.EriDb, smtIn *smt.SMT) (common.Hash, error) {
 	var a *accounts.Account
 	var addr common.Address
 	var as map[string]string
@@ -318,11 +552,6 @@ func regenerateIntermediateHashes(logPrefix string, db kv.RwTx, eridb *db2.EriDb
 		return trie.EmptyRoot, err
 	}
 
-	err2 := db.ClearBucket("HermezSmtAccountValues")
-	if err2 != nil {
-		log.Warn(fmt.Sprint("regenerate SaveStageProgress to zero error: ", err2))
-	}
-
 	root := smtIn.LastRoot()
 
 	return common.BigToHash(root), nil
@@ -431,12 +660,50 @@ func zkIncrementIntermediateHashes(logPrefix string, s *stagedsync.StageState, d
 	return hash, nil
 }
 
-func unwindZkSMT(logPrefix string, from, to uint64, db kv.RwTx, checkRoot bool, expectedRootHash *common.Hash, quit <-chan struct{}) (common.Hash, error) {
+// zkIncrementIntermediateHashesWithHistory hashes the blocks inside the history window one at a time and records the
+// root of each of them, anything before the window is hashed as a single range
+func zkIncrementIntermediateHashesWithHistory(logPrefix string, s *stagedsync.StageState, db kv.RwTx, eridb *db2.EriDb, dbSmt *smt.SMT, from, to, historyBlocks uint64) (common.Hash, error) {
+	windowStart := from
+	if to > historyBlocks && to-historyBlocks > from {
+		windowStart = to - historyBlocks
+	}
+
+	var root common.Hash
+	var err error
+	if windowStart > from {
+		if root, err = zkIncrementIntermediateHashes(logPrefix, s, db, eridb, dbSmt, from, windowStart); err != nil {
+			return trie.EmptyRoot, err
+		}
+		if err = eridb.SetRootAt(windowStart, root.Big()); err != nil {
+			return trie.EmptyRoot, err
+		}
+	}
+
+	for blockNo := windowStart; blockNo < to; blockNo++ {
+		if root, err = zkIncrementIntermediateHashes(logPrefix, s, db, eridb, dbSmt, blockNo, blockNo+1); err != nil {
+			return trie.EmptyRoot, err
+		}
+		if err = eridb.SetRootAt(blockNo+1, root.Big()); err != nil {
+			return trie.EmptyRoot, err
+		}
+	}
+
+	if to > historyBlocks {
+		if err = eridb.DeleteRootsBefore(to - historyBlocks); err != nil {
+			return trie.EmptyRoot, err
+		}
+	}
+
+	return root, nil
+}
+
+func unwindZkSMT(logPrefix string, from, to uint64, db kv.RwTx, checkRoot bool, expectedRootHash *common.Hash, keepStaleNodes bool, smtCache *db2.NodeCache, quit <-chan struct{}) (common.Hash, error) {
 	log.Info(fmt.Sprintf("[%s] Unwind trie hashes started", logPrefix))
 	defer log.Info(fmt.Sprintf("[%s] Unwind ended", logPrefix))
 
-	eridb := db2.NewEriDb(db)
-	dbSmt := smt.NewSMT(eridb)
+	smtDb := newSmtDb(db2.NewEriDb(db), smtCache)
+	dbSmt := smt.NewSMT(smtDb)
+	dbSmt.KeepStaleNodes = keepStaleNodes
 
 	log.Info(fmt.Sprintf("[%s]", logPrefix), "last root", common.BigToHash(dbSmt.LastRoot()))
 
@@ -445,7 +712,7 @@ func unwindZkSMT(logPrefix string, from, to uint64, db kv.RwTx, checkRoot bool,
 		quit = make(chan struct{})
 	}
 
-	eridb.OpenBatch(quit)
+	smtDb.OpenBatch(quit)
 
 	ac, err := db.CursorDupSort(kv.AccountChangeSet)
 	if err != nil {
@@ -571,11 +838,11 @@ func unwindZkSMT(logPrefix string, from, to uint64, db kv.RwTx, checkRoot bool,
 
 	if err := verifyLastHash(dbSmt, expectedRootHash, checkRoot, logPrefix); err != nil {
 		log.Error("failed to verify hash")
-		eridb.RollbackBatch()
+		smtDb.RollbackBatch()
 		return trie.EmptyRoot, err
 	}
 
-	if err := eridb.CommitBatch(); err != nil {
+	if err := smtDb.CommitBatch(); err != nil {
 		return trie.EmptyRoot, err
 	}
 
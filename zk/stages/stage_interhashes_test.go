@@ -0,0 +1,49 @@
+package stages
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/memdb"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/common/dbutils"
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/smt"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+)
+
+func TestSmtKeySourcesChanged(t *testing.T) {
+	_, tx := memdb.NewTestTx(t)
+	addr := common.HexToAddress("0x1234")
+	other := common.HexToAddress("0x5678")
+	slot := common.HexToHash("0x02")
+
+	// block 2 changes addr and one of its slots, block 4 is outside the range
+	require.NoError(t, tx.Put(kv.AccountChangeSet, dbutils.EncodeBlockNumber(2), addr.Bytes()))
+	storageKey := append(dbutils.EncodeBlockNumber(2), dbutils.PlainGenerateStoragePrefix(addr.Bytes(), 1)...)
+	require.NoError(t, tx.Put(kv.StorageChangeSet, storageKey, slot.Bytes()))
+	require.NoError(t, tx.Put(kv.AccountChangeSet, dbutils.EncodeBlockNumber(4), other.Bytes()))
+
+	sources, err := smtKeySourcesChanged(tx, 1, 3)
+	require.NoError(t, err)
+
+	// the same keys and sources as the stage writes going forward
+	memDb := db2.NewMemDb()
+	account := &accounts.Account{Balance: *uint256.NewInt(1), Nonce: 1}
+	_, _, err = smt.NewSMT(memDb).SetStorage("",
+		map[common.Address]*accounts.Account{addr: account},
+		map[common.Address]string{addr: "0x6001"},
+		map[common.Address]map[string]string{addr: {fmt.Sprintf("0x%032x", slot): "0x01"}},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, sources, len(memDb.DbKeySource))
+	for key, source := range sources {
+		require.Equal(t, memDb.DbKeySource[utils.ArrayToScalar(key[:]).String()], source)
+	}
+}
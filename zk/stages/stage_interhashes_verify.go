@@ -0,0 +1,400 @@
+package stages
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/length"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/status-im/keycard-go/hexutils"
+
+	"github.com/ledgerwatch/erigon/common/dbutils"
+	state2 "github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/smt"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+)
+
+// SmtDivergence is a leaf of the SMT which doesn't hold the value the plain state has for it, or which has no key
+// source.  A leaf without a key source is resolved when one of the accounts of the plain state has its key, Expected is
+// nil when none does.
+type SmtDivergence struct {
+	Key        utils.NodeKey
+	Resolved   bool
+	Type       int
+	Address    common.Address
+	StorageKey common.Hash
+	Expected   *big.Int
+	Actual     *big.Int
+	// Subtree is the path from the root to the leaf, or to the empty branch or other leaf the key ends at
+	Subtree string
+}
+
+func (d *SmtDivergence) Field() string {
+	if !d.Resolved {
+		return "unknown"
+	}
+	return smt.FieldName(d.Type)
+}
+
+type SmtVerifyResult struct {
+	Root        common.Hash
+	Leaves      uint64
+	Accounts    uint64
+	Divergences []*SmtDivergence
+	// Truncated is set when the check stopped at maxDivergences, there may be more
+	Truncated bool
+}
+
+// Unresolved counts the leaves without a key source which belong to no account of the plain state
+func (r *SmtVerifyResult) Unresolved() int {
+	n := 0
+	for _, d := range r.Divergences {
+		if !d.Resolved {
+			n++
+		}
+	}
+	return n
+}
+
+// VerifySmt cross-checks the latest SMT against the plain state, which is only kept for the latest block so older
+// roots can't be checked.  Every leaf is looked up in the plain state through its key source, then the leaves each
+// account should have are counted, only accounts whose count doesn't match have their SMT keys computed and looked up.
+// It stops after maxDivergences divergences.
+func VerifySmt(ctx context.Context, logPrefix string, tx kv.RwTx, maxDivergences int) (*SmtVerifyResult, error) {
+	dbSmt := smt.NewSMT(db2.NewEriDb(tx))
+	root := utils.ScalarToRoot(dbSmt.LastRoot())
+	v := &smtVerifier{
+		logPrefix:      logPrefix,
+		tx:             tx,
+		psr:            state2.NewPlainStateReader(tx),
+		dbSmt:          dbSmt,
+		root:           root,
+		maxDivergences: maxDivergences,
+		leafCounts:     make(map[common.Address]int),
+		reported:       make(map[utils.NodeKey]*SmtDivergence),
+		result:         &SmtVerifyResult{Root: common.BigToHash(root.ToBigInt())},
+	}
+
+	log.Info(fmt.Sprintf("[%s] Checking SMT leaves against the plain state", logPrefix), "root", v.result.Root.Hex())
+	if err := v.checkLeaves(ctx); err != nil {
+		return nil, err
+	}
+	if !v.full() {
+		log.Info(fmt.Sprintf("[%s] Checking plain state accounts against the SMT", logPrefix), "leaves", v.result.Leaves)
+		if err := v.checkAccounts(ctx); err != nil {
+			return nil, err
+		}
+	}
+	v.result.Truncated = v.full()
+
+	return v.result, nil
+}
+
+type smtVerifier struct {
+	logPrefix      string
+	tx             kv.Tx
+	psr            *state2.PlainStateReader
+	dbSmt          *smt.SMT
+	root           utils.NodeKey
+	maxDivergences int
+
+	// leafCounts is the number of leaves found for each account, flagged holds the accounts which had a divergence
+	leafCounts map[common.Address]int
+	flagged    map[common.Address]struct{}
+	reported   map[utils.NodeKey]*SmtDivergence
+	result     *SmtVerifyResult
+}
+
+func (v *smtVerifier) full() bool {
+	return v.maxDivergences > 0 && len(v.result.Divergences) >= v.maxDivergences
+}
+
+func (v *smtVerifier) report(d *SmtDivergence) error {
+	proof, err := v.dbSmt.GetProof(d.Key, v.root)
+	if err != nil {
+		return err
+	}
+	var subtree strings.Builder
+	for _, bit := range d.Key.GetPath()[:len(proof.Siblings)] {
+		subtree.WriteByte(byte('0' + bit))
+	}
+	d.Subtree = subtree.String()
+
+	if len(v.result.Divergences) == 0 {
+		log.Warn(fmt.Sprintf("[%s] First SMT divergence", v.logPrefix), "address", d.Address, "field", d.Field(), "storageKey", d.StorageKey,
+			"expected", d.Expected, "actual", d.Actual, "subtree", d.Subtree)
+	}
+	v.result.Divergences = append(v.result.Divergences, d)
+	v.reported[d.Key] = d
+	if d.Resolved {
+		if v.flagged == nil {
+			v.flagged = make(map[common.Address]struct{})
+		}
+		v.flagged[d.Address] = struct{}{}
+	}
+	return nil
+}
+
+// checkLeaves finds the leaves whose value isn't the one in the plain state, including leaves of accounts or slots the
+// plain state doesn't have
+func (v *smtVerifier) checkLeaves(ctx context.Context) error {
+	return v.dbSmt.IterateState(ctx, v.root, nil, func(leaf *smt.StateLeaf) (bool, error) {
+		v.result.Leaves++
+		if !leaf.Resolved {
+			if err := v.report(&SmtDivergence{Key: leaf.Key, Actual: leaf.Value}); err != nil {
+				return false, err
+			}
+			return !v.full(), nil
+		}
+
+		v.leafCounts[leaf.Address]++
+		expected, err := v.expectedValue(leaf.Address, leaf.Type, leaf.StorageKey)
+		if err != nil {
+			return false, err
+		}
+		if expected.Cmp(leaf.Value) != 0 {
+			if err = v.report(&SmtDivergence{
+				Key:        leaf.Key,
+				Resolved:   true,
+				Type:       leaf.Type,
+				Address:    leaf.Address,
+				StorageKey: leaf.StorageKey,
+				Expected:   expected,
+				Actual:     leaf.Value,
+			}); err != nil {
+				return false, err
+			}
+		}
+		return !v.full(), nil
+	})
+}
+
+// expectedValue is the value the plain state has for a leaf, zero when the account or slot doesn't exist
+func (v *smtVerifier) expectedValue(addr common.Address, t int, storageKey common.Hash) (*big.Int, error) {
+	acc, err := v.psr.ReadAccountData(addr)
+	if err != nil || acc == nil {
+		return big.NewInt(0), err
+	}
+
+	switch t {
+	case utils.KEY_BALANCE:
+		return acc.Balance.ToBig(), nil
+	case utils.KEY_NONCE:
+		return new(big.Int).SetUint64(acc.Nonce), nil
+	case utils.SC_CODE, utils.SC_LENGTH:
+		code, err := v.psr.ReadAccountCode(addr, acc.Incarnation, acc.CodeHash)
+		if err != nil || len(code) == 0 {
+			return big.NewInt(0), err
+		}
+		if t == utils.SC_LENGTH {
+			return big.NewInt(int64(len(code))), nil
+		}
+		hashedBytecode, err := utils.HashContractBytecode(fmt.Sprintf("0x%s", hexutils.BytesToHex(code)))
+		if err != nil {
+			return nil, err
+		}
+		return utils.ConvertHexToBigInt(hashedBytecode), nil
+	case utils.SC_STORAGE:
+		value, err := v.psr.ReadAccountStorage(addr, acc.Incarnation, &storageKey)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetBytes(value), nil
+	default:
+		return nil, fmt.Errorf("unknown key source type %d for %s", t, addr)
+	}
+}
+
+// checkAccounts finds the leaves missing from the SMT.  Every leaf found was for a non-zero value of the plain state,
+// so an account with as many leaves as it has non-zero fields and slots can't be missing any.
+func (v *smtVerifier) checkAccounts(ctx context.Context) error {
+	var addr common.Address
+	var acc *accounts.Account
+	var slots int
+
+	finish := func() error {
+		if acc == nil {
+			return nil
+		}
+		v.result.Accounts++
+		expected := slots
+		if !acc.Balance.IsZero() {
+			expected++
+		}
+		if acc.Nonce != 0 {
+			expected++
+		}
+		if !acc.IsEmptyCodeHash() {
+			expected += 2
+		}
+		_, flagged := v.flagged[addr]
+		if expected == v.leafCounts[addr] && !flagged {
+			return nil
+		}
+		return v.checkAccount(addr, acc)
+	}
+
+	err := v.tx.ForEach(kv.PlainState, nil, func(k, value []byte) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if v.full() {
+			return nil
+		}
+
+		if len(k) == length.Addr {
+			if err := finish(); err != nil {
+				return err
+			}
+			addr = common.BytesToAddress(k)
+			acc = &accounts.Account{}
+			slots = 0
+			if err := acc.DecodeForStorage(value); err != nil {
+				acc = nil
+			}
+			return nil
+		}
+
+		if acc == nil {
+			return nil
+		}
+		if _, incarnation, _ := dbutils.PlainParseCompositeStorageKey(k); incarnation == acc.Incarnation && new(big.Int).SetBytes(value).Sign() != 0 {
+			slots++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if v.full() {
+		return nil
+	}
+	return finish()
+}
+
+// checkAccount looks up every leaf the account should have
+func (v *smtVerifier) checkAccount(addr common.Address, acc *accounts.Account) error {
+	ethAddr := addr.String()
+
+	check := func(key utils.NodeKey, t int, storageKey common.Hash) error {
+		reported, ok := v.reported[key]
+		if ok && reported.Resolved || !ok && v.full() {
+			return nil
+		}
+		expected, err := v.expectedValue(addr, t, storageKey)
+		if err != nil {
+			return err
+		}
+		if ok {
+			// the leaf without a key source is this one, repairing it writes the key source back
+			reported.Resolved, reported.Type, reported.Address, reported.StorageKey, reported.Expected = true, t, addr, storageKey, expected
+			return nil
+		}
+		value, err := v.dbSmt.GetProof(key, v.root)
+		if err != nil {
+			return err
+		}
+		actual := utils.ArrayBigToScalar(utils.BigIntArrayFromNodeValue8(&value.Value))
+		if expected.Cmp(actual) == 0 {
+			return nil
+		}
+		return v.report(&SmtDivergence{
+			Key:        key,
+			Resolved:   true,
+			Type:       t,
+			Address:    addr,
+			StorageKey: storageKey,
+			Expected:   expected,
+			Actual:     actual,
+		})
+	}
+
+	accountKeys := []struct {
+		key func(string) (utils.NodeKey, error)
+		t   int
+	}{
+		{utils.KeyEthAddrBalance, utils.KEY_BALANCE},
+		{utils.KeyEthAddrNonce, utils.KEY_NONCE},
+		{utils.KeyContractCode, utils.SC_CODE},
+		{utils.KeyContractLength, utils.SC_LENGTH},
+	}
+	for _, ak := range accountKeys {
+		key, err := ak.key(ethAddr)
+		if err != nil {
+			return err
+		}
+		if err = check(key, ak.t, common.Hash{}); err != nil {
+			return err
+		}
+	}
+
+	ethAddrArray := utils.ScalarToArrayBig(utils.ConvertHexToBigInt(ethAddr))
+	prefix := dbutils.PlainGenerateStoragePrefix(addr[:], acc.Incarnation)
+	return v.tx.ForPrefix(kv.PlainState, prefix, func(k, value []byte) error {
+		if len(k) != len(prefix)+length.Hash || new(big.Int).SetBytes(value).Sign() == 0 {
+			return nil
+		}
+		storageKey := common.BytesToHash(k[len(prefix):])
+		key, err := utils.KeyContractStorage(ethAddrArray, storageKey.Hex())
+		if err != nil {
+			return err
+		}
+		return check(key, utils.SC_STORAGE, storageKey)
+	})
+}
+
+// RepairSmt writes the plain state values of the diverging leaves into the SMT, only the paths from those leaves to the
+// root are rehashed.  Leaves the plain state doesn't have are removed along with their key source, so are the leaves
+// without a key source which belong to no account.  The replaced nodes are kept in case the SMT history still refers
+// to them, garbage collection removes them otherwise.  A truncated result is refused, the leaves without a key source
+// are only known not to belong to any account once every account has been checked.
+func RepairSmt(logPrefix string, tx kv.RwTx, result *SmtVerifyResult) (common.Hash, error) {
+	if result.Truncated {
+		return common.Hash{}, fmt.Errorf("verification stopped after %d divergences, raise --max-divergences (0 for no limit) to repair them all", len(result.Divergences))
+	}
+
+	eridb := db2.NewEriDb(tx)
+	dbSmt := smt.NewSMT(eridb)
+	dbSmt.KeepStaleNodes = true
+
+	divergences := result.Divergences
+	keys := make([]*utils.NodeKey, 0, len(divergences))
+	values := make([]*utils.NodeValue8, 0, len(divergences))
+	for _, d := range divergences {
+		expected := d.Expected
+		if !d.Resolved {
+			expected = big.NewInt(0)
+		}
+		value, err := utils.NodeValue8FromBigIntArray(utils.ScalarToArrayBig(expected))
+		if err != nil {
+			return common.Hash{}, err
+		}
+		// the leaf goes away with a zero value, so does its key source even though stale nodes are kept
+		if expected.Sign() != 0 {
+			err = dbSmt.InsertKeySource(&d.Key, d.Type, &d.Address, &d.StorageKey)
+		} else {
+			err = eridb.DeleteKeySource(d.Key)
+		}
+		if err != nil {
+			return common.Hash{}, err
+		}
+		keys = append(keys, &d.Key)
+		values = append(values, value)
+	}
+	if len(keys) == 0 {
+		return common.BigToHash(dbSmt.LastRoot()), nil
+	}
+
+	if _, err := dbSmt.InsertBatch(logPrefix, keys, values, nil, nil); err != nil {
+		return common.Hash{}, err
+	}
+	return common.BigToHash(dbSmt.LastRoot()), nil
+}
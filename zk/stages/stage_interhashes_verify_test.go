@@ -0,0 +1,206 @@
+package stages
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/memdb"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/smt"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+)
+
+var (
+	verifyAddr = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	verifySlot = common.HexToHash("0x05")
+)
+
+// newVerifyTx has a plain state with an account holding a storage slot, and the SMT built from it
+func newVerifyTx(t *testing.T) (kv.RwTx, *smt.SMT) {
+	_, tx := memdb.NewTestTx(t)
+	require.NoError(t, db2.CreateEriDbBuckets(tx))
+
+	w := state.NewPlainStateWriterNoHistory(tx)
+	acc := accounts.NewAccount()
+	acc.Nonce, acc.Balance, acc.Incarnation = 3, *uint256.NewInt(1000), 1
+	require.NoError(t, w.UpdateAccountData(verifyAddr, &accounts.Account{}, &acc))
+	require.NoError(t, w.WriteAccountStorage(verifyAddr, 1, &verifySlot, uint256.NewInt(0), uint256.NewInt(7)))
+	other := accounts.NewAccount()
+	other.Balance = *uint256.NewInt(50)
+	require.NoError(t, w.UpdateAccountData(common.HexToAddress("0x2"), &accounts.Account{}, &other))
+
+	eridb := db2.NewEriDb(tx)
+	dbSmt := smt.NewSMT(eridb)
+	_, err := generateSmtFromPlainState("", tx, eridb, dbSmt)
+	require.NoError(t, err)
+	return tx, dbSmt
+}
+
+func verifyStorageKey(t *testing.T, slot common.Hash) utils.NodeKey {
+	key, err := utils.KeyContractStorage(utils.ScalarToArrayBig(utils.ConvertHexToBigInt(verifyAddr.String())), slot.Hex())
+	require.NoError(t, err)
+	return key
+}
+
+func verifyAccountKey(t *testing.T, key func(string) (utils.NodeKey, error)) utils.NodeKey {
+	nodeKey, err := key(verifyAddr.String())
+	require.NoError(t, err)
+	return nodeKey
+}
+
+// requireDivergence checks the single divergence found and that its subtree is the path to where the key ends
+func requireDivergence(t *testing.T, tx kv.RwTx, dbSmt *smt.SMT, key utils.NodeKey, keyType int, storageKey common.Hash, expected, actual int64) *SmtVerifyResult {
+	t.Helper()
+	result, err := VerifySmt(context.Background(), "", tx, 0)
+	require.NoError(t, err)
+	require.Len(t, result.Divergences, 1)
+
+	d := result.Divergences[0]
+	assert.Equal(t, key, d.Key)
+	assert.True(t, d.Resolved)
+	assert.Equal(t, verifyAddr, d.Address)
+	assert.Equal(t, keyType, d.Type)
+	assert.Equal(t, storageKey, d.StorageKey)
+	assert.Equal(t, big.NewInt(expected), d.Expected)
+	assert.Equal(t, big.NewInt(actual), d.Actual)
+
+	proof, err := dbSmt.GetProof(key, utils.ScalarToRoot(dbSmt.LastRoot()))
+	require.NoError(t, err)
+	var path strings.Builder
+	for _, bit := range key.GetPath()[:len(proof.Siblings)] {
+		path.WriteByte(byte('0' + bit))
+	}
+	assert.Equal(t, path.String(), d.Subtree)
+	return result
+}
+
+// requireRepaired repairs the divergences and checks the SMT is the one built from the plain state
+func requireRepaired(t *testing.T, tx kv.RwTx, result *SmtVerifyResult) {
+	t.Helper()
+	expected, err := GenerateSmtRoot(context.Background(), "", tx, t.TempDir())
+	require.NoError(t, err)
+
+	root, err := RepairSmt("", tx, result)
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+
+	result, err = VerifySmt(context.Background(), "", tx, 0)
+	require.NoError(t, err)
+	require.Empty(t, result.Divergences)
+}
+
+func TestVerifySmt_Clean(t *testing.T) {
+	tx, _ := newVerifyTx(t)
+
+	result, err := VerifySmt(context.Background(), "", tx, 0)
+	require.NoError(t, err)
+	require.Empty(t, result.Divergences)
+	require.Equal(t, uint64(4), result.Leaves)
+	require.Equal(t, uint64(2), result.Accounts)
+}
+
+func TestVerifySmt_CorruptLeaf(t *testing.T) {
+	tx, dbSmt := newVerifyTx(t)
+	key := verifyAccountKey(t, utils.KeyEthAddrBalance)
+	_, err := dbSmt.InsertKA(key, big.NewInt(999))
+	require.NoError(t, err)
+
+	result := requireDivergence(t, tx, dbSmt, key, utils.KEY_BALANCE, common.Hash{}, 1000, 999)
+	requireRepaired(t, tx, result)
+}
+
+func TestVerifySmt_CorruptStorageSlot(t *testing.T) {
+	tx, dbSmt := newVerifyTx(t)
+	key := verifyStorageKey(t, verifySlot)
+	_, err := dbSmt.InsertKA(key, big.NewInt(8))
+	require.NoError(t, err)
+
+	result := requireDivergence(t, tx, dbSmt, key, utils.SC_STORAGE, verifySlot, 7, 8)
+	requireRepaired(t, tx, result)
+}
+
+func TestVerifySmt_MissingLeaf(t *testing.T) {
+	tx, dbSmt := newVerifyTx(t)
+	key := verifyAccountKey(t, utils.KeyEthAddrNonce)
+	_, err := dbSmt.InsertKA(key, big.NewInt(0))
+	require.NoError(t, err)
+
+	// the leaf is only found missing by counting the leaves of the account
+	result := requireDivergence(t, tx, dbSmt, key, utils.KEY_NONCE, common.Hash{}, 3, 0)
+	requireRepaired(t, tx, result)
+}
+
+func TestVerifySmt_ExtraLeafRemovesKeySource(t *testing.T) {
+	tx, dbSmt := newVerifyTx(t)
+	slot := common.HexToHash("0x06")
+	key := verifyStorageKey(t, slot)
+	_, err := dbSmt.InsertKA(key, big.NewInt(5))
+	require.NoError(t, err)
+	require.NoError(t, dbSmt.InsertKeySource(&key, utils.SC_STORAGE, &verifyAddr, &slot))
+
+	result := requireDivergence(t, tx, dbSmt, key, utils.SC_STORAGE, slot, 0, 5)
+	requireRepaired(t, tx, result)
+
+	_, err = dbSmt.Db.GetKeySource(key)
+	require.Error(t, err, "the key source of the removed leaf should be gone")
+}
+
+func TestVerifySmt_LostKeySourceIsResolved(t *testing.T) {
+	tx, dbSmt := newVerifyTx(t)
+	key := verifyAccountKey(t, utils.KeyEthAddrBalance)
+	require.NoError(t, db2.NewEriDb(tx).DeleteKeySource(key))
+
+	result, err := VerifySmt(context.Background(), "", tx, 0)
+	require.NoError(t, err)
+	require.Len(t, result.Divergences, 1)
+	d := result.Divergences[0]
+	assert.True(t, d.Resolved)
+	assert.Equal(t, verifyAddr, d.Address)
+	assert.Equal(t, utils.KEY_BALANCE, d.Type)
+	assert.Equal(t, big.NewInt(1000), d.Expected)
+	assert.Equal(t, 0, result.Unresolved())
+
+	requireRepaired(t, tx, result)
+	_, err = dbSmt.Db.GetKeySource(key)
+	require.NoError(t, err, "the key source should be written back")
+}
+
+func TestVerifySmt_UnresolvedLeafIsRemoved(t *testing.T) {
+	tx, dbSmt := newVerifyTx(t)
+	_, err := dbSmt.InsertKA(utils.NodeKey{1, 2, 3, 4}, big.NewInt(42))
+	require.NoError(t, err)
+
+	result, err := VerifySmt(context.Background(), "", tx, 0)
+	require.NoError(t, err)
+	require.Len(t, result.Divergences, 1)
+	assert.False(t, result.Divergences[0].Resolved)
+	assert.Equal(t, 1, result.Unresolved())
+
+	requireRepaired(t, tx, result)
+}
+
+func TestRepairSmt_RefusesTruncatedResult(t *testing.T) {
+	tx, dbSmt := newVerifyTx(t)
+	_, err := dbSmt.InsertKA(verifyAccountKey(t, utils.KeyEthAddrBalance), big.NewInt(999))
+	require.NoError(t, err)
+	_, err = dbSmt.InsertKA(verifyStorageKey(t, verifySlot), big.NewInt(8))
+	require.NoError(t, err)
+
+	result, err := VerifySmt(context.Background(), "", tx, 1)
+	require.NoError(t, err)
+	require.Len(t, result.Divergences, 1)
+	require.True(t, result.Truncated)
+
+	_, err = RepairSmt("", tx, result)
+	require.ErrorContains(t, err, "raise --max-divergences")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ledgerwatch/erigon/eth/stagedsync"
 	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
 	"github.com/ledgerwatch/erigon/zk/contracts"
+	"github.com/ledgerwatch/erigon/zk/events"
 	"github.com/ledgerwatch/erigon/zk/hermez_db"
 	"github.com/ledgerwatch/erigon/zk/types"
 	"github.com/ledgerwatch/log/v3"
@@ -21,13 +22,15 @@ type L1SequencerSyncCfg struct {
 	db     kv.RwDB
 	zkCfg  *ethconfig.Zk
 	syncer IL1Syncer
+	events *events.Events
 }
 
-func StageL1SequencerSyncCfg(db kv.RwDB, zkCfg *ethconfig.Zk, sync IL1Syncer) L1SequencerSyncCfg {
+func StageL1SequencerSyncCfg(db kv.RwDB, zkCfg *ethconfig.Zk, sync IL1Syncer, events *events.Events) L1SequencerSyncCfg {
 	return L1SequencerSyncCfg{
 		db:     db,
 		zkCfg:  zkCfg,
 		syncer: sync,
+		events: events,
 	}
 }
 
@@ -104,6 +107,7 @@ Loop:
 					if err != nil {
 						return err
 					}
+					cfg.events.OnL1InfoTreeUpdate(latestUpdate)
 					found = true
 					infoTreeUpdates++
 					latestL1InfoTreeBlockNumber = l.BlockNumber
@@ -17,6 +17,7 @@ import (
 	"github.com/ledgerwatch/erigon/eth/stagedsync"
 	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
 	"github.com/ledgerwatch/erigon/zk/contracts"
+	"github.com/ledgerwatch/erigon/zk/events"
 	"github.com/ledgerwatch/erigon/zk/hermez_db"
 	"github.com/ledgerwatch/erigon/zk/sequencer"
 	"github.com/ledgerwatch/erigon/zk/types"
@@ -44,14 +45,16 @@ type L1SyncerCfg struct {
 	db     kv.RwDB
 	syncer IL1Syncer
 
-	zkCfg *ethconfig.Zk
+	zkCfg  *ethconfig.Zk
+	events *events.Events
 }
 
-func StageL1SyncerCfg(db kv.RwDB, syncer IL1Syncer, zkCfg *ethconfig.Zk) L1SyncerCfg {
+func StageL1SyncerCfg(db kv.RwDB, syncer IL1Syncer, zkCfg *ethconfig.Zk, events *events.Events) L1SyncerCfg {
 	return L1SyncerCfg{
 		db:     db,
 		syncer: syncer,
 		zkCfg:  zkCfg,
+		events: events,
 	}
 }
 
@@ -157,6 +160,7 @@ Loop:
 					if err := hermezDb.WriteSequence(info.L1BlockNo, info.BatchNo, info.L1TxHash, info.StateRoot); err != nil {
 						return fmt.Errorf("failed to write batch info, %w", err)
 					}
+					cfg.events.OnBatchVirtualized(info)
 					newSequencesCount++
 				case logVerify:
 					if info.BatchNo > highestVerification.BatchNo {
@@ -165,6 +169,7 @@ Loop:
 					if err := hermezDb.WriteVerification(info.L1BlockNo, info.BatchNo, info.L1TxHash, info.StateRoot); err != nil {
 						return fmt.Errorf("failed to write verification for block %d, %w", info.L1BlockNo, err)
 					}
+					cfg.events.OnBatchVerified(info)
 					newVerificationsCount++
 				case logL1InfoTreeUpdate:
 					if latestL1InfoTreeBlockNumber > 0 && l.BlockNumber <= latestL1InfoTreeBlockNumber {
@@ -175,6 +180,7 @@ Loop:
 					if err != nil {
 						return err
 					}
+					cfg.events.OnL1InfoTreeUpdate(latestL1InfoTreeUpdate)
 					found = true
 					infoTreeUpdates++
 					latestL1InfoTreeBlockNumber = l.BlockNumber
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/gateway-fm/cdk-erigon-lib/common"
 	"github.com/gateway-fm/cdk-erigon-lib/kv"
 	"github.com/ledgerwatch/log/v3"
 
@@ -46,6 +47,9 @@ func SpawnSequencingStage(
 	}
 
 	sdb := newStageDb(tx)
+	if cfg.counterEstimator != nil {
+		cfg.counterEstimator.setSmtDepth(sdb.smt.GetDepth())
+	}
 
 	executionAt, err := s.ExecutionAt(tx)
 	if err != nil {
@@ -117,6 +121,7 @@ func SpawnSequencingStage(
 	runLoopBlocks := true
 	lastStartedBn := executionAt - 1
 	yielded := mapset.NewSet[[32]byte]()
+	yieldedBundles := mapset.NewSet[common.Hash]()
 	coinbase := cfg.zk.AddressSequencer
 	workRemaining := true
 	decodedBlocksSize := uint64(0)
@@ -135,11 +140,33 @@ func SpawnSequencingStage(
 		}
 	}
 
+	// in HA mode a standby, or a leader that has just been promoted, replays the batches from the leader datastream
+	// using the same path as l1 recovery rather than sequencing from the pool
+	var leaderBlockHashes []common.Hash
+	leaderReplay := false
+	if !l1Recovery && cfg.ha != nil {
+		decodedBlocks, leaderBlockHashes, coinbase, leaderReplay, err = getNextLeaderBatchData(cfg, tx, thisBatch, executionAt)
+		if err != nil {
+			return err
+		}
+		decodedBlocksSize = uint64(len(decodedBlocks))
+		if leaderReplay && decodedBlocksSize == 0 {
+			// the leader has not closed the next batch yet
+			return nil
+		}
+	}
+	replay := l1Recovery || leaderReplay
+	// the lease term fences what we sequence, nothing is published if the lease changed hands in between
+	term := cfg.ha.term()
+
+	// whatever is left pending was either committed by the caller since the last run or abandoned with the batch
+	cfg.pending.Clear()
+
 	log.Info(fmt.Sprintf("[%s] Starting batch %d...", logPrefix, thisBatch))
 
 	var blockNumber uint64
 	for blockNumber = executionAt; runLoopBlocks; blockNumber++ {
-		if l1Recovery {
+		if replay {
 			decodedBlocksIndex := blockNumber - executionAt
 			if decodedBlocksIndex == decodedBlocksSize {
 				runLoopBlocks = false
@@ -165,6 +192,9 @@ func SpawnSequencingStage(
 			if err != nil {
 				return err
 			}
+			if !replay {
+				cfg.pending.StartBlock(header)
+			}
 		} else {
 			batchCounters = clonedBatchCounters
 
@@ -183,13 +213,13 @@ func SpawnSequencingStage(
 		if err != nil {
 			return err
 		}
-		if !l1Recovery && overflowOnNewBlock {
+		if !replay && overflowOnNewBlock {
 			break
 		}
 
 		thisBlockNumber := header.Number.Uint64()
 
-		infoTreeIndexProgress, l1TreeUpdate, l1TreeUpdateIndex, l1BlockHash, ger, shouldWriteGerToContract, err := prepareL1AndInfoTreeRelatedStuff(sdb, &decodedBlock, l1Recovery)
+		infoTreeIndexProgress, l1TreeUpdate, l1TreeUpdateIndex, l1BlockHash, ger, shouldWriteGerToContract, err := prepareL1AndInfoTreeRelatedStuff(sdb, &decodedBlock, replay)
 		if err != nil {
 			return err
 		}
@@ -213,7 +243,7 @@ func SpawnSequencingStage(
 
 		if !reRunBlockAfterOverflow {
 			// start waiting for a new transaction to arrive
-			if !l1Recovery {
+			if !replay {
 				log.Info(fmt.Sprintf("[%s] Waiting for txs from the pool...", logPrefix))
 			}
 
@@ -234,23 +264,66 @@ func SpawnSequencingStage(
 				case <-logTicker.C:
 					log.Info(fmt.Sprintf("[%s] Waiting some more for txs from the pool...", logPrefix))
 				case <-blockTicker.C:
-					if !l1Recovery {
+					if !replay {
 						break LOOP_TRANSACTIONS
 					}
 				case <-batchTicker.C:
-					if !l1Recovery {
+					if !replay {
 						runLoopBlocks = false
 						break LOOP_TRANSACTIONS
 					}
 				case <-nonEmptyBatchTimer.C:
-					if !l1Recovery && hasAnyTransactionsInThisBatch {
+					if !replay && hasAnyTransactionsInThisBatch {
 						runLoopBlocks = false
 						break LOOP_TRANSACTIONS
 					}
 				default:
-					if !l1Recovery {
+					if !replay {
+						if !cfg.ha.holdsTerm(term) {
+							return errLostLeadership
+						}
+
+						// bundles go ahead of the pool transactions, once sequenced a bundle stays in the pool until
+						// its transactions are mined in case this batch doesn't make it
+						if bundle, bundleTransactions := getNextBundle(cfg, header, yieldedBundles); bundle != nil {
+							var bundleReceipts []*types.Receipt
+							var bundleEffectiveGases []uint8
+							var outcome bundleOutcome
+							bundleReceipts, bundleEffectiveGases, batchCounters, outcome, err = attemptAddBundle(cfg, sdb, ibs, batchCounters, header, parentBlock.Header(), bundle.Hash, bundleTransactions)
+							if err != nil {
+								return err
+							}
+							switch {
+							case outcome == bundleAdded:
+								log.Info(fmt.Sprintf("[%s] added bundle to batch", logPrefix), "batch", thisBatch, "bundle-hash", bundle.Hash, "txs", len(bundleTransactions))
+								yieldedBundles.Add(bundle.Hash)
+								for i, transaction := range bundleTransactions {
+									addedTransactions = append(addedTransactions, transaction)
+									addedReceipts = append(addedReceipts, bundleReceipts[i])
+									effectiveGases = append(effectiveGases, bundleEffectiveGases[i])
+									if !cfg.txPool.IsPrivate(transaction.Hash()) {
+										cfg.pending.AddTransaction(transaction, bundleReceipts[i], bundleEffectiveGases[i])
+									}
+									yielded.Add(transaction.Hash())
+								}
+								hasAnyTransactionsInThisBatch = true
+								nonEmptyBatchTimer.Reset(cfg.zk.SequencerNonEmptyBatchSealTime)
+							case outcome == bundleOverflow && len(addedTransactions) > 0:
+								// it may fit in the next batch
+								log.Info(fmt.Sprintf("[%s] bundle overflowed the batch", logPrefix), "batch", thisBatch, "bundle-hash", bundle.Hash)
+								yieldedBundles.Add(bundle.Hash)
+							default:
+								log.Info(fmt.Sprintf("[%s] dropping bundle", logPrefix), "bundle-hash", bundle.Hash, "overflow", outcome == bundleOverflow)
+								cfg.txPool.RemoveBundle(bundle.Hash)
+							}
+						}
+
+						availableCounters, err := remainingCounters(cfg, batchCounters)
+						if err != nil {
+							return err
+						}
 						cfg.txPool.LockFlusher()
-						blockTransactions, err = getNextPoolTransactions(cfg, executionAt, forkId, yielded)
+						blockTransactions, err = getNextPoolTransactions(cfg, executionAt, forkId, availableCounters, yielded)
 						if err != nil {
 							return err
 						}
@@ -261,24 +334,24 @@ func SpawnSequencingStage(
 						var receipt *types.Receipt
 						var effectiveGas uint8
 
-						if l1Recovery {
+						if replay {
 							effectiveGas = effectiveGases[i]
 						} else {
 							effectiveGas = DeriveEffectiveGasPrice(cfg, transaction)
 							effectiveGases = append(effectiveGases, effectiveGas)
 						}
 
-						receipt, overflow, err = attemptAddTransaction(cfg, sdb, ibs, batchCounters, header, parentBlock.Header(), transaction, effectiveGas, l1Recovery)
+						receipt, overflow, err = attemptAddTransaction(cfg, sdb, ibs, batchCounters, header, parentBlock.Header(), transaction, effectiveGas, replay)
 						if err != nil {
 							// if we are in recovery just log the error as a warning.  If the data is on the L1 then we should consider it as confirmed.
 							// The executor/prover would simply skip a TX with an invalid nonce for example so we don't need to worry about that here.
-							if l1Recovery {
+							if replay {
 								log.Warn(fmt.Sprintf("[%s] error adding transaction to batch during recovery: %v", logPrefix, err))
 								continue
 							}
 							return err
 						}
-						if !l1Recovery && overflow {
+						if !replay && overflow {
 							log.Info(fmt.Sprintf("[%s] overflowed adding transaction to batch", logPrefix), "batch", thisBatch, "tx-hash", transaction.Hash(), "txs before overflow", len(addedTransactions))
 							/*
 								There are two cases when overflow could occur.
@@ -296,7 +369,7 @@ func SpawnSequencingStage(
 							} else {
 								txSize := len(blockTransactions)
 								for ; i < txSize; i++ {
-									yielded.Remove(transaction.Hash())
+									yielded.Remove(blockTransactions[i].Hash())
 								}
 							}
 
@@ -305,15 +378,19 @@ func SpawnSequencingStage(
 
 						addedTransactions = append(addedTransactions, transaction)
 						addedReceipts = append(addedReceipts, receipt)
+						// private transactions can't be seen until they are mined
+						if !replay && !cfg.txPool.IsPrivate(transaction.Hash()) {
+							cfg.pending.AddTransaction(transaction, receipt, effectiveGas)
+						}
 
 						hasAnyTransactionsInThisBatch = true
 						nonEmptyBatchTimer.Reset(cfg.zk.SequencerNonEmptyBatchSealTime)
 					}
 
-					if l1Recovery {
+					if replay {
 						// just go into the normal loop waiting for new transactions to signal that the recovery
 						// has finished as far as it can go
-						if len(blockTransactions) == 0 && !workRemaining {
+						if l1Recovery && len(blockTransactions) == 0 && !workRemaining {
 							log.Info(fmt.Sprintf("[%s] L1 recovery no more transactions to recover", logPrefix))
 						}
 
@@ -321,7 +398,7 @@ func SpawnSequencingStage(
 					}
 				}
 			}
-			if !l1Recovery && overflow {
+			if !replay && overflow {
 				blockNumber-- // in order to trigger reRunBlockAfterOverflow check
 				continue      // lets execute the same block again
 			}
@@ -349,6 +426,12 @@ func SpawnSequencingStage(
 			return err
 		}
 
+		if leaderReplay {
+			if err = checkReplayedBlockHash(tx, thisBlockNumber, leaderBlockHashes[blockNumber-executionAt]); err != nil {
+				return err
+			}
+		}
+
 		log.Info(fmt.Sprintf("[%s] Finish block %d with %d transactions...", logPrefix, thisBlockNumber, len(addedTransactions)))
 	}
 
@@ -365,19 +448,33 @@ func SpawnSequencingStage(
 
 	// if we do not have an executors in the zk config then we can populate the stream immediately with the latest
 	// batch information
-	if !cfg.zk.HasExecutors() {
-		srv := server.NewDataStreamServer(cfg.stream, cfg.chainConfig.ChainID.Uint64(), server.StandardOperationMode)
-		if err = server.WriteBlocksToStream(tx, sdb.hermezDb.HermezDbReader, srv, cfg.stream, executionAt+1, blockNumber, logPrefix); err != nil {
-			return err
+	publish := func() error {
+		if cfg.zk.HasExecutors() {
+			return nil
 		}
+		srv := server.NewDataStreamServer(cfg.stream, cfg.chainConfig.ChainID.Uint64(), server.StandardOperationMode)
+		return server.WriteBlocksToStream(tx, sdb.hermezDb.HermezDbReader, srv, cfg.stream, executionAt+1, blockNumber, logPrefix)
+	}
+	// never publish a batch we sequenced ourselves once another node may have taken over, the lease can't change hands
+	// while the batch is written
+	if replay {
+		err = publish()
+	} else {
+		err = cfg.ha.fenced(term, publish)
+	}
+	if err != nil {
+		return err
 	}
 
 	log.Info(fmt.Sprintf("[%s] Finish batch %d...", logPrefix, thisBatch))
+	cfg.events.OnBatchClosed(thisBatch, blockNumber)
 
 	if freshTx {
 		if err = tx.Commit(); err != nil {
 			return err
 		}
+		// the blocks can be read from the db now, they are no longer pending
+		cfg.pending.Clear()
 	}
 
 	return nil
@@ -234,7 +234,12 @@ func postBlockStateHandling(
 	ibs.PostExecuteStateSet(cfg.chainConfig, header.Number.Uint64(), &rootHash)
 
 	// store a reference to this block info root against the block number
-	return hermezDb.WriteBlockInfoRoot(header.Number.Uint64(), rootHash)
+	if err = hermezDb.WriteBlockInfoRoot(header.Number.Uint64(), rootHash); err != nil {
+		return err
+	}
+
+	// and what the tree was built from so its leaves can be proven
+	return hermezDb.WriteBlockInfoTree(header.Number.Uint64(), infoTree.Info())
 }
 
 func addSenders(
@@ -245,10 +250,14 @@ func addSenders(
 	finalHeader *types.Header,
 ) error {
 	signer := types.MakeSigner(cfg.chainConfig, newNum.Uint64())
-	cryptoContext := secp256k1.ContextForThread(1)
 	senders := make([]common.Address, 0, len(finalTransactions))
 	for _, transaction := range finalTransactions {
-		from, err := signer.SenderWithContext(cryptoContext, transaction)
+		// the transactions from the pool come with their sender already recovered
+		if from, ok := transaction.GetSender(); ok {
+			senders = append(senders, from)
+			continue
+		}
+		from, err := signer.SenderWithContext(secp256k1.ContextForThread(1), transaction)
 		if err != nil {
 			return err
 		}
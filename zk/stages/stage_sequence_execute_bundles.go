@@ -0,0 +1,120 @@
+package stages
+
+import (
+	"bytes"
+	"fmt"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/zk/txpool"
+)
+
+type bundleOutcome int
+
+const (
+	bundleAdded    bundleOutcome = iota
+	bundleOverflow               // the bundle doesn't fit in the counters left in the batch
+	bundleFailed                 // a transaction of the bundle can't be applied or reverted
+)
+
+// getNextBundle returns the next bundle from the pool that can go in the block along with its transactions.  A
+// bundle that can't be decoded is dropped from the pool.
+func getNextBundle(cfg SequenceBlockCfg, header *types.Header, toSkip mapset.Set[common.Hash]) (*txpool.Bundle, []types.Transaction) {
+	signer := types.MakeSigner(cfg.chainConfig, header.Number.Uint64())
+	for {
+		bundle := cfg.txPool.NextBundle(header.Number.Uint64(), toSkip)
+		if bundle == nil {
+			return nil, nil
+		}
+
+		transactions, err := decodeBundle(bundle, signer)
+		if err != nil {
+			log.Warn("[txpool] dropping bundle", "hash", bundle.Hash, "err", err)
+			cfg.txPool.RemoveBundle(bundle.Hash)
+			continue
+		}
+		return bundle, transactions
+	}
+}
+
+func decodeBundle(bundle *txpool.Bundle, signer *types.Signer) ([]types.Transaction, error) {
+	transactions := make([]types.Transaction, 0, len(bundle.Txs))
+	for _, txBytes := range bundle.Txs {
+		transaction, err := types.DecodeTransaction(rlp.NewStream(bytes.NewReader(txBytes), uint64(len(txBytes))))
+		if err != nil {
+			return nil, err
+		}
+		sender, err := transaction.Sender(*signer)
+		if err != nil {
+			return nil, err
+		}
+		transaction.SetSender(sender)
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
+// attemptAddBundle applies the transactions of a bundle one after the other.  If any of them can't be applied, reverts
+// or overflows the counters the state, the gas used, the counters and the effective gas prices written are put back
+// to where they were before the bundle and the batch counters to carry on with are returned.
+func attemptAddBundle(
+	cfg SequenceBlockCfg,
+	sdb *stageDb,
+	ibs *state.IntraBlockState,
+	batchCounters *vm.BatchCounterCollector,
+	header *types.Header,
+	parentHeader *types.Header,
+	bundleHash common.Hash,
+	transactions []types.Transaction,
+) ([]*types.Receipt, []uint8, *vm.BatchCounterCollector, bundleOutcome, error) {
+	rules := cfg.chainConfig.Rules(header.Number.Uint64(), header.Time)
+	if err := ibs.StartBundle(rules, noop); err != nil {
+		return nil, nil, batchCounters, bundleFailed, err
+	}
+
+	countersBeforeBundle := batchCounters.Clone()
+	gasUsedBeforeBundle := header.GasUsed
+
+	receipts := make([]*types.Receipt, 0, len(transactions))
+	effectiveGases := make([]uint8, 0, len(transactions))
+	for i, transaction := range transactions {
+		effectiveGas := DeriveEffectiveGasPrice(cfg, transaction)
+		receipt, overflow, err := attemptAddTransaction(cfg, sdb, ibs, batchCounters, header, parentHeader, transaction, effectiveGas, false)
+
+		outcome := bundleAdded
+		switch {
+		case err != nil:
+			log.Debug(fmt.Sprintf("bundle %s transaction %s can't be applied", bundleHash, transaction.Hash()), "err", err)
+			outcome = bundleFailed
+		case overflow:
+			outcome = bundleOverflow
+		case receipt.Status == types.ReceiptStatusFailed:
+			log.Debug(fmt.Sprintf("bundle %s transaction %s reverted", bundleHash, transaction.Hash()))
+			outcome = bundleFailed
+		}
+		if outcome != bundleAdded {
+			ibs.RevertBundle()
+			header.GasUsed = gasUsedBeforeBundle
+			applied := make([]common.Hash, 0, i+1)
+			for _, t := range transactions[:i+1] {
+				applied = append(applied, t.Hash())
+			}
+			if err := sdb.hermezDb.DeleteEffectiveGasPricePercentages(&applied); err != nil {
+				return nil, nil, countersBeforeBundle, bundleFailed, err
+			}
+			return nil, nil, countersBeforeBundle, outcome, nil
+		}
+
+		receipts = append(receipts, receipt)
+		effectiveGases = append(effectiveGases, effectiveGas)
+	}
+
+	ibs.EndBundle()
+	return receipts, effectiveGases, batchCounters, bundleAdded, nil
+}
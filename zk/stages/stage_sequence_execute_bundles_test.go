@@ -0,0 +1,179 @@
+package stages
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/memdb"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/consensus/ethash"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/eth/ethconfig"
+	"github.com/ledgerwatch/erigon/params"
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/zk/hermez_db"
+)
+
+var bundleRevertingContract = common.HexToAddress("0xcc")
+
+type bundleTest struct {
+	t             *testing.T
+	cfg           SequenceBlockCfg
+	sdb           *stageDb
+	ibs           *state.IntraBlockState
+	batchCounters *vm.BatchCounterCollector
+	header        *types.Header
+	parent        *types.Header
+	key           *ecdsa.PrivateKey
+	sender        common.Address
+	nonce         uint64
+}
+
+// newBundleTest is a sequencer starting the first block of a batch on top of a genesis where the sender has a balance
+// and a contract always reverts
+func newBundleTest(t *testing.T) *bundleTest {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+
+	db := memdb.NewTestDB(t)
+	_, _, err = core.CommitGenesisBlock(db, &types.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			sender:                  {Balance: big.NewInt(params.Ether)},
+			bundleRevertingContract: {Balance: new(big.Int), Code: common.FromHex("0x60006000fd")}, // revert(0, 0)
+		},
+	}, t.TempDir())
+	require.NoError(t, err)
+
+	tx, err := db.BeginRw(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(tx.Rollback)
+	require.NoError(t, hermez_db.CreateHermezBuckets(tx))
+	require.NoError(t, db2.CreateEriDbBuckets(tx))
+	sdb := newStageDb(tx)
+
+	parent := rawdb.ReadCurrentHeader(tx)
+	require.NotNil(t, parent)
+	batchCounters := vm.NewBatchCounterCollector(sdb.smt.GetDepth(), 7)
+	_, err = batchCounters.StartNewBlock()
+	require.NoError(t, err)
+
+	zkVmConfig := vm.NewZkConfig(vm.Config{}, nil)
+	return &bundleTest{
+		t: t,
+		cfg: SequenceBlockCfg{
+			chainConfig: params.TestChainConfig,
+			engine:      ethash.NewFaker(),
+			zkVmConfig:  &zkVmConfig,
+			zk:          &ethconfig.Zk{EffectiveGasPriceForEthTransfer: 255, EffectiveGasPriceForContractInvocation: 255},
+		},
+		sdb:           sdb,
+		ibs:           state.New(sdb.stateReader),
+		batchCounters: batchCounters,
+		header: &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     big.NewInt(1),
+			Difficulty: new(big.Int),
+			GasLimit:   transactionGasLimit,
+			Time:       parent.Time + 1,
+		},
+		parent: parent,
+		key:    key,
+		sender: sender,
+	}
+}
+
+func (b *bundleTest) sign(to common.Address, gas uint64, data []byte) types.Transaction {
+	txn, err := types.SignTx(types.NewTransaction(b.nonce, to, uint256.NewInt(0), gas, uint256.NewInt(1e9), data), *types.LatestSignerForChainID(params.TestChainConfig.ChainID), b.key)
+	require.NoError(b.t, err)
+	b.nonce++
+	txn.SetSender(b.sender)
+	return txn
+}
+
+func (b *bundleTest) add(transactions ...types.Transaction) ([]*types.Receipt, *vm.BatchCounterCollector, bundleOutcome) {
+	receipts, _, batchCounters, outcome, err := attemptAddBundle(b.cfg, b.sdb, b.ibs, b.batchCounters, b.header, b.parent, common.Hash{1}, transactions)
+	require.NoError(b.t, err)
+	return receipts, batchCounters, outcome
+}
+
+func (b *bundleTest) hasEffectiveGasPrice(txn types.Transaction) bool {
+	has, err := b.sdb.tx.Has(hermez_db.TX_PRICE_PERCENTAGE, txn.Hash().Bytes())
+	require.NoError(b.t, err)
+	return has
+}
+
+func (b *bundleTest) usedCounters(batchCounters *vm.BatchCounterCollector) map[string]int {
+	combined, err := batchCounters.CombineCollectors()
+	require.NoError(b.t, err)
+	return combined.UsedAsMap()
+}
+
+func TestAttemptAddBundle_Added(t *testing.T) {
+	b := newBundleTest(t)
+	first, second := b.sign(common.Address{1}, 21_000, nil), b.sign(common.Address{1}, 21_000, nil)
+
+	receipts, batchCounters, outcome := b.add(first, second)
+	require.Equal(t, bundleAdded, outcome)
+	require.Len(t, receipts, 2)
+	require.Same(t, b.batchCounters, batchCounters)
+	require.Equal(t, uint64(42_000), b.header.GasUsed)
+	require.Equal(t, uint64(2), b.ibs.GetNonce(b.sender))
+	require.True(t, b.hasEffectiveGasPrice(first))
+	require.True(t, b.hasEffectiveGasPrice(second))
+}
+
+func TestAttemptAddBundle_OverflowMidBundle(t *testing.T) {
+	b := newBundleTest(t)
+	before := b.usedCounters(b.batchCounters)
+
+	// the call data alone takes more keccaks than a batch has
+	first := b.sign(common.Address{1}, 21_000, nil)
+	tooLarge := b.sign(common.Address{1}, 2_000_000, make([]byte, 400_000))
+
+	receipts, batchCounters, outcome := b.add(first, tooLarge)
+	require.Equal(t, bundleOverflow, outcome)
+	require.Nil(t, receipts)
+
+	// the batch carries on as if the bundle was never tried
+	require.NotSame(t, b.batchCounters, batchCounters)
+	require.Equal(t, before, b.usedCounters(batchCounters))
+	require.Zero(t, b.header.GasUsed)
+	require.Zero(t, b.ibs.GetNonce(b.sender))
+	require.False(t, b.hasEffectiveGasPrice(first))
+
+	// and a transaction that fits still goes in with the counters handed back
+	b.batchCounters = batchCounters
+	b.nonce = 0
+	receipts, _, outcome = b.add(b.sign(common.Address{1}, 21_000, nil))
+	require.Equal(t, bundleAdded, outcome)
+	require.Len(t, receipts, 1)
+	require.Equal(t, uint64(21_000), b.header.GasUsed)
+}
+
+func TestAttemptAddBundle_Reverted(t *testing.T) {
+	b := newBundleTest(t)
+	before := b.usedCounters(b.batchCounters)
+
+	first := b.sign(common.Address{1}, 21_000, nil)
+	reverting := b.sign(bundleRevertingContract, 100_000, nil)
+
+	_, batchCounters, outcome := b.add(first, reverting)
+	require.Equal(t, bundleFailed, outcome)
+	require.Equal(t, before, b.usedCounters(batchCounters))
+	require.Zero(t, b.header.GasUsed)
+	require.Zero(t, b.ibs.GetNonce(b.sender))
+	require.False(t, b.hasEffectiveGasPrice(first))
+	require.False(t, b.hasEffectiveGasPrice(reverting))
+}
@@ -0,0 +1,124 @@
+package stages
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/ledgerwatch/erigon/zk/hermez_db"
+	"github.com/ledgerwatch/erigon/zk/txpool"
+)
+
+// txCounterEstimator pre-simulates pool transactions on top of the latest executed block so the pool can pack
+// batches by their virtual counters
+type txCounterEstimator struct {
+	cfg SequenceBlockCfg
+
+	// smtDepth is the depth of the tree the last batch was sequenced on, the counters of a tx depend on it
+	smtDepth atomic.Int32
+}
+
+func newTxCounterEstimator(cfg SequenceBlockCfg) *txCounterEstimator {
+	return &txCounterEstimator{cfg: cfg}
+}
+
+func (e *txCounterEstimator) setSmtDepth(depth int) {
+	e.smtDepth.Store(int32(depth))
+}
+
+func (e *txCounterEstimator) EstimateCounters(ctx context.Context, rlpTxs [][]byte) ([]*txpool.CounterEstimate, error) {
+	estimates := make([]*txpool.CounterEstimate, len(rlpTxs))
+
+	if err := e.cfg.db.View(ctx, func(tx kv.Tx) error {
+		executionAt, err := stages.GetStageProgress(tx, stages.Execution)
+		if err != nil {
+			return err
+		}
+		parent := rawdb.ReadHeaderByNumber(tx, executionAt)
+		if parent == nil {
+			return fmt.Errorf("header %d not found", executionAt)
+		}
+
+		hermezDb := hermez_db.NewHermezDbReader(tx)
+		batchNo, err := hermezDb.GetBatchNoByL2Block(executionAt)
+		if err != nil {
+			return err
+		}
+		forkId, err := hermezDb.GetForkId(batchNo)
+		if err != nil {
+			return err
+		}
+
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			Coinbase:   e.cfg.zk.AddressSequencer,
+			Difficulty: blockDifficulty,
+			Number:     new(big.Int).SetUint64(executionAt + 1),
+			GasLimit:   getGasLimit(uint16(forkId)),
+			Time:       parent.Time,
+		}
+		getHeader := func(hash common.Hash, number uint64) *types.Header { return rawdb.ReadHeader(tx, hash, number) }
+		blockHashFunc := core.GetHashFn(header, getHeader)
+
+		smtDepth := int(e.smtDepth.Load())
+
+		ibs := state.New(state.NewPlainStateReader(tx))
+		for i, rlpTx := range rlpTxs {
+			transaction, err := types.DecodeTransaction(rlp.NewStream(bytes.NewReader(rlpTx), uint64(len(rlpTx))))
+			if err != nil {
+				continue
+			}
+
+			ibs.Prepare(transaction.Hash(), common.Hash{}, i)
+			receipt, _, txCounters, err := core.ApplyTransactionWithCounters_zkevm(
+				e.cfg.chainConfig,
+				blockHashFunc,
+				e.cfg.engine,
+				&e.cfg.zk.AddressSequencer,
+				ibs,
+				header,
+				transaction,
+				*e.cfg.zkVmConfig, // a copy, the config of the sequencer collects the counters of the batch
+				parent.ExcessDataGas,
+				DeriveEffectiveGasPrice(e.cfg, transaction),
+				smtDepth)
+			if err != nil {
+				continue
+			}
+
+			estimates[i] = &txpool.CounterEstimate{
+				Counters: txCounters.UsedAsMap(),
+				GasUsed:  receipt.GasUsed,
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return estimates, nil
+}
+
+// remainingCounters is what the pool can still pack into the batch, nil when counter aware selection is off
+func remainingCounters(cfg SequenceBlockCfg, batchCounters *vm.BatchCounterCollector) (txpool.ZkCounters, error) {
+	if cfg.counterEstimator == nil {
+		return nil, nil
+	}
+	combined, err := batchCounters.CombineCollectors()
+	if err != nil {
+		return nil, err
+	}
+	return combined.RemainingAsMap(), nil
+}
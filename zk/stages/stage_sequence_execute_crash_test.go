@@ -0,0 +1,302 @@
+package stages
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/datadir"
+	"github.com/gateway-fm/cdk-erigon-lib/gointerfaces/remote"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/kvcache"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/memdb"
+	"github.com/gateway-fm/cdk-erigon-lib/txpool/txpoolcfg"
+	types2 "github.com/gateway-fm/cdk-erigon-lib/types"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/chain"
+	"github.com/ledgerwatch/erigon/consensus/ethash"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/eth/ethconfig"
+	"github.com/ledgerwatch/erigon/eth/stagedsync"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/ethdb/prune"
+	"github.com/ledgerwatch/erigon/params"
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/turbo/shards"
+	"github.com/ledgerwatch/erigon/zk/events"
+	"github.com/ledgerwatch/erigon/zk/hermez_db"
+	"github.com/ledgerwatch/erigon/zk/pending"
+	"github.com/ledgerwatch/erigon/zk/txpool"
+)
+
+// where the sequencer is killed: after the stage yielded from the pool and executed the batch but before the batch
+// is committed, between the commit and the pool hearing about the blocks, and before the pool flushes what it heard
+const (
+	crashBeforeCommit      = "before-commit"
+	crashAfterCommit       = "after-commit"
+	crashAfterPoolNotified = "after-pool-notified"
+)
+
+var crashPoints = []string{crashBeforeCommit, crashAfterCommit, crashAfterPoolNotified}
+
+const crashTestForkId = 7
+
+// crashTest runs the sequencing stage against a pool as the stage loop does: the batch is committed, the blocks are
+// sent to the pool through the accumulator and the pool flushes.  A crash throws the pool away with whatever it
+// hadn't flushed and loads a new one from its db.
+type crashTest struct {
+	t           *testing.T
+	ctx         context.Context
+	rng         *rand.Rand
+	db          kv.RwDB
+	poolDB      kv.RwDB
+	pool        *txpool.TxPool
+	chainConfig *chain.Config
+	accumulator *shards.Accumulator
+	cfg         SequenceBlockCfg
+
+	keys      []*ecdsa.PrivateKey
+	senders   []common.Address
+	nextNonce map[common.Address]uint64
+	submitted map[common.Hash]struct{}
+}
+
+// newCrashTest is a sequencer past its first batch, the empty block 1 standing in for the injected batch
+func newCrashTest(t *testing.T, seed int64, senders int) *crashTest {
+	chainConfig := *params.TestChainConfig
+	c := &crashTest{
+		t:           t,
+		ctx:         context.Background(),
+		rng:         rand.New(rand.NewSource(seed)),
+		db:          memdb.NewTestDB(t),
+		poolDB:      memdb.NewTestPoolDB(t),
+		chainConfig: &chainConfig,
+		accumulator: shards.NewAccumulator(),
+		nextNonce:   map[common.Address]uint64{},
+		submitted:   map[common.Hash]struct{}{},
+	}
+	alloc := types.GenesisAlloc{}
+	for i := 0; i < senders; i++ {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		c.keys = append(c.keys, key)
+		c.senders = append(c.senders, crypto.PubkeyToAddress(key.PublicKey))
+		alloc[c.senders[i]] = types.GenesisAccount{Balance: big.NewInt(params.Ether)}
+	}
+	genesis := &types.Genesis{Config: c.chainConfig, Alloc: alloc}
+	_, genesisBlock, err := core.CommitGenesisBlock(c.db, genesis, t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, c.db.Update(c.ctx, func(tx kv.RwTx) error {
+		if err := hermez_db.CreateHermezBuckets(tx); err != nil {
+			return err
+		}
+		if err := db2.CreateEriDbBuckets(tx); err != nil {
+			return err
+		}
+		block := types.NewBlock(&types.Header{
+			ParentHash: genesisBlock.Hash(),
+			Number:     big.NewInt(1),
+			Root:       genesisBlock.Root(),
+			Difficulty: new(big.Int),
+			GasLimit:   getGasLimit(crashTestForkId),
+			Time:       genesisBlock.Time() + 1,
+		}, nil, nil, nil, nil)
+		if err := rawdb.WriteBlock(tx, block); err != nil {
+			return err
+		}
+		if err := rawdb.WriteCanonicalHash(tx, block.Hash(), 1); err != nil {
+			return err
+		}
+		hermezDb := hermez_db.NewHermezDb(tx)
+		if err := hermezDb.WriteForkId(1, crashTestForkId); err != nil {
+			return err
+		}
+		if err := hermezDb.WriteForkIdBlockOnce(crashTestForkId, 1); err != nil {
+			return err
+		}
+		if err := hermezDb.WriteBlockBatch(1, 1); err != nil {
+			return err
+		}
+		return updateSequencerProgress(tx, 1, 1, 0)
+	}))
+
+	zkVmConfig := vm.NewZkConfig(vm.Config{}, nil)
+	zk := &ethconfig.Zk{
+		AddressSequencer:                       common.HexToAddress("0x5e"),
+		SequencerBlockSealTime:                 20 * time.Millisecond,
+		SequencerBatchSealTime:                 150 * time.Millisecond,
+		SequencerNonEmptyBatchSealTime:         60 * time.Millisecond,
+		EffectiveGasPriceForEthTransfer:        255,
+		EffectiveGasPriceForContractInvocation: 255,
+		// the batches are left for the executors to check, there is no datastream to publish them to
+		ExecutorUrls: []string{"executor"},
+	}
+	c.cfg = StageSequenceBlocksCfg(c.db, prune.DefaultMode, 0, nil, c.chainConfig, ethash.NewFaker(), &zkVmConfig, c.accumulator, false, false,
+		false, datadir.Dirs{}, nil, genesis, ethconfig.Sync{}, nil, nil, zk, nil, c.poolDB, nil, pending.NewStore(), events.NewEvents())
+	c.restart()
+	return c
+}
+
+// restart loads a new pool from the pool db, as the node does when it starts again
+func (c *crashTest) restart() {
+	ethCfg := &ethconfig.Config{Zk: &ethconfig.Zk{}}
+	pool, err := txpool.New(make(chan types2.Announcements, 100), c.db, txpoolcfg.DefaultConfig, ethCfg, kvcache.NewDummy(), *uint256.MustFromBig(c.chainConfig.ChainID), big.NewInt(0), big.NewInt(0))
+	require.NoError(c.t, err)
+	c.pool = pool
+	c.cfg.txPool = pool
+
+	head := c.head()
+	c.pool.ForceUpdateLatestBlock(head)
+	c.SendStateChanges(c.ctx, &remote.StateChangeBatch{BlockGasLimit: getGasLimit(crashTestForkId), ChangeBatch: []*remote.StateChange{{BlockHeight: head}}})
+}
+
+func (c *crashTest) head() (head uint64) {
+	require.NoError(c.t, c.db.View(c.ctx, func(tx kv.Tx) (err error) {
+		head, err = stages.GetStageProgress(tx, stages.Execution)
+		return err
+	}))
+	return head
+}
+
+// SendStateChanges hands the blocks the stage loop sends after a commit to the pool, as the pool fetcher does
+func (c *crashTest) SendStateChanges(ctx context.Context, batch *remote.StateChangeBatch) {
+	var minedTxs types2.TxSlots
+	parseCtx := types2.NewTxParseContext(*uint256.MustFromBig(c.chainConfig.ChainID))
+	for _, change := range batch.ChangeBatch {
+		for _, rlp := range change.Txs {
+			slot, sender := &types2.TxSlot{}, make([]byte, 20)
+			_, err := parseCtx.ParseTransaction(rlp, 0, slot, sender, false /* hasEnvelope */, nil)
+			require.NoError(c.t, err)
+			minedTxs.Append(slot, sender, false)
+		}
+	}
+	require.NoError(c.t, c.poolDB.View(ctx, func(tx kv.Tx) error {
+		return c.pool.OnNewBlock(ctx, batch, types2.TxSlots{}, minedTxs, tx)
+	}))
+}
+
+// submit sends the next transactions of some senders to the pool and flushes it, a transaction the pool never
+// flushed is lost with it and not what this is about
+func (c *crashTest) submit(n int) {
+	var slots types2.TxSlots
+	parseCtx := types2.NewTxParseContext(*uint256.MustFromBig(c.chainConfig.ChainID))
+	parseCtx.WithSender(false)
+	signer := types.LatestSignerForChainID(c.chainConfig.ChainID)
+	for i := 0; i < n; i++ {
+		k := c.rng.Intn(len(c.keys))
+		sender := c.senders[k]
+		txn, err := types.SignTx(types.NewTransaction(c.nextNonce[sender], common.HexToAddress("0xaa"), uint256.NewInt(1), 21_000, uint256.NewInt(1e9), nil), *signer, c.keys[k])
+		require.NoError(c.t, err)
+		c.nextNonce[sender]++
+		c.submitted[txn.Hash()] = struct{}{}
+
+		rlp, err := types.MarshalTransactionsBinary(types.Transactions{txn})
+		require.NoError(c.t, err)
+		slot := &types2.TxSlot{}
+		_, err = parseCtx.ParseTransaction(rlp[0], 0, slot, nil, false /* hasEnvelope */, nil)
+		require.NoError(c.t, err)
+		slots.Append(slot, sender[:], true)
+	}
+	require.NoError(c.t, c.poolDB.View(c.ctx, func(tx kv.Tx) error {
+		reasons, err := c.pool.AddLocalTxs(c.ctx, slots, tx)
+		for _, reason := range reasons {
+			require.Equal(c.t, txpool.Success, reason, reason.String())
+		}
+		return err
+	}))
+	require.NoError(c.t, c.pool.Flush(c.ctx, c.poolDB))
+}
+
+// sequenceBatch runs the stage for a batch, it returns false when the sequencer is killed at crashAt
+func (c *crashTest) sequenceBatch(crashAt string) bool {
+	tx, err := c.db.BeginRw(c.ctx)
+	require.NoError(c.t, err)
+	defer tx.Rollback()
+	stateVersion, err := rawdb.GetStateVersion(tx)
+	require.NoError(c.t, err)
+	c.accumulator.Reset(stateVersion)
+
+	s := &stagedsync.StageState{ID: stages.Execution}
+	require.NoError(c.t, SpawnSequencingStage(s, &stagedsync.Sync{}, tx, 0, c.ctx, c.cfg, false, true))
+	if crashAt == crashBeforeCommit {
+		return false
+	}
+
+	require.NoError(c.t, tx.Commit())
+	if crashAt == crashAfterCommit {
+		return false
+	}
+
+	c.accumulator.SendAndReset(c.ctx, c, 0, getGasLimit(crashTestForkId))
+	if crashAt == crashAfterPoolNotified {
+		return false
+	}
+	require.NoError(c.t, c.pool.Flush(c.ctx, c.poolDB))
+	return true
+}
+
+// check that every transaction submitted is either in the chain once or in the pool, never both
+func (c *crashTest) check() (mined int) {
+	inChain := map[common.Hash]uint64{}
+	require.NoError(c.t, c.db.View(c.ctx, func(tx kv.Tx) error {
+		head, err := stages.GetStageProgress(tx, stages.Execution)
+		if err != nil {
+			return err
+		}
+		for n := uint64(2); n <= head; n++ {
+			block, err := rawdb.ReadBlockByNumber(tx, n)
+			if err != nil {
+				return err
+			}
+			require.NotNil(c.t, block)
+			for _, txn := range block.Transactions() {
+				require.NotContains(c.t, inChain, txn.Hash(), "included twice, in blocks %d and %d", inChain[txn.Hash()], n)
+				inChain[txn.Hash()] = n
+			}
+		}
+		return nil
+	}))
+
+	for hash := range c.submitted {
+		_, mined := inChain[hash]
+		inPool := c.pool.TxStatus(hash).SubPool != 0
+		require.True(c.t, inPool != mined, "tx %x in pool %t, in chain %t", hash, inPool, mined)
+	}
+	pendingCount, baseFeeCount, queuedCount := c.pool.CountContent()
+	require.Equal(c.t, len(c.submitted)-len(inChain), pendingCount+baseFeeCount+queuedCount)
+	return len(inChain)
+}
+
+func TestSequencingStage_PoolSurvivesCrashes(t *testing.T) {
+	for seed := int64(1); seed <= 2; seed++ {
+		c := newCrashTest(t, seed, 4)
+		for round := 0; round < 15; round++ {
+			c.submit(c.rng.Intn(6))
+			crashAt := ""
+			if c.rng.Intn(2) == 0 {
+				crashAt = crashPoints[c.rng.Intn(len(crashPoints))]
+			}
+			if !c.sequenceBatch(crashAt) {
+				c.restart()
+			}
+			c.check()
+		}
+
+		// with no more crashes everything submitted ends up in the chain
+		for i := 0; i < 20 && c.check() < len(c.submitted); i++ {
+			require.True(t, c.sequenceBatch(""))
+		}
+		require.Equal(t, len(c.submitted), c.check())
+	}
+}
@@ -0,0 +1,282 @@
+package stages
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/zk/datastream/types"
+	"github.com/ledgerwatch/erigon/zk/hermez_db"
+	"github.com/ledgerwatch/erigon/zk/sequencer"
+	zktx "github.com/ledgerwatch/erigon/zk/tx"
+)
+
+const (
+	// how long a standby waits for new blocks from the leader stream in a single stage run
+	leaderStreamWait = 1 * time.Second
+)
+
+var errLostLeadership = errors.New("sequencer lease lost, abandoning the open batch")
+
+// SequencerHACfg holds the state for running the sequencer in active/standby mode.  A standby node replays the
+// batches found in the leader datastream through the same code path as l1 recovery so that once it is promoted it
+// continues from the last block in the stream without forking.
+type SequencerHACfg struct {
+	elector  *sequencer.Elector
+	follower *leaderFollower
+
+	// set once a promoted node has replayed everything left in the old leader stream
+	caughtUp bool
+}
+
+// StageSequencerHACfg follows leaderStream until a leader advertises its own datastream in the lease, dial connects to
+// that one
+func StageSequencerHACfg(elector *sequencer.Elector, leaderStream DatastreamClient, dial func(url string) DatastreamClient) *SequencerHACfg {
+	if elector == nil {
+		return nil
+	}
+	follower := newLeaderFollower(leaderStream)
+	follower.leaderUrl = elector.LeaderStreamUrl
+	follower.dial = dial
+	return &SequencerHACfg{
+		elector:  elector,
+		follower: follower,
+	}
+}
+
+// term is the lease term a batch sequenced from now on belongs to, zero outside of HA mode
+func (c *SequencerHACfg) term() uint64 {
+	if c == nil {
+		return 0
+	}
+	return c.elector.Term()
+}
+
+// holdsTerm is a cheap check, made for every transaction, that the node is still the leader of the term
+func (c *SequencerHACfg) holdsTerm(term uint64) bool {
+	return c == nil || (c.elector.IsLeader() && c.elector.Term() == term)
+}
+
+// fenced publishes a batch sequenced in term, with the lease backend holding the lease of the node in that term until
+// publish returns
+func (c *SequencerHACfg) fenced(term uint64, publish func() error) error {
+	if c == nil {
+		return publish()
+	}
+	if err := c.elector.Fenced(term, publish); err != nil {
+		if errors.Is(err, sequencer.ErrFenced) {
+			return fmt.Errorf("%w: %v", errLostLeadership, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// leaderFollower buffers the blocks read from the leader datastream until a whole batch is available
+type leaderFollower struct {
+	client  DatastreamClient
+	running *atomic.Bool
+	pending []types.FullL2Block
+	highest uint64
+
+	// the datastream advertised by the current leader and how to connect to it, the client above follows url
+	leaderUrl func() string
+	dial      func(url string) DatastreamClient
+	url       string
+}
+
+func newLeaderFollower(client DatastreamClient) *leaderFollower {
+	return &leaderFollower{client: client, running: &atomic.Bool{}}
+}
+
+// followLeader moves to the datastream of the current leader when it isn't the one followed, blocks already read from
+// the previous one are kept as the new leader started from them
+func (f *leaderFollower) followLeader() {
+	if f.leaderUrl == nil || f.dial == nil {
+		return
+	}
+	url := f.leaderUrl()
+	if url == "" || url == f.url {
+		return
+	}
+	log.Info("[sequencer-ha] following the datastream of the new leader", "url", url, "previous", f.url)
+	abandonStream(f.client, f.running)
+	f.client = f.dial(url)
+	f.running = &atomic.Bool{}
+	f.url = url
+}
+
+func (f *leaderFollower) ensureStreaming(fromBlock uint64) {
+	if f.running.Load() {
+		return
+	}
+	f.running.Store(true)
+	client, running := f.client, f.running
+	go func() {
+		defer running.Store(false)
+		if err := client.ReadAllEntriesToChannel(types.NewL2BlockBookmark(fromBlock)); err != nil {
+			log.Warn("[sequencer-ha] leader datastream stopped", "err", err)
+		}
+	}()
+}
+
+// abandonStream drains a datastream no longer followed until its reader gives up, so the reader isn't left blocked
+// on a channel nobody reads
+func abandonStream(client DatastreamClient, running *atomic.Bool) {
+	go func() {
+		for running.Load() {
+			select {
+			case <-client.GetL2BlockChan():
+			case <-client.GetErrChan():
+			case <-time.After(leaderStreamWait):
+			}
+		}
+	}()
+}
+
+// collect drains the blocks currently available from the leader stream, waiting at most leaderStreamWait for new ones
+func (f *leaderFollower) collect(executionAt uint64) {
+	if f.highest < executionAt {
+		f.highest = executionAt
+	}
+	f.followLeader()
+	f.ensureStreaming(f.highest)
+
+	timer := time.NewTimer(leaderStreamWait)
+	defer timer.Stop()
+
+	for {
+		select {
+		case block := <-f.client.GetL2BlockChan():
+			if block.L2BlockNumber <= f.highest {
+				continue
+			}
+			f.pending = append(f.pending, block)
+			f.highest = block.L2BlockNumber
+		case err := <-f.client.GetErrChan():
+			if err != nil {
+				log.Warn("[sequencer-ha] error reading the leader datastream", "err", err)
+			}
+			return
+		case <-timer.C:
+			return
+		}
+	}
+}
+
+// nextBatch returns the blocks for batchNumber once the leader has closed it.  When final is set the batch is returned
+// even if it is still open, this is used after a promotion to take over whatever the old leader had streamed.
+func (f *leaderFollower) nextBatch(tx kv.Tx, batchNumber, executionAt uint64, final bool) ([]zktx.DecodedBatchL2Data, []common.Hash, common.Address, error) {
+	f.collect(executionAt)
+
+	// drop anything we have already executed, e.g. after an unwind or a previous partial read
+	start := 0
+	for start < len(f.pending) && f.pending[start].L2BlockNumber <= executionAt {
+		start++
+	}
+	f.pending = f.pending[start:]
+
+	if len(f.pending) == 0 {
+		return nil, nil, common.Address{}, nil
+	}
+
+	first := f.pending[0]
+	if first.L2BlockNumber != executionAt+1 {
+		return nil, nil, common.Address{}, fmt.Errorf("leader stream is not sequential, expected block %d, got %d", executionAt+1, first.L2BlockNumber)
+	}
+	if first.BatchNumber != batchNumber {
+		return nil, nil, common.Address{}, fmt.Errorf("leader stream is at batch %d but the next local batch is %d", first.BatchNumber, batchNumber)
+	}
+
+	end := 0
+	for end < len(f.pending) && f.pending[end].BatchNumber == batchNumber {
+		end++
+	}
+	if end == len(f.pending) && !final {
+		// the leader may still be adding blocks to this batch
+		return nil, nil, common.Address{}, nil
+	}
+
+	// the l1 info tree updates referenced by the leader have to be synced locally before we can replay the batch
+	hermezDb := hermez_db.NewHermezDbReader(tx)
+	for _, block := range f.pending[:end] {
+		if block.L1InfoTreeIndex == 0 {
+			continue
+		}
+		update, err := hermezDb.GetL1InfoTreeUpdate(uint64(block.L1InfoTreeIndex))
+		if err != nil {
+			return nil, nil, common.Address{}, err
+		}
+		if update == nil {
+			log.Info("[sequencer-ha] waiting for l1 info tree update used by the leader", "index", block.L1InfoTreeIndex)
+			return nil, nil, common.Address{}, nil
+		}
+	}
+
+	decoded := make([]zktx.DecodedBatchL2Data, 0, end)
+	hashes := make([]common.Hash, 0, end)
+	for _, block := range f.pending[:end] {
+		data := zktx.DecodedBatchL2Data{
+			DeltaTimestamp:  block.DeltaTimestamp,
+			L1InfoTreeIndex: block.L1InfoTreeIndex,
+		}
+		for _, l2Tx := range block.L2Txs {
+			transaction, _, err := zktx.DecodeTx(l2Tx.Encoded, l2Tx.EffectiveGasPricePercentage, block.ForkId)
+			if err != nil {
+				return nil, nil, common.Address{}, fmt.Errorf("decode leader transaction in block %d: %w", block.L2BlockNumber, err)
+			}
+			data.Transactions = append(data.Transactions, transaction)
+			data.EffectiveGasPricePercentages = append(data.EffectiveGasPricePercentages, l2Tx.EffectiveGasPricePercentage)
+		}
+		decoded = append(decoded, data)
+		hashes = append(hashes, block.L2Blockhash)
+	}
+
+	return decoded, hashes, first.Coinbase, nil
+}
+
+// getNextLeaderBatchData decides what a node running in HA mode should do for the next batch.  A standby returns the
+// next closed batch from the leader stream, a freshly promoted leader drains the remaining stream blocks first.  An
+// empty result with replay set to false means the node can sequence new blocks itself.
+func getNextLeaderBatchData(cfg SequenceBlockCfg, tx kv.Tx, batchNumber, executionAt uint64) (decoded []zktx.DecodedBatchL2Data, hashes []common.Hash, coinbase common.Address, replay bool, err error) {
+	ha := cfg.ha
+	if ha.elector.IsLeader() {
+		if ha.caughtUp {
+			return nil, nil, common.Address{}, false, nil
+		}
+		decoded, hashes, coinbase, err = ha.follower.nextBatch(tx, batchNumber, executionAt, true)
+		if err != nil {
+			return nil, nil, common.Address{}, false, err
+		}
+		if len(decoded) == 0 {
+			log.Info("[sequencer-ha] caught up with the previous leader, sequencing new blocks", "block", executionAt, "term", ha.elector.Term())
+			ha.caughtUp = true
+			return nil, nil, common.Address{}, false, nil
+		}
+		return decoded, hashes, coinbase, true, nil
+	}
+
+	ha.caughtUp = false
+	decoded, hashes, coinbase, err = ha.follower.nextBatch(tx, batchNumber, executionAt, false)
+	return decoded, hashes, coinbase, true, err
+}
+
+func checkReplayedBlockHash(tx kv.Tx, blockNumber uint64, expected common.Hash) error {
+	if expected == (common.Hash{}) {
+		return nil
+	}
+	actual, err := rawdb.ReadCanonicalHash(tx, blockNumber)
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("replayed block %d hash %s does not match the leader hash %s", blockNumber, actual, expected)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package stages
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/zk/datastream/types"
+	"github.com/ledgerwatch/erigon/zk/sequencer"
+)
+
+// unreachableBackend stops renewing the lease of its node, as if the node was cut off from the lease store
+type unreachableBackend struct {
+	sequencer.LeaseBackend
+	down atomic.Bool
+}
+
+func (b *unreachableBackend) TryAcquire(nodeId, streamUrl string, ttl time.Duration, now time.Time) (sequencer.Lease, error) {
+	if b.down.Load() {
+		return sequencer.Lease{}, errors.New("lease backend unreachable")
+	}
+	return b.LeaseBackend.TryAcquire(nodeId, streamUrl, ttl, now)
+}
+
+func published() error { return nil }
+
+func leaderStreamBlocks() []types.FullL2Block {
+	blocks := []types.FullL2Block{
+		{BatchNumber: 1, L2BlockNumber: 1, Coinbase: common.Address{1}},
+		{BatchNumber: 1, L2BlockNumber: 2, Coinbase: common.Address{1}},
+		{BatchNumber: 2, L2BlockNumber: 3, Coinbase: common.Address{1}},
+	}
+	for i := range blocks {
+		blocks[i].L2Blockhash = common.Hash{byte(blocks[i].L2BlockNumber)}
+	}
+	return blocks
+}
+
+func TestLeaderFollower_ReplaysClosedBatches(t *testing.T) {
+	tx, err := memdb.NewTestDB(t).BeginRo(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	follower := newLeaderFollower(NewTestDatastreamClient(leaderStreamBlocks(), nil))
+
+	decoded, hashes, coinbase, err := follower.nextBatch(tx, 1, 0, false)
+	require.NoError(t, err)
+	require.Len(t, decoded, 2)
+	require.Equal(t, []common.Hash{{1}, {2}}, hashes)
+	require.Equal(t, common.Address{1}, coinbase)
+
+	// the leader may still add blocks to batch 2
+	decoded, _, _, err = follower.nextBatch(tx, 2, 2, false)
+	require.NoError(t, err)
+	require.Empty(t, decoded)
+
+	decoded, hashes, _, err = follower.nextBatch(tx, 2, 2, true)
+	require.NoError(t, err)
+	require.Len(t, decoded, 1)
+	require.Equal(t, []common.Hash{{3}}, hashes)
+
+	_, _, _, err = follower.nextBatch(tx, 3, 1, true)
+	require.ErrorContains(t, err, "not sequential")
+}
+
+func TestLeaderFollower_FollowsTheNewLeader(t *testing.T) {
+	tx, err := memdb.NewTestDB(t).BeginRo(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	// b took over the batch a left open and closed it
+	newLeaderBlocks := append(leaderStreamBlocks(),
+		types.FullL2Block{BatchNumber: 2, L2BlockNumber: 4, L2Blockhash: common.Hash{4}},
+		types.FullL2Block{BatchNumber: 3, L2BlockNumber: 5, L2Blockhash: common.Hash{5}},
+	)
+	var dialed []string
+	leaderUrl := ""
+	follower := newLeaderFollower(NewTestDatastreamClient(leaderStreamBlocks(), nil))
+	follower.leaderUrl = func() string { return leaderUrl }
+	follower.dial = func(url string) DatastreamClient {
+		dialed = append(dialed, url)
+		return NewTestDatastreamClient(newLeaderBlocks, nil)
+	}
+
+	decoded, _, _, err := follower.nextBatch(tx, 1, 0, false)
+	require.NoError(t, err)
+	require.Len(t, decoded, 2)
+	decoded, _, _, err = follower.nextBatch(tx, 2, 2, false)
+	require.NoError(t, err)
+	require.Empty(t, decoded)
+
+	leaderUrl = "b:6900"
+	decoded, hashes, _, err := follower.nextBatch(tx, 2, 2, false)
+	require.NoError(t, err)
+	require.Len(t, decoded, 2)
+	require.Equal(t, []common.Hash{{3}, {4}}, hashes)
+
+	// the stream is only dialed again when the leader changes
+	_, _, _, err = follower.nextBatch(tx, 3, 4, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b:6900"}, dialed)
+}
+
+func TestSequencerHA_PromotionAfterLeaseLost(t *testing.T) {
+	tx, err := memdb.NewTestDB(t).BeginRo(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	path := filepath.Join(t.TempDir(), "lease")
+	ttl := 300 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leaderBackend := &unreachableBackend{LeaseBackend: sequencer.NewFileLeaseBackend(path)}
+	leader := StageSequencerHACfg(sequencer.NewElector(leaderBackend, "a", "", ttl), nil, nil)
+	leader.elector.Start(ctx)
+	require.Eventually(t, leader.elector.IsLeader, time.Second, 10*time.Millisecond)
+	term := leader.term()
+	require.NoError(t, leader.fenced(term, published))
+
+	standby := StageSequencerHACfg(sequencer.NewElector(sequencer.NewFileLeaseBackend(path), "b", "", ttl), NewTestDatastreamClient(leaderStreamBlocks(), nil), nil)
+	standby.elector.Start(ctx)
+	cfg := SequenceBlockCfg{ha: standby}
+
+	// the standby replays the closed batch and waits on the open one
+	decoded, _, _, replay, err := getNextLeaderBatchData(cfg, tx, 1, 0)
+	require.NoError(t, err)
+	require.True(t, replay)
+	require.Len(t, decoded, 2)
+	decoded, _, _, replay, err = getNextLeaderBatchData(cfg, tx, 2, 2)
+	require.NoError(t, err)
+	require.True(t, replay)
+	require.Empty(t, decoded)
+
+	// the leader can't renew its lease any more so the standby takes over once it expires
+	leaderBackend.down.Store(true)
+	require.Eventually(t, standby.elector.IsLeader, 5*ttl, 10*time.Millisecond)
+	require.Greater(t, standby.term(), term)
+
+	// whatever the old leader sequenced in its term can no longer be published
+	require.ErrorIs(t, leader.fenced(term, published), errLostLeadership)
+
+	// the promoted node takes over the batch the old leader left open, then sequences from the pool
+	decoded, _, _, replay, err = getNextLeaderBatchData(cfg, tx, 2, 2)
+	require.NoError(t, err)
+	require.True(t, replay)
+	require.Len(t, decoded, 1)
+	decoded, _, _, replay, err = getNextLeaderBatchData(cfg, tx, 3, 3)
+	require.NoError(t, err)
+	require.False(t, replay)
+	require.Empty(t, decoded)
+	require.NoError(t, standby.fenced(standby.term(), published))
+}
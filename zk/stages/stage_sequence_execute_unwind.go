@@ -193,6 +193,12 @@ func unwindExecutionStage(u *stagedsync.UnwindState, s *stagedsync.StageState, t
 	if err = hermezDb.TruncateLatestUsedGers(fromBatch); err != nil {
 		return fmt.Errorf("truncate latest used gers error: %v", err)
 	}
+	if err = hermezDb.DeleteBlockInfoRoots(u.UnwindPoint+1, s.BlockNumber); err != nil {
+		return fmt.Errorf("delete block info roots error: %v", err)
+	}
+	if err = hermezDb.DeleteBlockInfoTrees(u.UnwindPoint+1, s.BlockNumber); err != nil {
+		return fmt.Errorf("delete block info trees error: %v", err)
+	}
 
 	return nil
 }
@@ -29,7 +29,9 @@ import (
 	smtNs "github.com/ledgerwatch/erigon/smt/pkg/smt"
 	"github.com/ledgerwatch/erigon/turbo/services"
 	"github.com/ledgerwatch/erigon/turbo/shards"
+	"github.com/ledgerwatch/erigon/zk/events"
 	"github.com/ledgerwatch/erigon/zk/hermez_db"
+	"github.com/ledgerwatch/erigon/zk/pending"
 	zktx "github.com/ledgerwatch/erigon/zk/tx"
 	"github.com/ledgerwatch/erigon/zk/txpool"
 	zktypes "github.com/ledgerwatch/erigon/zk/types"
@@ -81,6 +83,13 @@ type SequenceBlockCfg struct {
 
 	txPool   *txpool.TxPool
 	txPoolDb kv.RwDB
+
+	ha *SequencerHACfg
+
+	pending *pending.Store
+	events  *events.Events
+
+	counterEstimator *txCounterEstimator // nil unless counter aware selection is on
 }
 
 func StageSequenceBlocksCfg(
@@ -106,8 +115,12 @@ func StageSequenceBlocksCfg(
 
 	txPool *txpool.TxPool,
 	txPoolDb kv.RwDB,
+
+	ha *SequencerHACfg,
+	pending *pending.Store,
+	events *events.Events,
 ) SequenceBlockCfg {
-	return SequenceBlockCfg{
+	cfg := SequenceBlockCfg{
 		db:            db,
 		prune:         pm,
 		batchSize:     batchSize,
@@ -128,7 +141,17 @@ func StageSequenceBlocksCfg(
 		zk:            zk,
 		txPool:        txPool,
 		txPoolDb:      txPoolDb,
+		ha:            ha,
+		pending:       pending,
+		events:        events,
 	}
+
+	if zk.SequencerCounterAwareSelection && !zk.ShouldCountersBeUnlimited() {
+		cfg.counterEstimator = newTxCounterEstimator(cfg)
+		txPool.SetCounterEstimator(cfg.counterEstimator)
+	}
+
+	return cfg
 }
 
 type stageDb struct {
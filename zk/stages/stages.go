@@ -347,8 +347,7 @@ func DefaultZkStages(
 				return UnwindZkIntermediateHashesStage(u, s, tx, zkInterHashesCfg, ctx)
 			},
 			Prune: func(firstCycle bool, p *stages.PruneState, tx kv.RwTx) error {
-				// TODO: implement this in zk interhashes
-				return nil
+				return PruneZkIntermediateHashesStage(p, tx, zkInterHashesCfg, ctx)
 			},
 		},
 		{
@@ -466,6 +465,10 @@ var AllStagesZk = []stages2.SyncStage{
 	stages2.Finish,
 }
 
+var ZkPruneOrder = stages.PruneOrder{
+	stages2.IntermediateHashes,
+}
+
 var ZkSequencerUnwindOrder = stages.UnwindOrder{
 	stages2.IntermediateHashes, // need to unwind SMT before we remove history
 	stages2.Execution,
@@ -130,25 +130,5 @@ func RpcGetHighestTxNo(rpcEndpoint string) (uint64, error) {
 }
 
 func DeriveEffectiveGasPrice(cfg SequenceBlockCfg, tx types.Transaction) uint8 {
-	if tx.GetTo() == nil {
-		return cfg.zk.EffectiveGasPriceForContractDeployment
-	}
-
-	data := tx.GetData()
-	dataLen := len(data)
-	if dataLen != 0 {
-		if dataLen >= 8 {
-			// transfer's method id 0xa9059cbb
-			isTransfer := data[0] == 169 && data[1] == 5 && data[2] == 156 && data[3] == 187
-			// transfer's method id 0x23b872dd
-			isTransferFrom := data[0] == 35 && data[1] == 184 && data[2] == 114 && data[3] == 221
-			if isTransfer || isTransferFrom {
-				return cfg.zk.EffectiveGasPriceForErc20Transfer
-			}
-		}
-
-		return cfg.zk.EffectiveGasPriceForContractInvocation
-	}
-
-	return cfg.zk.EffectiveGasPriceForEthTransfer
+	return cfg.zk.EffectiveGasPricePercentage(tx.GetTo(), tx.GetData())
 }
@@ -0,0 +1,202 @@
+package state_snapshot
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// chunkWriter writes table records into numbered chunk files, starting a new file once the uncompressed size of the
+// current one reaches the limit
+type chunkWriter struct {
+	dir   string
+	limit int
+
+	file    *os.File
+	hasher  hash.Hash
+	counter *countWriter
+	gz      *gzip.Writer
+	written int
+
+	chunks []Chunk
+	buf    [binary.MaxVarintLen64]byte
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func newChunkWriter(dir string, limit int) *chunkWriter {
+	return &chunkWriter{dir: dir, limit: limit}
+}
+
+func (w *chunkWriter) Write(table int, k, v []byte) error {
+	if w.gz != nil && w.written >= w.limit {
+		if err := w.closeChunk(); err != nil {
+			return err
+		}
+	}
+	if w.gz == nil {
+		if err := w.openChunk(); err != nil {
+			return err
+		}
+	}
+
+	if err := w.writeUvarint(uint64(table)); err != nil {
+		return err
+	}
+	for _, part := range [][]byte{k, v} {
+		if err := w.writeUvarint(uint64(len(part))); err != nil {
+			return err
+		}
+		n, err := w.gz.Write(part)
+		w.written += n
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *chunkWriter) writeUvarint(x uint64) error {
+	n := binary.PutUvarint(w.buf[:], x)
+	written, err := w.gz.Write(w.buf[:n])
+	w.written += written
+	return err
+}
+
+func (w *chunkWriter) openChunk() error {
+	name := fmt.Sprintf("chunk-%06d.gz", len(w.chunks))
+	file, err := os.Create(filepath.Join(w.dir, name))
+	if err != nil {
+		return err
+	}
+
+	w.file = file
+	w.hasher = sha256.New()
+	w.counter = &countWriter{w: io.MultiWriter(file, w.hasher)}
+	w.gz = gzip.NewWriter(w.counter)
+	w.written = 0
+	w.chunks = append(w.chunks, Chunk{Name: name})
+
+	return nil
+}
+
+func (w *chunkWriter) closeChunk() error {
+	if err := w.gz.Close(); err != nil {
+		return err
+	}
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	chunk := &w.chunks[len(w.chunks)-1]
+	chunk.Size = w.counter.n
+	chunk.Sha256 = hex.EncodeToString(w.hasher.Sum(nil))
+
+	w.gz = nil
+	w.file = nil
+
+	return nil
+}
+
+// Close finishes the current chunk and returns every chunk written
+func (w *chunkWriter) Close() ([]Chunk, error) {
+	if w.gz != nil {
+		if err := w.closeChunk(); err != nil {
+			return nil, err
+		}
+	}
+	return w.chunks, nil
+}
+
+// readChunk streams the records of a chunk into fn and fails if the file doesn't match the size and checksum from the
+// manifest. Records are handed over before the checksum is known so the caller must discard its work on error.
+func readChunk(source Source, chunk Chunk, fn func(table int, k, v []byte) error) error {
+	rc, err := source.Open(chunk.Name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	hasher := sha256.New()
+	counter := &countWriter{w: hasher}
+	tee := io.TeeReader(rc, counter)
+
+	gz, err := gzip.NewReader(tee)
+	if err != nil {
+		return fmt.Errorf("chunk %s: %w", chunk.Name, err)
+	}
+	defer gz.Close()
+
+	r := bufio.NewReader(gz)
+	for {
+		table, err := binary.ReadUvarint(r)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("chunk %s: %w", chunk.Name, err)
+		}
+		k, err := readPart(r)
+		if err != nil {
+			return fmt.Errorf("chunk %s: %w", chunk.Name, err)
+		}
+		v, err := readPart(r)
+		if err != nil {
+			return fmt.Errorf("chunk %s: %w", chunk.Name, err)
+		}
+		if err = fn(int(table), k, v); err != nil {
+			return err
+		}
+	}
+
+	// include anything after the gzip stream in the checksum
+	if _, err = io.Copy(io.Discard, tee); err != nil {
+		return fmt.Errorf("chunk %s: %w", chunk.Name, err)
+	}
+
+	if counter.n != chunk.Size {
+		return fmt.Errorf("chunk %s: size %d does not match manifest size %d", chunk.Name, counter.n, chunk.Size)
+	}
+	if sum := hex.EncodeToString(hasher.Sum(nil)); sum != chunk.Sha256 {
+		return fmt.Errorf("chunk %s: checksum %s does not match manifest checksum %s", chunk.Name, sum, chunk.Sha256)
+	}
+
+	return nil
+}
+
+func readPart(r *bufio.Reader) ([]byte, error) {
+	l, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	part := make([]byte, l)
+	if _, err = io.ReadFull(r, part); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return part, nil
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,248 @@
+package state_snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/memdb"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/smt"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+	"github.com/ledgerwatch/erigon/zk/hermez_db"
+)
+
+type record struct {
+	table int
+	k, v  []byte
+}
+
+func writeRecords(t *testing.T, dir string, limit, count int) ([]Chunk, []record) {
+	w := newChunkWriter(dir, limit)
+	records := make([]record, 0, count)
+	for i := 0; i < count; i++ {
+		r := record{
+			table: i % len(Tables),
+			k:     []byte(fmt.Sprintf("key-%d", i)),
+			v:     bytes.Repeat([]byte{byte(i)}, i%50),
+		}
+		require.NoError(t, w.Write(r.table, r.k, r.v))
+		records = append(records, r)
+	}
+	chunks, err := w.Close()
+	require.NoError(t, err)
+	return chunks, records
+}
+
+func readRecords(source Source, chunks []Chunk) ([]record, error) {
+	var records []record
+	for _, chunk := range chunks {
+		if err := readChunk(source, chunk, func(table int, k, v []byte) error {
+			records = append(records, record{table: table, k: k, v: v})
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+func TestChunkRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	chunks, written := writeRecords(t, dir, 1024, 1000)
+	assert.Greater(t, len(chunks), 1)
+
+	read, err := readRecords(DirSource(dir), chunks)
+	require.NoError(t, err)
+	require.Equal(t, len(written), len(read))
+	for i := range written {
+		assert.Equal(t, written[i].table, read[i].table)
+		assert.Equal(t, written[i].k, read[i].k)
+		assert.Equal(t, len(written[i].v), len(read[i].v))
+		assert.True(t, bytes.Equal(written[i].v, read[i].v))
+	}
+}
+
+const snapshotBlock = 5
+
+// newSyncedDb returns a db at snapshotBlock with a few accounts in its plain state and SMT
+func newSyncedDb(t *testing.T) kv.RwDB {
+	db := memdb.NewTestDB(t)
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		require.NoError(t, db2.CreateEriDbBuckets(tx))
+		require.NoError(t, hermez_db.CreateHermezBuckets(tx))
+
+		eridb := db2.NewEriDb(tx)
+		tree := smt.NewSMT(eridb)
+		w := state.NewPlainStateWriterNoHistory(tx)
+		var root *big.Int
+		for i := 1; i <= 3; i++ {
+			addr := libcommon.BigToAddress(big.NewInt(int64(i)))
+			acc := accounts.NewAccount()
+			acc.Balance = *uint256.NewInt(uint64(i) * 1e18)
+			acc.Nonce = uint64(i)
+			require.NoError(t, w.UpdateAccountData(addr, &accounts.Account{}, &acc))
+
+			var err error
+			root, err = tree.SetAccountState(addr.Hex(), acc.Balance.ToBig(), new(big.Int).SetUint64(acc.Nonce))
+			require.NoError(t, err)
+		}
+		require.NoError(t, eridb.SetLastRoot(root))
+
+		block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(snapshotBlock), Root: libcommon.BigToHash(root), Difficulty: new(big.Int)})
+		require.NoError(t, rawdb.WriteBlock(tx, block))
+		require.NoError(t, rawdb.WriteCanonicalHash(tx, block.Hash(), snapshotBlock))
+		require.NoError(t, stages.SaveStageProgress(tx, stages.Execution, snapshotBlock))
+		require.NoError(t, stages.SaveStageProgress(tx, stages.IntermediateHashes, snapshotBlock))
+
+		hermezDb := hermez_db.NewHermezDb(tx)
+		require.NoError(t, hermezDb.WriteBlockBatch(snapshotBlock, 2))
+		require.NoError(t, hermezDb.WriteForkId(2, 7))
+		return nil
+	}))
+	return db
+}
+
+// newEmptyDb returns a db initialised with a genesis block and nothing else, as a node leaves it after its first start
+func newEmptyDb(t *testing.T) kv.RwDB {
+	db := memdb.NewTestDB(t)
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		return rawdb.WriteCanonicalHash(tx, libcommon.Hash{1}, 0)
+	}))
+	return db
+}
+
+func exportSnapshot(t *testing.T, db kv.RwDB, dir string) *Manifest {
+	var manifest *Manifest
+	require.NoError(t, db.View(context.Background(), func(tx kv.Tx) (err error) {
+		manifest, err = Export(context.Background(), tx, dir, 1024)
+		return err
+	}))
+	return manifest
+}
+
+func requireUntouched(t *testing.T, db kv.RwDB) {
+	require.NoError(t, db.View(context.Background(), func(tx kv.Tx) error {
+		executed, err := stages.GetStageProgress(tx, stages.Execution)
+		require.NoError(t, err)
+		assert.Zero(t, executed)
+		hash, err := rawdb.ReadCanonicalHash(tx, snapshotBlock)
+		require.NoError(t, err)
+		assert.Equal(t, libcommon.Hash{}, hash)
+		return nil
+	}))
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	manifest := exportSnapshot(t, newSyncedDb(t), dir)
+	assert.Equal(t, uint64(snapshotBlock), manifest.BlockNumber)
+	assert.Equal(t, uint64(2), manifest.BatchNumber)
+	assert.Equal(t, uint64(7), manifest.ForkId)
+
+	db := newEmptyDb(t)
+	imported, err := Import(context.Background(), db, DirSource(dir), t.TempDir(), FixedRoot(manifest.StateRoot))
+	require.NoError(t, err)
+	assert.Equal(t, manifest.BlockHash, imported.BlockHash)
+
+	require.NoError(t, db.View(context.Background(), func(tx kv.Tx) error {
+		executed, err := stages.GetStageProgress(tx, stages.Execution)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(snapshotBlock), executed)
+		hash, err := rawdb.ReadCanonicalHash(tx, snapshotBlock)
+		require.NoError(t, err)
+		assert.Equal(t, manifest.BlockHash, hash)
+
+		lastRoot, err := tx.GetOne(db2.TableStats, []byte("lastRoot"))
+		require.NoError(t, err)
+		assert.Equal(t, manifest.StateRoot, libcommon.BigToHash(utils.ConvertHexToBigInt(string(lastRoot))))
+
+		acc, err := state.NewPlainStateReader(tx).ReadAccountData(libcommon.BigToAddress(big.NewInt(2)))
+		require.NoError(t, err)
+		require.NotNil(t, acc)
+		assert.Equal(t, uint64(2), acc.Nonce)
+		return nil
+	}))
+}
+
+func TestSnapshotImportUntrustedRoot(t *testing.T) {
+	dir := t.TempDir()
+	exportSnapshot(t, newSyncedDb(t), dir)
+
+	db := newEmptyDb(t)
+	_, err := Import(context.Background(), db, DirSource(dir), t.TempDir(), FixedRoot(libcommon.Hash{1}))
+	require.ErrorContains(t, err, "trusted root")
+	requireUntouched(t, db)
+}
+
+func TestSnapshotImportUnexpectedTables(t *testing.T) {
+	dir := t.TempDir()
+	manifest := exportSnapshot(t, newSyncedDb(t), dir)
+
+	// a snapshot writing to a table it is not made of
+	manifest.Tables = append(manifest.Tables[:len(manifest.Tables)-1:len(manifest.Tables)-1], kv.HeaderCanonical)
+	data, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ManifestFile), data, 0644))
+
+	db := newEmptyDb(t)
+	_, err = Import(context.Background(), db, DirSource(dir), t.TempDir(), FixedRoot(manifest.StateRoot))
+	require.ErrorContains(t, err, "tables")
+	requireUntouched(t, db)
+}
+
+func TestChunkChecksumMismatch(t *testing.T) {
+	dir := t.TempDir()
+	chunks, _ := writeRecords(t, dir, 1<<20, 100)
+	require.Len(t, chunks, 1)
+
+	// append a byte to the file, the gzip stream still decodes but the file no longer matches the manifest
+	f, err := os.OpenFile(filepath.Join(dir, chunks[0].Name), os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	_, err = readRecords(DirSource(dir), chunks)
+	assert.Error(t, err)
+
+	chunks[0].Sha256 = "00"
+	_, err = readRecords(DirSource(dir), chunks)
+	assert.Error(t, err)
+}
+
+func TestHttpSource(t *testing.T) {
+	dir := t.TempDir()
+	chunks, written := writeRecords(t, dir, 512, 200)
+
+	server := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer server.Close()
+
+	source := NewSource(server.URL + "/")
+	require.IsType(t, &HttpSource{}, source)
+
+	read, err := readRecords(source, chunks)
+	require.NoError(t, err)
+	assert.Equal(t, len(written), len(read))
+
+	_, err = source.Open("missing")
+	assert.Error(t, err)
+}
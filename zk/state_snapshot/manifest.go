@@ -0,0 +1,82 @@
+package state_snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/hexutility"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/zk/hermez_db"
+)
+
+const (
+	Version          = 1
+	ManifestFile     = "manifest.json"
+	DefaultChunkSize = 256 * 1024 * 1024
+)
+
+// Tables are the tables copied into a snapshot, the SMT itself along with the plain state it was built from and the
+// hermez data needed to keep executing blocks on top of it
+var Tables = []string{
+	db2.TableSmt,
+	db2.TableStats,
+	db2.TableAccountValues,
+	db2.TableMetadata,
+	db2.TableHashKey,
+	kv.PlainState,
+	kv.PlainContractCode,
+	kv.Code,
+	kv.IncarnationMap,
+	hermez_db.FORKIDS,
+	hermez_db.FORKID_BLOCK,
+	hermez_db.GLOBAL_EXIT_ROOTS,
+	hermez_db.L1_BLOCK_HASHES,
+}
+
+// Manifest describes a snapshot, it is written last so a directory without one is an incomplete export
+type Manifest struct {
+	Version         int              `json:"version"`
+	BatchNumber     uint64           `json:"batchNumber"`
+	BlockNumber     uint64           `json:"blockNumber"`
+	BlockHash       libcommon.Hash   `json:"blockHash"`
+	StateRoot       libcommon.Hash   `json:"stateRoot"`
+	ForkId          uint64           `json:"forkId"`
+	L1InfoTreeIndex uint64           `json:"l1InfoTreeIndex"`
+	Block           hexutility.Bytes `json:"block"` // rlp encoded block at BlockNumber
+	Tables          []string         `json:"tables"`
+	Chunks          []Chunk          `json:"chunks"`
+}
+
+// Chunk is a single gzipped file of table records, Size and Sha256 are of the file as served
+type Chunk struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	Sha256 string `json:"sha256"`
+}
+
+func ReadManifest(source Source) (*Manifest, error) {
+	r, err := source.Open(ManifestFile)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var m Manifest
+	if err = json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	if m.Version != Version {
+		return nil, fmt.Errorf("unsupported snapshot version %d, expected %d", m.Version, Version)
+	}
+
+	return &m, nil
+}
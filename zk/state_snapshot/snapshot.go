@@ -0,0 +1,282 @@
+package state_snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+	"golang.org/x/exp/slices"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/rlp"
+	db2 "github.com/ledgerwatch/erigon/smt/pkg/db"
+	"github.com/ledgerwatch/erigon/smt/pkg/utils"
+	"github.com/ledgerwatch/erigon/zk/hermez_db"
+	zkStages "github.com/ledgerwatch/erigon/zk/stages"
+)
+
+// importedStages are moved to the snapshot block on import so the datastream sync carries on from the next batch.
+// HashState is left at zero so it is promoted from the imported plain state on the first cycle.
+var importedStages = []stages.SyncStage{
+	stages.Batches,
+	stages.BlockHashes,
+	stages.Senders,
+	stages.Execution,
+	stages.CumulativeIndex,
+	stages.IntermediateHashes,
+	stages.LogIndex,
+	stages.CallTraces,
+	stages.AccountHistoryIndex,
+	stages.StorageHistoryIndex,
+	stages.TxLookup,
+	stages.Finish,
+}
+
+// Export writes the SMT and plain state at the intermediate hashes progress of the db into dir
+func Export(ctx context.Context, tx kv.Tx, dir string, chunkSize int) (*Manifest, error) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	blockNo, err := stages.GetStageProgress(tx, stages.IntermediateHashes)
+	if err != nil {
+		return nil, err
+	}
+	executed, err := stages.GetStageProgress(tx, stages.Execution)
+	if err != nil {
+		return nil, err
+	}
+	if blockNo != executed {
+		return nil, fmt.Errorf("intermediate hashes at block %d behind execution at block %d, let the node finish its sync cycle first", blockNo, executed)
+	}
+
+	hermezDb := hermez_db.NewHermezDbReader(tx)
+	batchNo, err := hermezDb.GetBatchNoByL2Block(blockNo)
+	if err != nil {
+		return nil, err
+	}
+	forkId, err := hermezDb.GetForkId(batchNo)
+	if err != nil {
+		return nil, err
+	}
+	l1InfoTreeIndex, err := stages.GetStageProgress(tx, stages.HighestUsedL1InfoIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := rawdb.ReadCanonicalHash(tx, blockNo)
+	if err != nil {
+		return nil, err
+	}
+	block := rawdb.ReadBlock(tx, hash, blockNo)
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", blockNo)
+	}
+	blockRlp, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		return nil, err
+	}
+
+	lastRoot, err := tx.GetOne(db2.TableStats, []byte("lastRoot"))
+	if err != nil {
+		return nil, err
+	}
+	if smtRoot := libcommon.BigToHash(utils.ConvertHexToBigInt(string(lastRoot))); smtRoot != block.Root() {
+		return nil, fmt.Errorf("smt root %s does not match block %d root %s", smtRoot, blockNo, block.Root())
+	}
+
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	w := newChunkWriter(dir, chunkSize)
+	for i, table := range Tables {
+		log.Info("Exporting table", "table", table)
+		if err = tx.ForEach(table, nil, func(k, v []byte) error {
+			if err := libcommon.Stopped(ctx.Done()); err != nil {
+				return err
+			}
+			return w.Write(i, k, v)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	chunks, err := w.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		Version:         Version,
+		BatchNumber:     batchNo,
+		BlockNumber:     blockNo,
+		BlockHash:       block.Hash(),
+		StateRoot:       block.Root(),
+		ForkId:          forkId,
+		L1InfoTreeIndex: l1InfoTreeIndex,
+		Block:           blockRlp,
+		Tables:          Tables,
+		Chunks:          chunks,
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(filepath.Join(dir, ManifestFile), data, 0644); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// Import loads a snapshot into an empty db, verifying the snapshot root against the trusted one, every chunk against the
+// manifest and the SMT root against one regenerated from the imported plain state. Nothing is committed unless the
+// whole snapshot checks out.
+func Import(ctx context.Context, db kv.RwDB, source Source, tmpDir string, trusted TrustedRoot) (*Manifest, error) {
+	manifest, err := ReadManifest(source)
+	if err != nil {
+		return nil, err
+	}
+
+	// the chunks are written to the tables named by the manifest so only ever take the ones a snapshot is made of
+	if !slices.Equal(manifest.Tables, Tables) {
+		return nil, fmt.Errorf("snapshot tables %v do not match the expected tables %v", manifest.Tables, Tables)
+	}
+
+	var block types.Block
+	if err = rlp.DecodeBytes(manifest.Block, &block); err != nil {
+		return nil, fmt.Errorf("decoding snapshot block: %w", err)
+	}
+	if block.NumberU64() != manifest.BlockNumber || block.Hash() != manifest.BlockHash || block.Root() != manifest.StateRoot {
+		return nil, fmt.Errorf("snapshot block %d (%s) does not match the manifest", block.NumberU64(), block.Hash())
+	}
+
+	trustedRoot, err := trusted.StateRoot(ctx, manifest.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("reading the trusted root of block %d: %w", manifest.BlockNumber, err)
+	}
+	if trustedRoot != manifest.StateRoot {
+		return nil, fmt.Errorf("snapshot root %s does not match the trusted root %s of block %d", manifest.StateRoot, trustedRoot, manifest.BlockNumber)
+	}
+
+	err = db.Update(ctx, func(tx kv.RwTx) error {
+		executed, err := stages.GetStageProgress(tx, stages.Execution)
+		if err != nil {
+			return err
+		}
+		if executed != 0 {
+			return fmt.Errorf("snapshots can only be imported into an empty db, execution is at block %d", executed)
+		}
+		// the genesis must already be in place, otherwise the node writes the genesis state over the imported one on
+		// its first start
+		genesisHash, err := rawdb.ReadCanonicalHash(tx, 0)
+		if err != nil {
+			return err
+		}
+		if genesisHash == (libcommon.Hash{}) {
+			return fmt.Errorf("no genesis block in the db, initialise the datadir before importing a snapshot")
+		}
+
+		if err = db2.CreateEriDbBuckets(tx); err != nil {
+			return err
+		}
+		if err = hermez_db.CreateHermezBuckets(tx); err != nil {
+			return err
+		}
+		for _, table := range Tables {
+			if err = tx.ClearBucket(table); err != nil {
+				return err
+			}
+		}
+
+		for _, chunk := range manifest.Chunks {
+			log.Info("Importing snapshot chunk", "chunk", chunk.Name, "size", libcommon.ByteCount(uint64(chunk.Size)))
+			if err = readChunk(source, chunk, func(table int, k, v []byte) error {
+				if err := libcommon.Stopped(ctx.Done()); err != nil {
+					return err
+				}
+				if table >= len(Tables) {
+					return fmt.Errorf("chunk %s: unknown table index %d", chunk.Name, table)
+				}
+				return tx.Put(Tables[table], k, v)
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err = verifyRoot(ctx, tx, manifest, tmpDir); err != nil {
+			return err
+		}
+
+		return writeHead(tx, manifest, &block)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+func verifyRoot(ctx context.Context, tx kv.RwTx, manifest *Manifest, tmpDir string) error {
+	lastRoot, err := db2.NewEriDb(tx).GetLastRoot()
+	if err != nil {
+		return err
+	}
+	if smtRoot := libcommon.BigToHash(lastRoot); smtRoot != manifest.StateRoot {
+		return fmt.Errorf("imported smt root %s does not match snapshot root %s", smtRoot, manifest.StateRoot)
+	}
+
+	log.Info("Regenerating SMT root from imported plain state")
+	root, err := zkStages.GenerateSmtRoot(ctx, "import", tx, tmpDir)
+	if err != nil {
+		return err
+	}
+	if root != manifest.StateRoot {
+		return fmt.Errorf("plain state root %s does not match snapshot root %s", root, manifest.StateRoot)
+	}
+
+	return nil
+}
+
+func writeHead(tx kv.RwTx, manifest *Manifest, block *types.Block) error {
+	if err := rawdb.WriteBlock(tx, block); err != nil {
+		return err
+	}
+	if err := rawdb.WriteTd(tx, block.Hash(), block.NumberU64(), big.NewInt(0)); err != nil {
+		return err
+	}
+	if err := rawdb.WriteCanonicalHash(tx, block.Hash(), block.NumberU64()); err != nil {
+		return err
+	}
+	if err := rawdb.WriteHeadHeaderHash(tx, block.Hash()); err != nil {
+		return err
+	}
+	rawdb.WriteHeadBlockHash(tx, block.Hash())
+
+	hermezDb := hermez_db.NewHermezDb(tx)
+	if err := hermezDb.WriteBlockBatch(manifest.BlockNumber, manifest.BatchNumber); err != nil {
+		return err
+	}
+
+	for _, stage := range importedStages {
+		if err := stages.SaveStageProgress(tx, stage, manifest.BlockNumber); err != nil {
+			return err
+		}
+	}
+	if err := stages.SaveStageProgress(tx, stages.ForkId, manifest.ForkId); err != nil {
+		return err
+	}
+	if err := stages.SaveStageProgress(tx, stages.HighestUsedL1InfoIndex, manifest.L1InfoTreeIndex); err != nil {
+		return err
+	}
+	return stages.SaveStageProgress(tx, stages.HighestSeenBatchNumber, manifest.BatchNumber)
+}
@@ -0,0 +1,46 @@
+package state_snapshot
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Source gives access to the files of a snapshot by name
+type Source interface {
+	Open(name string) (io.ReadCloser, error)
+}
+
+// NewSource returns a source for a local directory, or for a base url when the location is http(s)
+func NewSource(location string) Source {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return &HttpSource{BaseUrl: strings.TrimSuffix(location, "/"), Client: http.DefaultClient}
+	}
+	return DirSource(location)
+}
+
+type DirSource string
+
+func (d DirSource) Open(name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(string(d), name))
+}
+
+type HttpSource struct {
+	BaseUrl string
+	Client  *http.Client
+}
+
+func (h *HttpSource) Open(name string) (io.ReadCloser, error) {
+	resp, err := h.Client.Get(h.BaseUrl + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("fetching %s: unexpected status %s", name, resp.Status)
+	}
+	return resp.Body, nil
+}
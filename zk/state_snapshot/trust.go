@@ -0,0 +1,51 @@
+package state_snapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+
+	"github.com/ledgerwatch/erigon/zk/datastream/client"
+	"github.com/ledgerwatch/erigon/zk/datastream/types"
+)
+
+// TrustedRoot gives the state root of a block from a source other than the snapshot, a snapshot is only imported when
+// its root matches.  The snapshot is otherwise only checked against itself, which a forged snapshot passes.
+type TrustedRoot interface {
+	StateRoot(ctx context.Context, blockNo uint64) (libcommon.Hash, error)
+}
+
+// FixedRoot is a state root the operator got hold of independently, e.g. from a node they run or the L1 verified batches
+type FixedRoot libcommon.Hash
+
+func (r FixedRoot) StateRoot(ctx context.Context, blockNo uint64) (libcommon.Hash, error) {
+	return libcommon.Hash(r), nil
+}
+
+// DatastreamRoot reads the state root of the block from a datastream server
+type DatastreamRoot struct {
+	Url     string
+	Version int
+	Timeout time.Duration
+}
+
+func (d DatastreamRoot) StateRoot(ctx context.Context, blockNo uint64) (libcommon.Hash, error) {
+	c := client.NewClient(d.Url, d.Version, d.Timeout)
+	if err := c.Start(); err != nil {
+		return libcommon.Hash{}, err
+	}
+	defer c.Stop()
+
+	blocks, _, _, _, err := c.ReadEntries(types.NewL2BlockBookmark(blockNo), 1)
+	if err != nil {
+		return libcommon.Hash{}, err
+	}
+	for _, block := range *blocks {
+		if block.L2BlockNumber == blockNo {
+			return block.StateRoot, nil
+		}
+	}
+	return libcommon.Hash{}, fmt.Errorf("block %d not found in the datastream at %s", blockNo, d.Url)
+}
@@ -382,7 +382,9 @@ func GetDecodedV(tx types.Transaction, v *uint256.Int) *uint256.Int {
 	return result
 }
 
-func GenerateBlockBatchL2Data(forkId uint16, deltaTimestamp uint32, l1InfoTreeIndex uint32, transactions []types.Transaction) ([]byte, error) {
+// GenerateBlockBatchL2Data encodes a block as it appears in the batch data posted to the L1.  Transactions without an
+// entry in effectivePercentages are encoded with the maximum effective percentage.
+func GenerateBlockBatchL2Data(forkId uint16, deltaTimestamp uint32, l1InfoTreeIndex uint32, transactions []types.Transaction, effectivePercentages []uint8) ([]byte, error) {
 	var result []byte
 
 	// add in the changeL2Block transaction
@@ -390,8 +392,12 @@ func GenerateBlockBatchL2Data(forkId uint16, deltaTimestamp uint32, l1InfoTreeIn
 	result = binary.BigEndian.AppendUint32(result, deltaTimestamp)
 	result = binary.BigEndian.AppendUint32(result, l1InfoTreeIndex)
 
-	for _, transaction := range transactions {
-		encoded, err := TransactionToL2Data(transaction, forkId, MaxEffectivePercentage)
+	for idx, transaction := range transactions {
+		effectivePercentage := MaxEffectivePercentage
+		if idx < len(effectivePercentages) {
+			effectivePercentage = effectivePercentages[idx]
+		}
+		encoded, err := TransactionToL2Data(transaction, forkId, effectivePercentage)
 		if err != nil {
 			return nil, err
 		}
@@ -423,7 +423,7 @@ func Test_BlockBatchL2DataEncode(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	batchL2Data, err := GenerateBlockBatchL2Data(7, 1, 2, []types.Transaction{tx})
+	batchL2Data, err := GenerateBlockBatchL2Data(7, 1, 2, []types.Transaction{tx}, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -445,6 +445,36 @@ func Test_BlockBatchL2DataEncode(t *testing.T) {
 	require.Equal(t, expectedInfoTreeBytes, batchL2Data[5:9], "mismatch in l1 info tree")
 }
 
+func Test_BlockBatchL2DataEncodeWithEffectivePercentage(t *testing.T) {
+	toAddress := common.HexToAddress("0x1")
+	tx := &types.LegacyTx{
+		CommonTx: types.CommonTx{
+			ChainID: uint256.NewInt(5),
+			Nonce:   2,
+			Gas:     3,
+			To:      &toAddress,
+			Value:   uint256.NewInt(4),
+			V:       *uint256.NewInt(19),
+			R:       *uint256.NewInt(7),
+			S:       *uint256.NewInt(8),
+		},
+		GasPrice: uint256.NewInt(100),
+	}
+
+	expected, err := TransactionToL2Data(tx, 7, 128)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batchL2Data, err := GenerateBlockBatchL2Data(7, 1, 2, []types.Transaction{tx, tx}, []uint8{128})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	require.Equal(t, expected, batchL2Data[9:9+len(expected)], "transaction bytes mismatch")
+	require.Equal(t, byte(255), batchL2Data[len(batchL2Data)-1], "expected the maximum effective percentage without an entry")
+}
+
 func Test_BatchL2DataWithMultipleEmptyBlocks(t *testing.T) {
 	testData := "0b000001f4000000000b000001f400000000"
 	decoded, err := hex.DecodeString(testData)
@@ -40,6 +40,7 @@ import (
 	"github.com/hashicorp/golang-lru/v2/simplelru"
 	"github.com/holiman/uint256"
 	"github.com/ledgerwatch/erigon/eth/ethconfig"
+	"github.com/ledgerwatch/erigon/zk/gasless"
 	"github.com/ledgerwatch/log/v3"
 
 	"github.com/gateway-fm/cdk-erigon-lib/chain"
@@ -48,7 +49,6 @@ import (
 	"github.com/gateway-fm/cdk-erigon-lib/common/dbg"
 	"github.com/gateway-fm/cdk-erigon-lib/common/fixedgas"
 	emath "github.com/gateway-fm/cdk-erigon-lib/common/math"
-	"github.com/gateway-fm/cdk-erigon-lib/common/u256"
 	"github.com/gateway-fm/cdk-erigon-lib/gointerfaces"
 	"github.com/gateway-fm/cdk-erigon-lib/gointerfaces/grpcutil"
 	"github.com/gateway-fm/cdk-erigon-lib/gointerfaces/remote"
@@ -141,6 +141,12 @@ const (
 	InitCodeTooLarge    DiscardReason = 22 // EIP-3860 - transaction init code is too large
 	UnsupportedTx       DiscardReason = 23 // unsupported transaction type
 	OverflowZkCounters  DiscardReason = 24 // unsupported transaction type
+	Expired             DiscardReason = 25 // waited in its sub pool for longer than the sub pool's time to live
+	SenderSlotsExceeded DiscardReason = 26 // the sender has too many transactions in the pool
+	SenderBytesExceeded DiscardReason = 27 // the transactions of the sender in the pool are too large
+	NotSponsored        DiscardReason = 28 // zero priced transaction that no gasless policy rule matches
+	GaslessQuotaReached DiscardReason = 29 // the sender has used up the daily gas quota of the gasless policy rule
+	ReplaceYielded      DiscardReason = 30 // the transaction to replace was yielded to the sequencer for the open batch
 )
 
 func (r DiscardReason) String() string {
@@ -195,6 +201,18 @@ func (r DiscardReason) String() string {
 		return "unsupported transaction type"
 	case OverflowZkCounters:
 		return "overflow zk-counters"
+	case Expired:
+		return "expired"
+	case SenderSlotsExceeded:
+		return "sender has too many transactions in the pool"
+	case SenderBytesExceeded:
+		return "sender has too much transaction data in the pool"
+	case NotSponsored:
+		return "zero priced transaction not sponsored by the gasless policy"
+	case GaslessQuotaReached:
+		return "daily gasless quota exceeded"
+	case ReplaceYielded:
+		return "transaction to replace is already being sequenced"
 	default:
 		panic(fmt.Sprintf("discard reason: %d", r))
 	}
@@ -210,14 +228,22 @@ type metaTx struct {
 	bestIndex                         int
 	worstIndex                        int
 	timestamp                         uint64 // when it was added to pool
+	created                           time.Time
+	subPoolSince                      time.Time // when it moved into its current sub pool
 	subPool                           SubPoolMarker
 	currentSubPool                    SubPoolType
-	alreadyYielded                    bool
+	alreadyYielded                    bool // yielded to the sequencer since the pool last heard of a block
 	overflowZkCountersDuringExecution bool
+	counterEstimate                   *CounterEstimate // what the counter estimator expects the tx to use, nil until simulated
+	counterEstimateFailed             bool             // the tx failed when simulated so it isn't estimated again until its sender's state changes
+	yieldCount                        uint64           // how many times it was handed to the sequencer
+	skipCount                         uint64           // how many times it was passed over while yielding
+	lastSkipReason                    string
+	effectivePercentage               uint8 // the share of its gas price it pays, see ethconfig.Zk.EffectiveGasPricePercentage
 }
 
 func newMetaTx(slot *types.TxSlot, isLocal bool, timestmap uint64) *metaTx {
-	mt := &metaTx{Tx: slot, worstIndex: -1, bestIndex: -1, timestamp: timestmap}
+	mt := &metaTx{Tx: slot, worstIndex: -1, bestIndex: -1, timestamp: timestmap, created: time.Now()}
 	if isLocal {
 		mt.subPool = IsLocal
 	}
@@ -284,6 +310,7 @@ type TxPool struct {
 	unprocessedRemoteByHash map[string]int                        // to reject duplicates
 	byHash                  map[string]*metaTx                    // tx_hash => tx : only not committed to db yet records
 	discardReasonsLRU       *simplelru.LRU[string, DiscardReason] // tx_hash => discard_reason : non-persisted
+	txTimesLRU              *simplelru.LRU[string, *txTimes]      // tx_hash => when it entered and left the pool : only the arrival of txs still in the pool is persisted
 	pending                 *PendingPool
 	baseFee                 *SubPool
 	queued                  *SubPool
@@ -303,6 +330,21 @@ type TxPool struct {
 	shanghaiTime            *big.Int
 	isPostShanghai          atomic.Bool
 	allowFreeTransactions   bool
+	counterEstimator        CounterEstimator
+	bundles                 []*Bundle // in the order they arrived
+	bundleByHash            map[common.Hash]*Bundle
+	queuedTTL               time.Duration
+	pendingTTL              time.Duration
+	senderMaxSlots          uint64
+	senderMaxBytes          uint64
+	gaslessPolicy           *gasless.Policy
+	gaslessQuotas           *gasless.Quotas
+	zkCfg                   *ethconfig.Zk
+	journalBlock            uint64              // the last block whose mined txs were removed, see PoolJournalKey
+	privateTxs              map[string]struct{} // tx_hash => private : never announced nor returned to anything but the sequencer
+	hidePending             bool                // every transaction is treated as private
+	priceBump               uint64              // percentage a replacement must pay effectively above the transaction it replaces
+	yielded                 []*metaTx           // yielded since the last block, they can't be replaced
 
 	// we cannot be in a flushing state whilst getting transactions from the pool, so we have this mutex which is
 	// exposed publicly so anything wanting to get "best" transactions can ensure a flush isn't happening and
@@ -320,6 +362,10 @@ func New(newTxs chan types.Announcements, coreDB kv.RoDB, cfg txpoolcfg.Config,
 	if err != nil {
 		return nil, err
 	}
+	txTimesHistory, err := simplelru.NewLRU[string, *txTimes](10_000, nil)
+	if err != nil {
+		return nil, err
+	}
 
 	byNonce := &BySenderAndNonce{
 		tree:             btree.NewG[*metaTx](32, SortByNonceLess),
@@ -330,11 +376,16 @@ func New(newTxs chan types.Announcements, coreDB kv.RoDB, cfg txpoolcfg.Config,
 	for _, sender := range cfg.TracedSenders {
 		tracedSenders[common.BytesToAddress([]byte(sender))] = struct{}{}
 	}
+	priceBump := cfg.PriceBump
+	if ethCfg.TxPoolPriceBump > 0 {
+		priceBump = ethCfg.TxPoolPriceBump
+	}
 	return &TxPool{
 		lock:                    &sync.Mutex{},
 		byHash:                  map[string]*metaTx{},
 		isLocalLRU:              localsHistory,
 		discardReasonsLRU:       discardHistory,
+		txTimesLRU:              txTimesHistory,
 		all:                     byNonce,
 		recentlyConnectedPeers:  &recentlyConnectedPeers{},
 		pending:                 NewPendingSubPool(PendingSubPool, cfg.PendingSubPoolLimit),
@@ -351,6 +402,17 @@ func New(newTxs chan types.Announcements, coreDB kv.RoDB, cfg txpoolcfg.Config,
 		londonBlock:             londonBlock,
 		shanghaiTime:            shanghaiTime,
 		allowFreeTransactions:   ethCfg.AllowFreeTransactions,
+		bundleByHash:            map[common.Hash]*Bundle{},
+		queuedTTL:               ethCfg.TxPoolQueuedTTL,
+		pendingTTL:              ethCfg.TxPoolPendingTTL,
+		senderMaxSlots:          ethCfg.TxPoolSenderMaxSlots,
+		senderMaxBytes:          ethCfg.TxPoolSenderMaxBytes,
+		gaslessPolicy:           ethCfg.GaslessPolicy,
+		gaslessQuotas:           gasless.NewQuotas(),
+		zkCfg:                   ethCfg.Zk,
+		privateTxs:              map[string]struct{}{},
+		hidePending:             ethCfg.TxPoolHidePending,
+		priceBump:               priceBump,
 		flushMtx:                &sync.Mutex{},
 	}, nil
 }
@@ -413,6 +475,10 @@ func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remote.StateChang
 	if err := p.senders.onNewBlock(stateChanges, unwindTxs, minedTxs); err != nil {
 		return err
 	}
+	if err := p.onNewBlockGasless(coreTx, stateChanges, unwindTxs, minedTxs); err != nil {
+		return err
+	}
+	p.clearCounterEstimatesLocked(stateChanges, unwindTxs)
 	_, unwindTxs, err = p.validateTxs(&unwindTxs, cacheView)
 	if err != nil {
 		return err
@@ -434,6 +500,9 @@ func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remote.StateChang
 	if err := removeMined(p.all, minedTxs.Txs, p.pending, p.baseFee, p.queued, p.discardLocked); err != nil {
 		return err
 	}
+	p.removeMinedBundles(minedTxs.Txs)
+	p.clearYieldedLocked()
+	p.journalBlock = stateChanges.ChangeBatch[len(stateChanges.ChangeBatch)-1].BlockHeight
 
 	//log.Debug("[txpool] new block", "unwinded", len(unwindTxs.txs), "mined", len(minedTxs.txs), "baseFee", baseFee, "blockHeight", blockHeight)
 
@@ -542,6 +611,9 @@ func (p *TxPool) getRlpLocked(tx kv.Tx, hash []byte) (rlpTxn []byte, sender comm
 func (p *TxPool) GetRlp(tx kv.Tx, hash []byte) ([]byte, error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
+	if p.hiddenLocked(string(hash)) {
+		return nil, nil
+	}
 	rlpTx, _, _, err := p.getRlpLocked(tx, hash)
 	return common.Copy(rlpTx), err
 }
@@ -549,7 +621,7 @@ func (p *TxPool) AppendLocalAnnouncements(types []byte, sizes []uint32, hashes [
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	for hash, txn := range p.byHash {
-		if txn.subPool&IsLocal == 0 {
+		if txn.subPool&IsLocal == 0 || p.hiddenLocked(hash) {
 			continue
 		}
 		types = append(types, txn.Tx.Type)
@@ -562,8 +634,11 @@ func (p *TxPool) AppendRemoteAnnouncements(types []byte, sizes []uint32, hashes
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
+	if p.hidePending {
+		return types, sizes, hashes
+	}
 	for hash, txn := range p.byHash {
-		if txn.subPool&IsLocal != 0 {
+		if txn.subPool&IsLocal != 0 || p.hiddenLocked(hash) {
 			continue
 		}
 		types = append(types, txn.Tx.Type)
@@ -608,19 +683,20 @@ func (p *TxPool) Started() bool                      { return p.started.Load() }
 func (p *TxPool) ResetYieldedStatus() {
 	p.lock.Lock()
 	defer p.lock.Unlock()
-	best := p.pending.best
-	for i := 0; i < len(best.ms); i++ {
-		best.ms[i].alreadyYielded = false
-	}
+	p.clearYieldedLocked()
 }
 
 func (p *TxPool) YieldBest(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableGas uint64, toSkip mapset.Set[[32]byte]) (bool, int, error) {
-	return p.best(n, txs, tx, onTopOf, availableGas, toSkip)
+	return p.best(n, txs, tx, onTopOf, availableGas, toSkip, true)
 }
 
 func (p *TxPool) PeekBest(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableGas uint64) (bool, error) {
-	set := mapset.NewThreadUnsafeSet[[32]byte]()
-	onTime, _, err := p.best(n, txs, tx, onTopOf, availableGas, set)
+	if p.hidePending {
+		txs.Resize(0)
+		return true, nil
+	}
+	set := p.privateSet()
+	onTime, _, err := p.best(n, txs, tx, onTopOf, availableGas, set, false)
 	return onTime, err
 }
 
@@ -656,8 +732,16 @@ func (p *TxPool) validateTx(txn *types.TxSlot, isLocal bool, stateCache kvcache.
 		return UnsupportedTx
 	}
 
+	sponsored, reason := p.checkGasless(txn)
+	if reason != Success {
+		if txn.Traced {
+			log.Info(fmt.Sprintf("TX TRACING: validateTx gasless idHash=%x reason=%s", txn.IDHash, reason))
+		}
+		return reason
+	}
+
 	// Drop non-local transactions under our own minimal accepted gas price or tip
-	if !isLocal && uint256.NewInt(p.cfg.MinFeeCap).Cmp(&txn.FeeCap) == 1 {
+	if !isLocal && !sponsored && uint256.NewInt(p.cfg.MinFeeCap).Cmp(&txn.FeeCap) == 1 {
 		if txn.Traced {
 			log.Info(fmt.Sprintf("TX TRACING: validateTx underpriced idHash=%x local=%t, feeCap=%d, cfg.MinFeeCap=%d", txn.IDHash, isLocal, txn.FeeCap, p.cfg.MinFeeCap))
 		}
@@ -685,6 +769,12 @@ func (p *TxPool) validateTx(txn *types.TxSlot, isLocal bool, stateCache kvcache.
 		}
 		return Spammer
 	}
+	if reason := p.checkSenderQuota(txn); reason != Success {
+		if txn.Traced {
+			log.Info(fmt.Sprintf("TX TRACING: validateTx sender quota idHash=%x reason=%s", txn.IDHash, reason))
+		}
+		return reason
+	}
 
 	// check nonce and balance
 	senderNonce, senderBalance, _ := p.senders.info(stateCache, txn.SenderID)
@@ -833,6 +923,10 @@ func fillDiscardReasons(reasons []DiscardReason, newTxs types.TxSlots, discardRe
 }
 
 func (p *TxPool) AddLocalTxs(ctx context.Context, newTransactions types.TxSlots, tx kv.Tx) ([]DiscardReason, error) {
+	return p.addLocalTxs(ctx, newTransactions, tx, false)
+}
+
+func (p *TxPool) addLocalTxs(ctx context.Context, newTransactions types.TxSlots, tx kv.Tx, private bool) ([]DiscardReason, error) {
 	coreTx, err := p.coreDB().BeginRo(ctx)
 	if err != nil {
 		return nil, err
@@ -859,6 +953,9 @@ func (p *TxPool) AddLocalTxs(ctx context.Context, newTransactions types.TxSlots,
 	if err = p.senders.registerNewSenders(&newTransactions); err != nil {
 		return nil, err
 	}
+	if private {
+		defer p.unmarkRejectedLocked(p.markPrivateLocked(newTransactions))
+	}
 
 	reasons, newTxs, err := p.validateTxs(&newTransactions, cacheView)
 	if err != nil {
@@ -1044,15 +1141,10 @@ func (p *TxPool) setBaseFee(baseFee uint64) (uint64, bool) {
 
 func (p *TxPool) addLocked(mt *metaTx, announcements *types.Announcements) DiscardReason {
 	// Insert to pending pool, if pool doesn't have txn with same Nonce and bigger Tip
+	mt.effectivePercentage = p.effectivePercentage(mt.Tx)
 	found := p.all.get(mt.Tx.SenderID, mt.Tx.Nonce)
 	if found != nil {
-		tipThreshold := uint256.NewInt(0)
-		tipThreshold = tipThreshold.Mul(&found.Tx.Tip, uint256.NewInt(100+p.cfg.PriceBump))
-		tipThreshold.Div(tipThreshold, u256.N100)
-		feecapThreshold := uint256.NewInt(0)
-		feecapThreshold.Mul(&found.Tx.FeeCap, uint256.NewInt(100+p.cfg.PriceBump))
-		feecapThreshold.Div(feecapThreshold, u256.N100)
-		if mt.Tx.Tip.Cmp(tipThreshold) < 0 || mt.Tx.FeeCap.Cmp(feecapThreshold) < 0 {
+		if reason := p.checkReplacementLocked(found, mt); reason != NotSet {
 			// Both tip and feecap need to be larger than previously to replace the transaction
 			// In case if the transation is stuck, "poke" it to rebroadcast
 			if mt.subPool&IsLocal != 0 && (found.currentSubPool == PendingSubPool || found.currentSubPool == BaseFeeSubPool) {
@@ -1061,7 +1153,7 @@ func (p *TxPool) addLocked(mt *metaTx, announcements *types.Announcements) Disca
 			if bytes.Equal(found.Tx.IDHash[:], mt.Tx.IDHash[:]) {
 				return NotSet
 			}
-			return NotReplaced
+			return reason
 		}
 
 		switch found.currentSubPool {
@@ -1079,6 +1171,7 @@ func (p *TxPool) addLocked(mt *metaTx, announcements *types.Announcements) Disca
 	}
 
 	p.byHash[string(mt.Tx.IDHash[:])] = mt
+	p.trackAdded(mt)
 
 	if replaced := p.all.replaceOrInsert(mt); replaced != nil {
 		if assert.Enable {
@@ -1101,6 +1194,7 @@ func (p *TxPool) discardLocked(mt *metaTx, reason DiscardReason) {
 	p.deletedTxs = append(p.deletedTxs, mt)
 	p.all.delete(mt)
 	p.discardReasonsLRU.Add(string(mt.Tx.IDHash[:]), reason)
+	p.trackDiscarded(mt)
 }
 
 func (p *TxPool) NonceFromAddress(addr [20]byte) (nonce uint64, inPool bool) {
@@ -1255,6 +1349,10 @@ func MainLoop(ctx context.Context, db kv.RwDB, coreDB kv.RoDB, p *TxPool, newTxs
 	defer commitEvery.Stop()
 	logEvery := time.NewTicker(p.cfg.LogEvery)
 	defer logEvery.Stop()
+	expireTxsEvery := time.NewTicker(expireTxsInterval)
+	defer expireTxsEvery.Stop()
+
+	go counterEstimationLoop(ctx, db, p)
 
 	for {
 		select {
@@ -1265,6 +1363,10 @@ func MainLoop(ctx context.Context, db kv.RwDB, coreDB kv.RoDB, p *TxPool, newTxs
 			return
 		case <-logEvery.C:
 			p.logStats()
+		case <-expireTxsEvery.C:
+			if p.Started() {
+				p.expireTxs()
+			}
 		case <-processRemoteTxsEvery.C:
 			if !p.Started() {
 				continue
@@ -1381,6 +1483,14 @@ func MainLoop(ctx context.Context, db kv.RwDB, coreDB kv.RoDB, p *TxPool, newTxs
 	}
 }
 
+// Flush writes what the pool holds to its db, as MainLoop does every CommitEvery
+func (p *TxPool) Flush(ctx context.Context, db kv.RwDB) error {
+	p.LockFlusher()
+	defer p.UnlockFlusher()
+	_, err := p.flush(ctx, db)
+	return err
+}
+
 func (p *TxPool) flush(ctx context.Context, db kv.RwDB) (written uint64, err error) {
 	defer writeToDBTimer.UpdateDuration(time.Now())
 	p.lock.Lock()
@@ -1472,6 +1582,18 @@ func (p *TxPool) flushLocked(tx kv.RwTx) (err error) {
 	if err := PutLastSeenBlock(tx, p.lastSeenBlock.Load(), encID); err != nil {
 		return err
 	}
+	if err := p.flushGaslessQuotas(tx); err != nil {
+		return err
+	}
+	if err := p.flushJournal(tx); err != nil {
+		return err
+	}
+	if err := p.flushPrivateTxs(tx); err != nil {
+		return err
+	}
+	if err := p.flushBundles(tx); err != nil {
+		return err
+	}
 
 	// clean - in-memory data structure as later as possible - because if during this Tx will happen error,
 	// DB will stay consistent but some in-memory structures may be already cleaned, and retry will not work
@@ -1489,6 +1611,20 @@ func (p *TxPool) fromDB(ctx context.Context, tx kv.Tx, coreTx kv.Tx) error {
 		p.lastSeenBlock.Store(lastSeenBlock)
 	}
 
+	if err := p.gaslessQuotasFromDB(tx); err != nil {
+		return err
+	}
+	journal, mined, err := p.journalFromDB(tx, coreTx)
+	if err != nil {
+		return err
+	}
+	if err := p.privateTxsFromDB(tx); err != nil {
+		return err
+	}
+	if err := p.bundlesFromDB(tx, mined); err != nil {
+		return err
+	}
+
 	cacheView, err := p._stateCache.View(ctx, coreTx)
 	if err != nil {
 		return err
@@ -1528,15 +1664,24 @@ func (p *TxPool) fromDB(ctx context.Context, tx kv.Tx, coreTx kv.Tx) error {
 			log.Warn("[txpool] fromDB: parseTransaction", "err", err)
 			continue
 		}
-		txn.Rlp = nil // means that we don't need store it in db anymore
 
 		txn.SenderID, txn.Traced = p.senders.getOrCreateID(addr)
 		binary.BigEndian.Uint64(v)
+		// only set when the sender is parsed too, the sender byte limits need it
+		txn.Size = uint32(len(txRlp))
 
 		isLocalTx := p.isLocalLRU.Contains(string(k))
 
+		// mined after the last flush, it is dropped from the db on the next one
+		if _, ok := mined[string(k)]; ok {
+			p.deletedTxs = append(p.deletedTxs, newMetaTx(txn, isLocalTx, 0))
+			continue
+		}
+
+		// the rlp is kept until the transaction is validated, the gasless policy matches on its target and call data
 		if reason := p.validateTx(txn, isLocalTx, cacheView); reason != NotSet && reason != Success {
-			return nil
+			p.deletedTxs = append(p.deletedTxs, newMetaTx(txn, isLocalTx, 0))
+			continue
 		}
 		txs.Resize(uint(i + 1))
 		txs.Txs[i] = txn
@@ -1563,7 +1708,12 @@ func (p *TxPool) fromDB(ctx context.Context, tx kv.Tx, coreTx kv.Tx) error {
 		pendingBaseFee, math.MaxUint64 /* blockGasLimit */, p.pending, p.baseFee, p.queued, p.all, p.byHash, p.addLocked, p.discardLocked, false); err != nil {
 		return err
 	}
+	// the rlp is kept until the transactions are added, their effective gas price percentage depends on the call data
+	for _, txn := range txs.Txs {
+		txn.Rlp = nil // means that we don't need store it in db anymore
+	}
 	p.pendingBaseFee.Store(pendingBaseFee)
+	p.restoreJournal(journal)
 
 	return nil
 }
@@ -1662,6 +1812,9 @@ func (p *TxPool) deprecatedForEach(_ context.Context, f func(rlp []byte, sender
 	defer p.lock.Unlock()
 	p.all.ascendAll(func(mt *metaTx) bool {
 		slot := mt.Tx
+		if p.hiddenLocked(string(slot.IDHash[:])) {
+			return true
+		}
 		slotRlp := slot.Rlp
 		if slot.Rlp == nil {
 			v, err := tx.GetOne(kv.PoolTransaction, slot.IDHash[:])
@@ -2063,6 +2216,9 @@ func (p *PendingPool) Add(i *metaTx) {
 	if i.Tx.Traced {
 		log.Info(fmt.Sprintf("TX TRACING: moved to subpool %s, IdHash=%x, sender=%d", p.t, i.Tx.IDHash, i.Tx.SenderID))
 	}
+	if i.currentSubPool != p.t {
+		i.subPoolSince = time.Now()
+	}
 	i.currentSubPool = p.t
 	heap.Push(p.worst, i)
 	p.best.UnsafeAdd(i)
@@ -2118,6 +2274,9 @@ func (p *SubPool) Add(i *metaTx) {
 	if i.Tx.Traced {
 		log.Info(fmt.Sprintf("TX TRACING: moved to subpool %s, IdHash=%x, sender=%d", p.t, i.Tx.IDHash, i.Tx.SenderID))
 	}
+	if i.currentSubPool != p.t {
+		i.subPoolSince = time.Now()
+	}
 	i.currentSubPool = p.t
 	heap.Push(p.best, i)
 	heap.Push(p.worst, i)
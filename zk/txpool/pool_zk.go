@@ -145,8 +145,9 @@ func onSenderStateChange(senderID uint64, senderNonce uint64, senderBalance uint
 }
 
 // zk: the implementation of best here is changed only to not take into account block gas limits as we don't care about
-// these in zk.  Instead we do a quick check on the transaction maximum gas in zk
-func (p *TxPool) best(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableGas uint64, toSkip mapset.Set[[32]byte]) (bool, int, error) {
+// these in zk.  Instead we do a quick check on the transaction maximum gas in zk.  Only what is yielded to the
+// sequencer is marked as such, not what is peeked at
+func (p *TxPool) best(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableGas uint64, toSkip mapset.Set[[32]byte], yield bool) (bool, int, error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -203,6 +204,7 @@ func (p *TxPool) best(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableG
 		intrinsicGas, _ := CalcIntrinsicGas(uint64(mt.Tx.DataLen), uint64(mt.Tx.DataNonZeroLen), nil, mt.Tx.Creation, true, true, isShanghai)
 		if intrinsicGas > availableGas {
 			// we might find another TX with a low enough intrinsic gas to include so carry on
+			mt.skipped(skippedNoGasLeft)
 			continue
 		}
 
@@ -214,6 +216,9 @@ func (p *TxPool) best(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableG
 		copy(txs.Senders.At(count), sender.Bytes())
 		txs.IsLocal[count] = isLocal
 		toSkip.Add(mt.Tx.IDHash)
+		if yield {
+			p.markYieldedLocked(mt)
+		}
 		count++
 	}
 
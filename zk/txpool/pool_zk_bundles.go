@@ -0,0 +1,336 @@
+package txpool
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/length"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/kvcache"
+	"github.com/gateway-fm/cdk-erigon-lib/types"
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/log/v3"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// MaxBundleSize is the most transactions a bundle can have
+	MaxBundleSize = 16
+
+	// maxBundlesPerSender is how many bundles the sender of their first transaction can have waiting to be sequenced
+	maxBundlesPerSender = 4
+)
+
+// PoolBundlesKey keeps the bundles waiting to be sequenced so they survive a restart
+var PoolBundlesKey = []byte("zk_bundles")
+
+var (
+	ErrBundleEmpty             = errors.New("bundle has no transactions")
+	ErrBundleTooLarge          = errors.New("bundle has too many transactions")
+	ErrBundleKnown             = errors.New("bundle already known")
+	ErrBundleSenderLimit       = errors.New("too many bundles from the sender waiting to be sequenced")
+	ErrBundleNonceGap          = errors.New("bundle nonce gap")
+	ErrBundleInsufficientFunds = errors.New("bundle sender can't pay for all its transactions")
+)
+
+// Bundle is a group of transactions that are sequenced one after the other in the same block, or not at all
+type Bundle struct {
+	Hash     libcommon.Hash
+	TxHashes []libcommon.Hash
+	Txs      [][]byte // rlp encoded transactions in the order they are sequenced
+
+	// MaxBlockNumber is the last block the bundle can be sequenced in, 0 when it can wait for any block
+	MaxBlockNumber uint64
+
+	Added time.Time
+
+	// sender is the sender of the first transaction, set once the bundle has been checked
+	sender libcommon.Address
+}
+
+// BundleHash identifies a bundle by the hashes of its transactions
+func BundleHash(txHashes []libcommon.Hash) libcommon.Hash {
+	hasher := sha3.NewLegacyKeccak256()
+	for _, hash := range txHashes {
+		hasher.Write(hash[:])
+	}
+	var hash libcommon.Hash
+	hasher.Sum(hash[:0])
+	return hash
+}
+
+// AddBundle queues a bundle for the sequencer.  Every transaction gets the checks of a local transaction sent to the
+// pool, and the transactions of each sender must follow on from its nonce, or its transactions in the pool, without a
+// gap and its balance must pay for all of them.  The bundles which expired or can no longer be applied are evicted
+// first, each sender can then have maxBundlesPerSender bundles waiting.
+func (p *TxPool) AddBundle(ctx context.Context, txHashes []libcommon.Hash, txs [][]byte, maxBlockNumber uint64) (libcommon.Hash, error) {
+	if len(txs) == 0 {
+		return libcommon.Hash{}, ErrBundleEmpty
+	}
+	if len(txs) > MaxBundleSize {
+		return libcommon.Hash{}, ErrBundleTooLarge
+	}
+
+	bundle := &Bundle{
+		Hash:           BundleHash(txHashes),
+		TxHashes:       txHashes,
+		Txs:            txs,
+		MaxBlockNumber: maxBlockNumber,
+		Added:          time.Now(),
+	}
+
+	coreTx, err := p.coreDB().BeginRo(ctx)
+	if err != nil {
+		return libcommon.Hash{}, err
+	}
+	defer coreTx.Rollback()
+	cacheView, err := p.cache().View(ctx, coreTx)
+	if err != nil {
+		return libcommon.Hash{}, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.Started() {
+		return libcommon.Hash{}, errors.New("txpool not started yet")
+	}
+	if _, ok := p.bundleByHash[bundle.Hash]; ok {
+		return bundle.Hash, ErrBundleKnown
+	}
+	if err = p.checkBundleLocked(bundle, cacheView); err != nil {
+		return libcommon.Hash{}, err
+	}
+
+	p.evictBundlesLocked(cacheView)
+	waiting := 0
+	for _, b := range p.bundles {
+		if b.sender == bundle.sender {
+			waiting++
+		}
+	}
+	if waiting >= maxBundlesPerSender {
+		return libcommon.Hash{}, fmt.Errorf("%w, the limit is %d", ErrBundleSenderLimit, maxBundlesPerSender)
+	}
+
+	p.bundles = append(p.bundles, bundle)
+	p.bundleByHash[bundle.Hash] = bundle
+	return bundle.Hash, nil
+}
+
+// checkBundleLocked checks a bundle can be applied on top of the state and the pool, and sets its sender
+func (p *TxPool) checkBundleLocked(bundle *Bundle, cacheView kvcache.CacheView) error {
+	parseCtx := types.NewTxParseContext(p.chainID)
+	nextNonce := map[uint64]uint64{}
+	spent := map[uint64]*uint256.Int{}
+	for i, txRlp := range bundle.Txs {
+		txn := &types.TxSlot{}
+		sender := make([]byte, length.Addr)
+		if _, err := parseCtx.ParseTransaction(txRlp, 0, txn, sender, false /* hasEnvelope */, nil); err != nil {
+			return fmt.Errorf("transaction %d: %w", i, err)
+		}
+		txn.SenderID, txn.Traced = p.senders.getOrCreateID(libcommon.BytesToAddress(sender))
+		if i == 0 {
+			bundle.sender = libcommon.BytesToAddress(sender)
+		}
+		if reason := p.validateTx(txn, true, cacheView); reason != Success {
+			return fmt.Errorf("transaction %d: %s", i, reason)
+		}
+
+		stateNonce, balance, err := p.senders.info(cacheView, txn.SenderID)
+		if err != nil {
+			return err
+		}
+		next, ok := nextNonce[txn.SenderID]
+		if !ok {
+			// the first transaction of the sender can follow its transactions in the pool
+			next = stateNonce
+			p.all.ascend(txn.SenderID, func(mt *metaTx) bool {
+				if mt.Tx.Nonce != next {
+					return mt.Tx.Nonce < next
+				}
+				next++
+				return true
+			})
+		}
+		if txn.Nonce > next || ok && txn.Nonce != next {
+			return fmt.Errorf("%w: transaction %d has nonce %d, expected %d", ErrBundleNonceGap, i, txn.Nonce, next)
+		}
+		nextNonce[txn.SenderID] = txn.Nonce + 1
+
+		cost := uint256.NewInt(txn.Gas)
+		cost.Mul(cost, &txn.FeeCap)
+		cost.Add(cost, &txn.Value)
+		if spent[txn.SenderID] == nil {
+			spent[txn.SenderID] = new(uint256.Int)
+		}
+		spent[txn.SenderID].Add(spent[txn.SenderID], cost)
+		if spent[txn.SenderID].Cmp(&balance) > 0 {
+			return fmt.Errorf("%w: %s has %d, needs %d", ErrBundleInsufficientFunds, libcommon.BytesToAddress(sender), &balance, spent[txn.SenderID])
+		}
+	}
+	return nil
+}
+
+// evictBundlesLocked drops the bundles which can't go in the next block or can no longer be applied
+func (p *TxPool) evictBundlesLocked(cacheView kvcache.CacheView) {
+	nextBlock := p.lastSeenBlock.Load() + 1
+	var evicted []*Bundle
+	for _, bundle := range p.bundles {
+		if bundle.MaxBlockNumber != 0 && bundle.MaxBlockNumber < nextBlock {
+			evicted = append(evicted, bundle)
+			continue
+		}
+		if err := p.checkBundleLocked(bundle, cacheView); err != nil {
+			log.Debug("[txpool] evicting bundle", "hash", bundle.Hash, "err", err)
+			evicted = append(evicted, bundle)
+		}
+	}
+	for _, bundle := range evicted {
+		p.removeBundleLocked(bundle.Hash)
+	}
+}
+
+// NextBundle returns the oldest bundle that can go in the given block and isn't in toSkip, bundles that have expired
+// are dropped
+func (p *TxPool) NextBundle(blockNumber uint64, toSkip mapset.Set[libcommon.Hash]) *Bundle {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var next *Bundle
+	var expired []*Bundle
+	for _, bundle := range p.bundles {
+		if bundle.MaxBlockNumber != 0 && bundle.MaxBlockNumber < blockNumber {
+			expired = append(expired, bundle)
+			continue
+		}
+		if next == nil && !toSkip.Contains(bundle.Hash) {
+			next = bundle
+		}
+	}
+	for _, bundle := range expired {
+		log.Debug("[txpool] bundle expired", "hash", bundle.Hash, "maxBlockNumber", bundle.MaxBlockNumber)
+		p.removeBundleLocked(bundle.Hash)
+	}
+	return next
+}
+
+// RemoveBundle drops a bundle, either because it was sequenced or because it can't be
+func (p *TxPool) RemoveBundle(hash libcommon.Hash) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.removeBundleLocked(hash)
+}
+
+// BundleCount is the number of bundles waiting to be sequenced
+func (p *TxPool) BundleCount() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.bundles)
+}
+
+func (p *TxPool) removeBundleLocked(hash libcommon.Hash) {
+	if _, ok := p.bundleByHash[hash]; !ok {
+		return
+	}
+	delete(p.bundleByHash, hash)
+	for i, bundle := range p.bundles {
+		if bundle.Hash == hash {
+			p.bundles = append(p.bundles[:i], p.bundles[i+1:]...)
+			break
+		}
+	}
+}
+
+// removeMinedBundles drops the bundles with a transaction in a new block, once one of them is mined the bundle can no
+// longer be applied as a whole
+func (p *TxPool) removeMinedBundles(minedTxs []*types.TxSlot) {
+	if len(p.bundles) == 0 || len(minedTxs) == 0 {
+		return
+	}
+	mined := make(map[libcommon.Hash]struct{}, len(minedTxs))
+	for _, txn := range minedTxs {
+		mined[txn.IDHash] = struct{}{}
+	}
+	var toRemove []libcommon.Hash
+	for _, bundle := range p.bundles {
+		for _, hash := range bundle.TxHashes {
+			if _, ok := mined[hash]; ok {
+				toRemove = append(toRemove, bundle.Hash)
+				break
+			}
+		}
+	}
+	for _, hash := range toRemove {
+		p.removeBundleLocked(hash)
+	}
+}
+
+// flushBundles writes the bundles in the order they arrived: the max block number, the time added and the number of
+// transactions, then the hash, length and rlp of each transaction
+func (p *TxPool) flushBundles(tx kv.RwTx) error {
+	var v []byte
+	for _, bundle := range p.bundles {
+		v = binary.BigEndian.AppendUint64(v, bundle.MaxBlockNumber)
+		v = binary.BigEndian.AppendUint64(v, uint64(bundle.Added.UnixNano()))
+		v = append(v, byte(len(bundle.Txs)))
+		for i, txn := range bundle.Txs {
+			v = append(v, bundle.TxHashes[i][:]...)
+			v = binary.BigEndian.AppendUint32(v, uint32(len(txn)))
+			v = append(v, txn...)
+		}
+	}
+	return tx.Put(kv.PoolInfo, PoolBundlesKey, v)
+}
+
+// bundlesFromDB loads the bundles of the last flush but those with a transaction mined since
+func (p *TxPool) bundlesFromDB(tx kv.Tx, mined map[string]struct{}) error {
+	v, err := tx.GetOne(kv.PoolInfo, PoolBundlesKey)
+	if err != nil {
+		return err
+	}
+	errLength := fmt.Errorf("pool bundles: unexpected length %d", len(v))
+	for pos := 0; pos < len(v); {
+		if pos+17 > len(v) {
+			return errLength
+		}
+		bundle := &Bundle{
+			MaxBlockNumber: binary.BigEndian.Uint64(v[pos:]),
+			Added:          time.Unix(0, int64(binary.BigEndian.Uint64(v[pos+8:]))),
+		}
+		count := int(v[pos+16])
+		pos += 17
+
+		isMined := false
+		for i := 0; i < count; i++ {
+			if pos+length.Hash+4 > len(v) {
+				return errLength
+			}
+			hash := libcommon.BytesToHash(v[pos : pos+length.Hash])
+			size := int(binary.BigEndian.Uint32(v[pos+length.Hash:]))
+			pos += length.Hash + 4
+			if pos+size > len(v) {
+				return errLength
+			}
+			if _, ok := mined[string(hash[:])]; ok {
+				isMined = true
+			}
+			bundle.TxHashes = append(bundle.TxHashes, hash)
+			bundle.Txs = append(bundle.Txs, libcommon.Copy(v[pos:pos+size]))
+			pos += size
+		}
+		if isMined {
+			continue
+		}
+
+		bundle.Hash = BundleHash(bundle.TxHashes)
+		p.bundles = append(p.bundles, bundle)
+		p.bundleByHash[bundle.Hash] = bundle
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package txpool
+
+import (
+	"math/big"
+	"testing"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/stretchr/testify/require"
+
+	types2 "github.com/ledgerwatch/erigon/core/types"
+)
+
+func (p *testPool) addBundle(maxBlockNumber uint64, txns ...types2.Transaction) libcommon.Hash {
+	hash, err := p.tryAddBundle(maxBlockNumber, txns...)
+	require.NoError(p.t, err)
+	return hash
+}
+
+func (p *testPool) tryAddBundle(maxBlockNumber uint64, txns ...types2.Transaction) (libcommon.Hash, error) {
+	var hashes []libcommon.Hash
+	var rlps [][]byte
+	for _, txn := range txns {
+		hashes = append(hashes, txn.Hash())
+		rlps = append(rlps, p.rlp(txn))
+	}
+	return p.pool.AddBundle(p.ctx, hashes, rlps, maxBlockNumber)
+}
+
+func TestBundles_Restart(t *testing.T) {
+	p := newTestPool(t, 2)
+	first := p.addBundle(0, p.sign(0, 1e9, nil), p.sign(0, 1e9, []byte{0x01}))
+	second := p.addBundle(20, p.sign(1, 1e9, nil))
+	before := p.pool.NextBundle(p.head, mapset.NewSet[libcommon.Hash]())
+	p.flush()
+	p.restart()
+
+	require.Equal(t, 2, p.pool.BundleCount())
+	next := p.pool.NextBundle(p.head, mapset.NewSet[libcommon.Hash]())
+	require.Equal(t, first, next.Hash)
+	require.Equal(t, before.TxHashes, next.TxHashes)
+	require.Equal(t, before.Txs, next.Txs)
+	require.True(t, before.Added.Equal(next.Added))
+
+	next = p.pool.NextBundle(p.head, mapset.NewSet(first))
+	require.Equal(t, second, next.Hash)
+	require.Equal(t, uint64(20), next.MaxBlockNumber)
+}
+
+func TestBundles_MinedWhileStopped(t *testing.T) {
+	p := newTestPool(t, 2)
+	mined := p.sign(0, 1e9, nil)
+	p.addBundle(0, mined, p.sign(0, 1e9, nil))
+	kept := p.addBundle(0, p.sign(1, 1e9, nil))
+	p.flush()
+
+	// the sequencer stops after the block is written but before the pool is told about it
+	parent, err := p.readCanonicalHash(p.head)
+	require.NoError(t, err)
+	p.writeBlock(types2.NewBlock(&types2.Header{Number: new(big.Int).SetUint64(p.head + 1), ParentHash: parent}, []types2.Transaction{mined}, nil, nil, nil))
+	p.restart()
+
+	require.Equal(t, 1, p.pool.BundleCount())
+	require.Equal(t, kept, p.pool.NextBundle(p.head, mapset.NewSet[libcommon.Hash]()).Hash)
+}
+
+func TestBundles_Checked(t *testing.T) {
+	p := newTestPool(t, 2)
+	p.mine(p.sign(0, 1e9, nil))
+
+	_, err := p.tryAddBundle(0, p.signNonce(0, 0, 1e9, nil))
+	require.ErrorContains(t, err, NonceTooLow.String())
+
+	// the nonces of a sender follow each other
+	_, err = p.tryAddBundle(0, p.signNonce(0, 1, 1e9, nil), p.signNonce(0, 3, 1e9, nil))
+	require.ErrorIs(t, err, ErrBundleNonceGap)
+	_, err = p.tryAddBundle(0, p.signNonce(0, 2, 1e9, nil))
+	require.ErrorIs(t, err, ErrBundleNonceGap)
+
+	// unless the transactions in between are in the pool
+	p.add(p.sign(0, 1e9, nil))
+	p.addBundle(0, p.signNonce(0, 2, 1e9, nil), p.signNonce(1, 0, 1e9, nil))
+
+	// every transaction of the sender is paid for from its balance
+	var expensive []types2.Transaction
+	for nonce := uint64(0); nonce < 5; nonce++ {
+		expensive = append(expensive, p.signNonce(1, nonce, 1e13, nil))
+	}
+	_, err = p.tryAddBundle(0, expensive[:4]...)
+	require.NoError(t, err)
+	_, err = p.tryAddBundle(0, expensive...)
+	require.ErrorIs(t, err, ErrBundleInsufficientFunds)
+}
+
+func TestBundles_SenderLimitEvicts(t *testing.T) {
+	p := newTestPool(t, 2)
+	for i := 0; i < maxBundlesPerSender; i++ {
+		p.addBundle(p.head+1, p.signNonce(0, 0, uint64(i+1)*1e9, nil))
+	}
+	_, err := p.tryAddBundle(0, p.signNonce(0, 0, 10e9, nil))
+	require.ErrorIs(t, err, ErrBundleSenderLimit)
+
+	// the limit is per sender
+	p.addBundle(0, p.signNonce(1, 0, 1e9, nil))
+
+	// once the chain is past their max block number the bundles make room
+	p.mine()
+	p.addBundle(0, p.signNonce(0, 0, 10e9, nil))
+	require.Equal(t, 2, p.pool.BundleCount())
+
+	// as do the ones that can no longer be applied
+	p.mine(p.signNonce(1, 0, 2e9, nil))
+	p.addBundle(0, p.signNonce(0, 0, 11e9, nil), p.signNonce(0, 1, 11e9, nil))
+	require.Equal(t, 2, p.pool.BundleCount())
+}
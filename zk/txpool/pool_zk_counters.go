@@ -0,0 +1,377 @@
+package txpool
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/gateway-fm/cdk-erigon-lib/common/cmp"
+	"github.com/gateway-fm/cdk-erigon-lib/common/fixedgas"
+	"github.com/gateway-fm/cdk-erigon-lib/gointerfaces"
+	"github.com/gateway-fm/cdk-erigon-lib/gointerfaces/remote"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/types"
+	"github.com/ledgerwatch/log/v3"
+)
+
+const (
+	estimateCountersEvery = 250 * time.Millisecond
+
+	// maxCounterEstimatesPerRound limits how many transactions without an estimate are simulated at once, the
+	// transactions of their senders with lower nonces are simulated alongside them
+	maxCounterEstimatesPerRound = 256
+)
+
+// ZkCounters are amounts of the zk virtual counters keyed by their short names, the keys of vm.Counters.UsedAsMap
+type ZkCounters map[string]int
+
+// CounterEstimate is what a transaction is expected to use once it is sequenced
+type CounterEstimate struct {
+	Counters ZkCounters
+	GasUsed  uint64
+}
+
+// CounterEstimator pre-simulates transactions against recent state.  The transactions are executed in order on the
+// same state so each one sees the effects of those before it, the estimate of a transaction that fails is nil.
+type CounterEstimator interface {
+	EstimateCounters(ctx context.Context, txs [][]byte) ([]*CounterEstimate, error)
+}
+
+// SetCounterEstimator enables the pre-simulation of pending transactions, their estimates are used by
+// YieldBestWithCounters to pack batches
+func (p *TxPool) SetCounterEstimator(estimator CounterEstimator) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.counterEstimator = estimator
+}
+
+func counterEstimationLoop(ctx context.Context, db kv.RoDB, p *TxPool) {
+	estimateEvery := time.NewTicker(estimateCountersEvery)
+	defer estimateEvery.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-estimateEvery.C:
+			if !p.Started() {
+				continue
+			}
+			if err := p.estimatePendingCounters(ctx, db); err != nil {
+				log.Warn("[txpool] estimate counters", "err", err)
+			}
+		}
+	}
+}
+
+// estimatePendingCounters simulates the best pending transactions that don't have an estimate yet
+func (p *TxPool) estimatePendingCounters(ctx context.Context, db kv.RoDB) error {
+	var estimator CounterEstimator
+	var toEstimate []*metaTx
+	var rlpTxs [][]byte
+
+	if err := db.View(ctx, func(tx kv.Tx) error {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+
+		estimator = p.counterEstimator
+		if estimator == nil {
+			return nil
+		}
+
+		included := map[*metaTx]struct{}{}
+		missing := 0
+		for _, mt := range p.pending.best.ms {
+			if missing >= maxCounterEstimatesPerRound {
+				break
+			}
+			if mt.counterEstimate != nil || mt.counterEstimateFailed {
+				continue
+			}
+			missing++
+
+			// the transactions of the sender with lower nonces run first so the nonce and balance are right
+			var err error
+			p.all.ascend(mt.Tx.SenderID, func(prev *metaTx) bool {
+				if prev.Tx.Nonce > mt.Tx.Nonce {
+					return false
+				}
+				if _, ok := included[prev]; ok || prev.currentSubPool != PendingSubPool {
+					return true
+				}
+				var rlpTx []byte
+				if rlpTx, _, _, err = p.getRlpLocked(tx, prev.Tx.IDHash[:]); err != nil {
+					return false
+				}
+				if len(rlpTx) == 0 {
+					return true
+				}
+				included[prev] = struct{}{}
+				toEstimate = append(toEstimate, prev)
+				rlpTxs = append(rlpTxs, rlpTx)
+				return true
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(toEstimate) == 0 {
+		return nil
+	}
+
+	estimates, err := estimator.EstimateCounters(ctx, rlpTxs)
+	if err != nil {
+		return err
+	}
+	if len(estimates) != len(toEstimate) {
+		return fmt.Errorf("expected %d counter estimates, got %d", len(toEstimate), len(estimates))
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for i, mt := range toEstimate {
+		mt.counterEstimate = estimates[i]
+		mt.counterEstimateFailed = estimates[i] == nil
+	}
+	return nil
+}
+
+// clearCounterEstimatesLocked drops the estimates of the senders whose state changed in the block or whose transactions
+// were unwound, failed ones included, so their transactions are simulated again on the new state
+func (p *TxPool) clearCounterEstimatesLocked(stateChanges *remote.StateChangeBatch, unwindTxs types.TxSlots) {
+	changed := map[uint64]struct{}{}
+	for _, changesList := range stateChanges.ChangeBatch {
+		for _, change := range changesList.Changes {
+			if id, ok := p.senders.getID(gointerfaces.ConvertH160toAddress(change.Address)); ok {
+				changed[id] = struct{}{}
+			}
+		}
+	}
+	for _, txn := range unwindTxs.Txs {
+		changed[txn.SenderID] = struct{}{}
+	}
+
+	for senderID := range changed {
+		p.all.ascend(senderID, func(mt *metaTx) bool {
+			mt.counterEstimate = nil
+			mt.counterEstimateFailed = false
+			return true
+		})
+	}
+}
+
+// YieldBestWithCounters is YieldBest packing the transactions into the counters left in the batch.  Transactions
+// with an estimate are picked by fee per share of the remaining counters as long as they fit, the ones without
+// follow in the usual order and are only checked by the sequencer when executed.
+func (p *TxPool) YieldBestWithCounters(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableGas uint64, availableCounters ZkCounters, toSkip mapset.Set[[32]byte]) (bool, int, error) {
+	if availableCounters == nil {
+		return p.best(n, txs, tx, onTopOf, availableGas, toSkip, true)
+	}
+	return p.bestWithCounters(n, txs, tx, onTopOf, availableGas, availableCounters, toSkip)
+}
+
+type counterCandidate struct {
+	mt      *metaTx
+	density float64
+	order   int // in the pending sub pool
+}
+
+// counterCandidates is a heap of the transactions ready to be yielded, estimated ones first by density and the rest
+// in the pending order
+type counterCandidates []*counterCandidate
+
+func (c counterCandidates) Len() int { return len(c) }
+func (c counterCandidates) Less(i, j int) bool {
+	iEstimated, jEstimated := c[i].mt.counterEstimate != nil, c[j].mt.counterEstimate != nil
+	if iEstimated != jEstimated {
+		return iEstimated
+	}
+	if c[i].density != c[j].density {
+		return c[i].density > c[j].density
+	}
+	return c[i].order < c[j].order
+}
+func (c counterCandidates) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+func (c *counterCandidates) Push(x interface{}) {
+	*c = append(*c, x.(*counterCandidate))
+}
+func (c *counterCandidates) Pop() interface{} {
+	old := *c
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*c = old[0 : n-1]
+	return item
+}
+
+type senderNonce struct {
+	senderID uint64
+	nonce    uint64
+}
+
+func (p *TxPool) bestWithCounters(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableGas uint64, availableCounters ZkCounters, toSkip mapset.Set[[32]byte]) (bool, int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// First wait for the corresponding block to arrive
+	if p.lastSeenBlock.Load() < onTopOf {
+		return false, 0, nil // Too early
+	}
+
+	isShanghai := p.isShanghai()
+	isLondon := p.isLondon()
+	best := p.pending.best
+	pendingBaseFee := p.pendingBaseFee.Load()
+
+	var toRemove []*metaTx
+	var all []*counterCandidate
+	for i, mt := range best.ms {
+		if toSkip.Contains(mt.Tx.IDHash) {
+			continue
+		}
+		if !isLondon && mt.Tx.Type == 0x2 {
+			// remove ldn txs when not in london
+			toRemove = append(toRemove, mt)
+			toSkip.Add(mt.Tx.IDHash)
+			continue
+		}
+		if mt.Tx.Gas >= transactionGasLimit {
+			// Skip transactions with very large gas limit, these shouldn't enter the pool at all
+			continue
+		}
+		all = append(all, &counterCandidate{mt: mt, density: counterDensity(mt, pendingBaseFee, availableCounters), order: i})
+	}
+
+	// the transactions waiting for the previous nonce of their sender join the candidates once it is yielded
+	candidates := make(counterCandidates, 0, len(all))
+	waiting := map[senderNonce]*counterCandidate{}
+	for _, c := range all {
+		if p.previousNonceYielded(c.mt, toSkip) {
+			candidates = append(candidates, c)
+		} else {
+			waiting[senderNonce{c.mt.Tx.SenderID, c.mt.Tx.Nonce}] = c
+		}
+	}
+	heap.Init(&candidates)
+
+	txs.Resize(uint(cmp.Min(int(n), len(all))))
+	usedCounters := ZkCounters{}
+	count := 0
+
+	for count < int(n) && availableGas >= fixedgas.TxGas && candidates.Len() > 0 {
+		mt := heap.Pop(&candidates).(*counterCandidate).mt
+
+		if mt.counterEstimate != nil && !countersFit(mt.counterEstimate.Counters, usedCounters, availableCounters) {
+			mt.skipped(skippedNoCountersLeft)
+			continue
+		}
+
+		// make sure we have enough gas in the caller to add this transaction.
+		// not an exact science using intrinsic gas but as close as we could hope for at
+		// this stage
+		intrinsicGas, _ := CalcIntrinsicGas(uint64(mt.Tx.DataLen), uint64(mt.Tx.DataNonZeroLen), nil, mt.Tx.Creation, true, true, isShanghai)
+		if intrinsicGas > availableGas {
+			mt.skipped(skippedNoGasLeft)
+			continue
+		}
+
+		rlpTx, sender, isLocal, err := p.getRlpLocked(tx, mt.Tx.IDHash[:])
+		if err != nil {
+			return false, count, err
+		}
+		if len(rlpTx) == 0 {
+			toRemove = append(toRemove, mt)
+			continue
+		}
+
+		availableGas -= intrinsicGas
+		if mt.counterEstimate != nil {
+			for k, v := range mt.counterEstimate.Counters {
+				usedCounters[k] += v
+			}
+		}
+
+		txs.Txs[count] = rlpTx
+		copy(txs.Senders.At(count), sender.Bytes())
+		txs.IsLocal[count] = isLocal
+		toSkip.Add(mt.Tx.IDHash)
+		p.markYieldedLocked(mt)
+		count++
+
+		next := senderNonce{mt.Tx.SenderID, mt.Tx.Nonce + 1}
+		if c, ok := waiting[next]; ok {
+			delete(waiting, next)
+			heap.Push(&candidates, c)
+		}
+	}
+
+	txs.Resize(uint(count))
+	for _, mt := range toRemove {
+		p.pending.Remove(mt)
+	}
+	return true, count, nil
+}
+
+// previousNonceYielded checks the transaction doesn't jump ahead of a pending one from the same sender
+func (p *TxPool) previousNonceYielded(mt *metaTx, yielded mapset.Set[[32]byte]) bool {
+	if mt.nonceDistance == 0 || mt.Tx.Nonce == 0 {
+		return true
+	}
+	prev := p.all.get(mt.Tx.SenderID, mt.Tx.Nonce-1)
+	return prev == nil || yielded.Contains(prev.Tx.IDHash)
+}
+
+// counterDensity is the fee a transaction pays for the largest share of a remaining counter it uses
+func counterDensity(mt *metaTx, pendingBaseFee uint64, availableCounters ZkCounters) float64 {
+	if mt.counterEstimate == nil {
+		return 0
+	}
+
+	tip := mt.minTip
+	if mt.minFeeCap.IsUint64() {
+		if feeCap := mt.minFeeCap.Uint64(); feeCap < pendingBaseFee {
+			tip = 0
+		} else if feeCap-pendingBaseFee < tip {
+			tip = feeCap - pendingBaseFee
+		}
+	}
+	gas := mt.counterEstimate.GasUsed
+	if gas == 0 {
+		gas = mt.Tx.Gas
+	}
+	fee := float64(tip) * float64(gas)
+
+	share := 0.0
+	for k, used := range mt.counterEstimate.Counters {
+		available, ok := availableCounters[k]
+		if !ok || used <= 0 {
+			continue
+		}
+		if available <= 0 {
+			return 0
+		}
+		share = math.Max(share, float64(used)/float64(available))
+	}
+	if share == 0 {
+		return math.Inf(1)
+	}
+	return fee / share
+}
+
+func countersFit(counters, used, available ZkCounters) bool {
+	for k, v := range counters {
+		limit, ok := available[k]
+		if ok && used[k]+v > limit {
+			return false
+		}
+	}
+	return true
+}
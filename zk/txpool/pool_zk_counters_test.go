@@ -0,0 +1,197 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/gointerfaces"
+	"github.com/gateway-fm/cdk-erigon-lib/gointerfaces/remote"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/types"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	types2 "github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/crypto"
+)
+
+func TestCounterDensity(t *testing.T) {
+	estimated := func(tip, feeCap, gasUsed uint64, counters ZkCounters) *metaTx {
+		return &metaTx{
+			Tx:              &types.TxSlot{Gas: 50_000},
+			minTip:          tip,
+			minFeeCap:       *uint256.NewInt(feeCap),
+			counterEstimate: &CounterEstimate{Counters: counters, GasUsed: gasUsed},
+		}
+	}
+
+	available := ZkCounters{"S": 100, "K": 10}
+	tests := []struct {
+		name      string
+		mt        *metaTx
+		baseFee   uint64
+		available ZkCounters
+		density   float64
+	}{
+		{"not estimated", &metaTx{Tx: &types.TxSlot{}}, 0, available, 0},
+		{"largest share", estimated(2, 2, 21_000, ZkCounters{"S": 10, "K": 5}), 0, available, 2 * 21_000 / 0.5},
+		{"gas limit without gas used", estimated(2, 2, 0, ZkCounters{"S": 50}), 0, available, 2 * 50_000 / 0.5},
+		{"tip capped by the base fee", estimated(5, 7, 21_000, ZkCounters{"S": 50}), 4, available, 3 * 21_000 / 0.5},
+		{"fee cap below the base fee", estimated(5, 7, 21_000, ZkCounters{"S": 50}), 10, available, 0},
+		{"counters not limited", estimated(2, 2, 21_000, ZkCounters{"P": 10}), 0, available, math.Inf(1)},
+		{"counter used up", estimated(2, 2, 21_000, ZkCounters{"S": 10, "K": 1}), 0, ZkCounters{"S": 100, "K": 0}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.density, counterDensity(tt.mt, tt.baseFee, tt.available))
+		})
+	}
+}
+
+func TestCountersFit(t *testing.T) {
+	available := ZkCounters{"S": 100, "K": 10}
+	require.True(t, countersFit(ZkCounters{"S": 50, "K": 5}, ZkCounters{"S": 50, "K": 5}, available))
+	require.False(t, countersFit(ZkCounters{"S": 51}, ZkCounters{"S": 50}, available))
+	require.False(t, countersFit(ZkCounters{"K": 1}, ZkCounters{"K": 10}, available))
+	// counters without a limit never overflow
+	require.True(t, countersFit(ZkCounters{"P": 1_000}, ZkCounters{"P": 1_000}, available))
+}
+
+// estimate sets what the transactions are expected to use as the counter estimator would
+func estimate(p *testPool, counters map[types2.Transaction]ZkCounters) {
+	for txn, c := range counters {
+		mt := p.metaTx(txn)
+		require.NotNil(p.t, mt)
+		p.pool.lock.Lock()
+		mt.counterEstimate = &CounterEstimate{Counters: c, GasUsed: 21_000}
+		p.pool.lock.Unlock()
+	}
+}
+
+func yieldWithCounters(p *testPool, available ZkCounters) []libcommon.Hash {
+	var slots types.TxsRlp
+	p.view(func(tx kv.Tx) error {
+		_, _, err := p.pool.YieldBestWithCounters(100, &slots, tx, p.head, 30_000_000, available, mapset.NewSet[[32]byte]())
+		return err
+	})
+	var yielded []libcommon.Hash
+	for _, rlp := range slots.Txs {
+		yielded = append(yielded, crypto.Keccak256Hash(rlp))
+	}
+	return yielded
+}
+
+func TestYieldBestWithCounters_NonceOrder(t *testing.T) {
+	p := newTestPool(t, 3)
+	first, second := p.sign(0, 1e9, nil), p.sign(0, 1e9, nil)
+	other := p.sign(1, 1e9, nil)
+	notEstimated := p.sign(2, 1e9, nil)
+	p.add(first, second, other, notEstimated)
+
+	// the second transaction of the sender is the densest but can't go before the first one
+	estimate(p, map[types2.Transaction]ZkCounters{
+		first:  {"S": 50},
+		second: {"S": 1},
+		other:  {"S": 10},
+	})
+	yielded := yieldWithCounters(p, ZkCounters{"S": 100})
+	require.Equal(t, []libcommon.Hash{other.Hash(), first.Hash(), second.Hash(), notEstimated.Hash()}, yielded)
+}
+
+func TestYieldBestWithCounters_Overflow(t *testing.T) {
+	p := newTestPool(t, 2)
+	first, second := p.sign(0, 1e9, nil), p.sign(0, 1e9, nil)
+	other := p.sign(1, 1e9, nil)
+	p.add(first, second, other)
+
+	// the first transaction of the sender doesn't fit once the densest is in, so neither does the second
+	estimate(p, map[types2.Transaction]ZkCounters{
+		first:  {"S": 95},
+		second: {"S": 1},
+		other:  {"S": 10},
+	})
+	yielded := yieldWithCounters(p, ZkCounters{"S": 100})
+	require.Equal(t, []libcommon.Hash{other.Hash()}, yielded)
+	require.Equal(t, skippedNoCountersLeft, p.metaTx(first).lastSkipReason)
+}
+
+type fakeEstimator struct {
+	calls     [][][]byte
+	estimates func(rlp []byte) *CounterEstimate
+	err       error
+}
+
+func (e *fakeEstimator) EstimateCounters(_ context.Context, txs [][]byte) ([]*CounterEstimate, error) {
+	e.calls = append(e.calls, txs)
+	if e.err != nil {
+		return nil, e.err
+	}
+	estimates := make([]*CounterEstimate, len(txs))
+	for i, rlp := range txs {
+		estimates[i] = e.estimates(rlp)
+	}
+	return estimates, nil
+}
+
+func TestEstimatePendingCounters(t *testing.T) {
+	p := newTestPool(t, 2)
+	first, second := p.sign(0, 1e9, nil), p.sign(0, 2e9, nil)
+	failing := p.sign(1, 1e9, nil)
+	p.add(first, second, failing)
+
+	estimator := &fakeEstimator{estimates: func(rlp []byte) *CounterEstimate {
+		if crypto.Keccak256Hash(rlp) == failing.Hash() {
+			return nil
+		}
+		return &CounterEstimate{Counters: ZkCounters{"S": len(rlp)}, GasUsed: 21_000}
+	}}
+
+	// nothing is simulated without an estimator
+	require.NoError(t, p.pool.estimatePendingCounters(p.ctx, p.poolDB))
+	p.pool.SetCounterEstimator(estimator)
+
+	estimator.err = errors.New("no state")
+	require.ErrorIs(t, p.pool.estimatePendingCounters(p.ctx, p.poolDB), estimator.err)
+	require.Nil(t, p.metaTx(first).counterEstimate)
+
+	estimator.err = nil
+	require.NoError(t, p.pool.estimatePendingCounters(p.ctx, p.poolDB))
+	require.Len(t, estimator.calls, 2)
+	require.Len(t, estimator.calls[1], 3)
+
+	// the transactions of a sender are simulated in nonce order, even when the later one pays more
+	var simulated []libcommon.Hash
+	for _, rlp := range estimator.calls[1] {
+		if hash := crypto.Keccak256Hash(rlp); hash != failing.Hash() {
+			simulated = append(simulated, hash)
+		}
+	}
+	require.Equal(t, []libcommon.Hash{first.Hash(), second.Hash()}, simulated)
+
+	require.NotNil(t, p.metaTx(first).counterEstimate)
+	require.NotNil(t, p.metaTx(second).counterEstimate)
+	require.Nil(t, p.metaTx(failing).counterEstimate)
+	require.True(t, p.metaTx(failing).counterEstimateFailed)
+
+	// neither the estimated nor the failed ones are simulated again
+	require.NoError(t, p.pool.estimatePendingCounters(p.ctx, p.poolDB))
+	require.Len(t, estimator.calls, 2)
+
+	// until the state of their sender changes, the other sender's estimates are kept
+	p.onNewBlock(&remote.StateChange{BlockHeight: p.head, Changes: []*remote.AccountChange{{
+		Action:  remote.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(p.senders[1]),
+		Data:    encodeSender(p.mined[p.senders[1]]),
+	}}}, types.TxSlots{})
+	require.False(t, p.metaTx(failing).counterEstimateFailed)
+	require.NotNil(t, p.metaTx(first).counterEstimate)
+
+	require.NoError(t, p.pool.estimatePendingCounters(p.ctx, p.poolDB))
+	require.Len(t, estimator.calls, 3)
+	require.Len(t, estimator.calls[2], 1)
+	require.True(t, p.metaTx(failing).counterEstimateFailed)
+}
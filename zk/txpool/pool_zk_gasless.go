@@ -0,0 +1,155 @@
+package txpool
+
+import (
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/gointerfaces/remote"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/types"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	types2 "github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/zk/gasless"
+)
+
+var PoolGaslessQuotasKey = []byte("gasless_quotas")
+
+var (
+	notSponsoredCounter       = metrics.GetOrCreateCounter(`txpool_rejected_not_sponsored`)
+	gaslessQuotaRejectCounter = metrics.GetOrCreateCounter(`txpool_rejected_gasless_quota`)
+)
+
+// checkGasless decides if a zero priced transaction is sponsored by the gasless policy, local transactions included.
+// The daily quota of the matching rule is checked against the gas already sponsored for the sender today and the gas
+// limits of its other zero priced transactions in the pool.  Without a policy zero priced transactions are left to the
+// other checks.
+func (p *TxPool) checkGasless(txn *types.TxSlot) (sponsored bool, reason DiscardReason) {
+	if !p.gaslessPolicy.Enabled() || !txn.FeeCap.IsZero() {
+		return false, Success
+	}
+
+	sender, ok := p.senders.senderID2Addr[txn.SenderID]
+	if !ok || txn.Rlp == nil {
+		notSponsoredCounter.Inc()
+		return false, NotSponsored
+	}
+	transaction, err := types2.UnmarshalTransactionFromBinary(txn.Rlp)
+	if err != nil {
+		notSponsoredCounter.Inc()
+		return false, NotSponsored
+	}
+	rule := p.gaslessPolicy.Match(sender, transaction.GetTo(), transaction.GetData())
+	if rule == nil {
+		notSponsoredCounter.Inc()
+		return false, NotSponsored
+	}
+	if rule.DailyGasQuota == 0 {
+		return true, Success
+	}
+
+	used := p.gaslessQuotas.Used(gasless.Day(time.Now()), sender)
+	replaced := p.all.get(txn.SenderID, txn.Nonce)
+	p.all.ascend(txn.SenderID, func(mt *metaTx) bool {
+		if mt != replaced && mt.Tx.FeeCap.IsZero() {
+			used += mt.Tx.Gas
+		}
+		return true
+	})
+	if used+txn.Gas > rule.DailyGasQuota {
+		gaslessQuotaRejectCounter.Inc()
+		return false, GaslessQuotaReached
+	}
+	return true, Success
+}
+
+// onNewBlockGasless charges the zero priced transactions of a new block to the daily quotas of their senders and
+// refunds those of unwound blocks, so they can be sent again.  The mined transactions whose block couldn't be read are
+// charged their gas limit.
+func (p *TxPool) onNewBlockGasless(coreTx kv.Tx, stateChanges *remote.StateChangeBatch, unwindTxs, minedTxs types.TxSlots) error {
+	if !p.gaslessPolicy.Enabled() {
+		return nil
+	}
+	for _, txn := range unwindTxs.Txs {
+		p.gaslessQuotas.Refund(txn.IDHash)
+	}
+	for _, change := range stateChanges.ChangeBatch {
+		if change.Direction != remote.Direction_FORWARD {
+			continue
+		}
+		if err := p.chargeGaslessBlock(coreTx, change.BlockHeight); err != nil {
+			return err
+		}
+	}
+	today := gasless.Day(time.Now())
+	for i, txn := range minedTxs.Txs {
+		if txn.FeeCap.IsZero() {
+			p.gaslessQuotas.Charge(txn.IDHash, today, minedTxs.Senders.AddressAt(i), txn.Gas)
+		}
+	}
+	p.gaslessQuotas.Prune(today)
+	return nil
+}
+
+// chargeGaslessBlock charges the zero priced transactions of a canonical block the gas their receipts say they used,
+// on the day of the block.  The blocks sequenced since the last flush are charged again when the pool is loaded, the
+// charges are only written with it.
+func (p *TxPool) chargeGaslessBlock(coreTx kv.Tx, number uint64) error {
+	if !p.gaslessPolicy.Enabled() {
+		return nil
+	}
+	hash, err := rawdb.ReadCanonicalHash(coreTx, number)
+	if err != nil {
+		return err
+	}
+	if hash == (libcommon.Hash{}) {
+		return nil
+	}
+	block, senders, err := rawdb.ReadBlockWithSenders(coreTx, hash, number)
+	if err != nil {
+		return err
+	}
+	if block == nil || len(senders) != len(block.Transactions()) {
+		return nil
+	}
+
+	var receipts types2.Receipts
+	day := gasless.Day(time.Unix(int64(block.Time()), 0))
+	for i, txn := range block.Transactions() {
+		if !txn.GetFeeCap().IsZero() {
+			continue
+		}
+		if receipts == nil {
+			receipts = rawdb.ReadReceipts_zkEvm(coreTx, block, senders)
+		}
+		gas := txn.GetGas()
+		if i < len(receipts) {
+			gas = receipts[i].GasUsed
+		}
+		p.gaslessQuotas.Charge(txn.Hash(), day, senders[i], gas)
+	}
+	return nil
+}
+
+func (p *TxPool) flushGaslessQuotas(tx kv.RwTx) error {
+	if !p.gaslessPolicy.Enabled() {
+		return nil
+	}
+	v, err := p.gaslessQuotas.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return tx.Put(kv.PoolInfo, PoolGaslessQuotasKey, v)
+}
+
+func (p *TxPool) gaslessQuotasFromDB(tx kv.Tx) error {
+	if !p.gaslessPolicy.Enabled() {
+		return nil
+	}
+	v, err := tx.GetOne(kv.PoolInfo, PoolGaslessQuotasKey)
+	if err != nil {
+		return err
+	}
+	return p.gaslessQuotas.UnmarshalBinary(v)
+}
@@ -0,0 +1,72 @@
+package txpool
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	types2 "github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/zk/gasless"
+)
+
+func newGaslessTestPool(t *testing.T) *testPool {
+	p := newTestPool(t, 1)
+	p.ethCfg.GaslessPolicy = &gasless.Policy{Rules: []gasless.Rule{{Name: "any", DailyGasQuota: 1_000_000}}}
+	p.restart()
+	return p
+}
+
+// mineGasless mines a block of today with the transactions, each using the gas given in its receipt
+func (p *testPool) mineGasless(txns []types2.Transaction, gasUsed []uint64) {
+	parent, err := p.readCanonicalHash(p.head)
+	require.NoError(p.t, err)
+	header := &types2.Header{Number: new(big.Int).SetUint64(p.head + 1), ParentHash: parent, Time: uint64(time.Now().Unix())}
+	var receipts types2.Receipts
+	var cumulative uint64
+	for _, gas := range gasUsed {
+		cumulative += gas
+		receipts = append(receipts, &types2.Receipt{Status: types2.ReceiptStatusSuccessful, CumulativeGasUsed: cumulative})
+	}
+	require.NoError(p.t, p.coreDB.Update(p.ctx, func(tx kv.RwTx) error {
+		return rawdb.WriteReceipts(tx, header.Number.Uint64(), receipts)
+	}))
+	p.mineBlock(types2.NewBlock(header, txns, nil, nil, nil))
+}
+
+func (p *testPool) gaslessUsed() uint64 {
+	p.pool.lock.Lock()
+	defer p.pool.lock.Unlock()
+	return p.pool.gaslessQuotas.Used(gasless.Day(time.Now()), p.senders[0])
+}
+
+func TestGasless_ChargesGasUsed(t *testing.T) {
+	p := newGaslessTestPool(t)
+	sponsored := p.sign(0, 0, []byte{1})
+	paid := p.sign(0, 1e9, []byte{1})
+	p.mineGasless([]types2.Transaction{sponsored, paid}, []uint64{40_000, 30_000})
+
+	// only the zero priced one is charged, with the gas it used rather than its limit of 100k
+	require.Equal(t, uint64(40_000), p.gaslessUsed())
+}
+
+func TestGasless_ChargesSurviveCrash(t *testing.T) {
+	p := newGaslessTestPool(t)
+	p.mineGasless([]types2.Transaction{p.sign(0, 0, []byte{1})}, []uint64{40_000})
+	p.flush()
+
+	// the sequencer is killed before the pool flushes the charges of the next blocks, they are read back from the
+	// chain when it is loaded again
+	p.mineGasless([]types2.Transaction{p.sign(0, 0, []byte{1})}, []uint64{50_000})
+	p.mineGasless([]types2.Transaction{p.sign(0, 0, []byte{1})}, []uint64{10_000})
+	require.Equal(t, uint64(100_000), p.gaslessUsed())
+	p.restart()
+	require.Equal(t, uint64(100_000), p.gaslessUsed())
+
+	p.flush()
+	p.restart()
+	require.Equal(t, uint64(100_000), p.gaslessUsed())
+}
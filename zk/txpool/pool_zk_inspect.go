@@ -0,0 +1,161 @@
+package txpool
+
+import (
+	"time"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/holiman/uint256"
+
+	types2 "github.com/ledgerwatch/erigon/core/types"
+)
+
+// the reasons a transaction is passed over while yielding, the last one is kept to explain why it is stuck
+const (
+	skippedNoGasLeft      = "not enough gas left in the block"
+	skippedNoCountersLeft = "not enough counters left in the batch"
+)
+
+// the reasons a transaction is not being included
+const (
+	BlockerNonceGap            = "nonce gap"
+	BlockerUnderpriced         = "underpriced"
+	BlockerInsufficientBalance = "insufficient balance"
+	BlockerGasLimitTooHigh     = "gas limit too high"
+	BlockerCounterOverflow     = "counter overflow"
+	BlockerSimulationFailed    = "fails when simulated"
+)
+
+// TxInspection is what the pool knows about a transaction and why it is not being included
+type TxInspection struct {
+	Hash    libcommon.Hash
+	Sender  libcommon.Address
+	Nonce   uint64
+	SubPool SubPoolType
+	IsLocal bool
+	Arrived time.Time
+	FeeCap  uint256.Int
+	Tip     uint256.Int
+	Gas     uint64
+
+	// EffectiveGasPricePercentage is derived as the sequencer does, 0 when the transaction can't be decoded
+	EffectiveGasPricePercentage uint8
+	CounterEstimate             *CounterEstimate // nil until the transaction is simulated
+
+	Yielded        uint64
+	Skipped        uint64
+	LastSkipReason string
+
+	Blockers []string
+}
+
+func (mt *metaTx) skipped(reason string) {
+	mt.skipCount++
+	mt.lastSkipReason = reason
+}
+
+// InspectFilter narrows Inspect down to the transactions of a sender or to a single transaction
+type InspectFilter struct {
+	Sender *libcommon.Address
+	Hash   *libcommon.Hash
+}
+
+// Inspect describes the transactions in the pool matching the filter but the private ones, the pool database is
+// needed for the transactions already flushed
+func (p *TxPool) Inspect(tx kv.Tx, filter InspectFilter) ([]TxInspection, error) {
+	inspections, rlps, err := p.inspect(tx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if p.zkCfg == nil {
+		return inspections, nil
+	}
+	// decoded out of the lock, the rlps stay valid for as long as the db transaction
+	for i, rlpTx := range rlps {
+		if len(rlpTx) == 0 {
+			continue
+		}
+		if transaction, err := types2.UnmarshalTransactionFromBinary(rlpTx); err == nil {
+			inspections[i].EffectiveGasPricePercentage = p.zkCfg.EffectiveGasPricePercentage(transaction.GetTo(), transaction.GetData())
+		}
+	}
+	return inspections, nil
+}
+
+func (p *TxPool) inspect(tx kv.Tx, filter InspectFilter) ([]TxInspection, [][]byte, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	pendingBaseFee := uint256.NewInt(p.pendingBaseFee.Load())
+	var inspections []TxInspection
+	var rlps [][]byte
+	var err error
+	visit := func(mt *metaTx) bool {
+		if p.hiddenLocked(string(mt.Tx.IDHash[:])) {
+			return true
+		}
+		var rlpTx []byte
+		if rlpTx, _, _, err = p.getRlpLocked(tx, mt.Tx.IDHash[:]); err != nil {
+			return false
+		}
+		inspections = append(inspections, TxInspection{
+			Hash:            mt.Tx.IDHash,
+			Sender:          p.senders.senderID2Addr[mt.Tx.SenderID],
+			Nonce:           mt.Tx.Nonce,
+			SubPool:         mt.currentSubPool,
+			IsLocal:         mt.subPool&IsLocal > 0,
+			Arrived:         mt.created,
+			FeeCap:          mt.Tx.FeeCap,
+			Tip:             mt.Tx.Tip,
+			Gas:             mt.Tx.Gas,
+			CounterEstimate: mt.counterEstimate,
+			Yielded:         mt.yieldCount,
+			Skipped:         mt.skipCount,
+			LastSkipReason:  mt.lastSkipReason,
+			Blockers:        blockers(mt, pendingBaseFee),
+		})
+		rlps = append(rlps, rlpTx)
+		return true
+	}
+
+	switch {
+	case filter.Hash != nil:
+		mt, ok := p.byHash[string(filter.Hash[:])]
+		if ok && (filter.Sender == nil || *filter.Sender == p.senders.senderID2Addr[mt.Tx.SenderID]) {
+			visit(mt)
+		}
+	case filter.Sender != nil:
+		if senderID, ok := p.senders.getID(*filter.Sender); ok {
+			p.all.ascend(senderID, visit)
+		}
+	default:
+		p.all.ascendAll(visit)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return inspections, rlps, nil
+}
+
+func blockers(mt *metaTx, pendingBaseFee *uint256.Int) []string {
+	var reasons []string
+	if mt.subPool&NoNonceGaps == 0 {
+		reasons = append(reasons, BlockerNonceGap)
+	}
+	if mt.subPool&EnoughFeeCapProtocol == 0 || mt.minFeeCap.Lt(pendingBaseFee) {
+		reasons = append(reasons, BlockerUnderpriced)
+	}
+	if mt.subPool&EnoughBalance == 0 {
+		reasons = append(reasons, BlockerInsufficientBalance)
+	}
+	if mt.subPool&NotTooMuchGas == 0 {
+		reasons = append(reasons, BlockerGasLimitTooHigh)
+	}
+	if mt.overflowZkCountersDuringExecution || mt.lastSkipReason == skippedNoCountersLeft {
+		reasons = append(reasons, BlockerCounterOverflow)
+	}
+	if mt.counterEstimateFailed {
+		reasons = append(reasons, BlockerSimulationFailed)
+	}
+	return reasons
+}
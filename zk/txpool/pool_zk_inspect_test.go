@@ -0,0 +1,98 @@
+package txpool
+
+import (
+	"testing"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/stretchr/testify/require"
+)
+
+func inspect(p *testPool, filter InspectFilter) (inspections []TxInspection) {
+	p.view(func(tx kv.Tx) (err error) {
+		inspections, err = p.pool.Inspect(tx, filter)
+		return err
+	})
+	return inspections
+}
+
+func inspectedHashes(inspections []TxInspection) []libcommon.Hash {
+	var hashes []libcommon.Hash
+	for _, inspection := range inspections {
+		hashes = append(hashes, inspection.Hash)
+	}
+	return hashes
+}
+
+func TestInspect_Filter(t *testing.T) {
+	p := newTestPool(t, 3)
+	first, second := p.sign(0, 1e9, nil), p.sign(0, 1e9, nil)
+	other := p.sign(1, 1e9, nil)
+	p.add(first, second, other)
+
+	require.Len(t, inspect(p, InspectFilter{}), 3)
+
+	sender := p.senders[0]
+	require.Equal(t, []libcommon.Hash{first.Hash(), second.Hash()}, inspectedHashes(inspect(p, InspectFilter{Sender: &sender})))
+
+	hash := other.Hash()
+	require.Equal(t, []libcommon.Hash{hash}, inspectedHashes(inspect(p, InspectFilter{Hash: &hash})))
+	// both have to match
+	require.Empty(t, inspect(p, InspectFilter{Sender: &sender, Hash: &hash}))
+
+	// a sender without transactions in the pool
+	unknown := p.senders[2]
+	require.Empty(t, inspect(p, InspectFilter{Sender: &unknown}))
+	missing := libcommon.Hash{1}
+	require.Empty(t, inspect(p, InspectFilter{Hash: &missing}))
+}
+
+func TestInspect_Flushed(t *testing.T) {
+	p := newTestPool(t, 1)
+	p.ethCfg.Zk.EffectiveGasPriceForEthTransfer = 100
+	p.ethCfg.Zk.EffectiveGasPriceForContractInvocation = 200
+	p.restart()
+	transfer, call := p.sign(0, 1e9, nil), p.sign(0, 1e9, []byte{0x01})
+	p.add(transfer, call)
+
+	// the rlps are read from the pool db once flushed
+	p.flush()
+	inspections := inspect(p, InspectFilter{})
+	require.Len(t, inspections, 2)
+	require.Equal(t, uint8(100), inspections[0].EffectiveGasPricePercentage)
+	require.Equal(t, uint8(200), inspections[1].EffectiveGasPricePercentage)
+	require.Equal(t, p.senders[0], inspections[0].Sender)
+	require.Equal(t, uint64(1), inspections[1].Nonce)
+}
+
+func TestInspect_Blockers(t *testing.T) {
+	p := newTestPool(t, 1)
+	pending := p.sign(0, 1e9, nil)
+	gapped := p.signNonce(0, 5, 1e9, nil)
+	p.add(pending, gapped)
+
+	hash := gapped.Hash()
+	inspections := inspect(p, InspectFilter{Hash: &hash})
+	require.Len(t, inspections, 1)
+	require.Equal(t, QueuedSubPool, inspections[0].SubPool)
+	require.Equal(t, []string{BlockerNonceGap}, inspections[0].Blockers)
+
+	hash = pending.Hash()
+	require.Empty(t, inspect(p, InspectFilter{Hash: &hash})[0].Blockers)
+}
+
+func TestInspect_Private(t *testing.T) {
+	p := newTestPool(t, 1)
+	public := p.sign(0, 1e9, nil)
+	p.add(public)
+	private := p.sign(0, 1e9, nil)
+	p.view(func(tx kv.Tx) error {
+		_, reason, err := p.pool.AddPrivateTx(p.ctx, tx, p.rlp(private))
+		require.Equal(t, Success, reason)
+		return err
+	})
+
+	require.Equal(t, []libcommon.Hash{public.Hash()}, inspectedHashes(inspect(p, InspectFilter{})))
+	hash := private.Hash()
+	require.Empty(t, inspect(p, InspectFilter{Hash: &hash}))
+}
@@ -0,0 +1,173 @@
+package txpool
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/length"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+)
+
+// PoolJournalKey keeps the last block whose mined transactions were removed from the pool, along with what the pool
+// knew about the transactions it still had, as of the last flush.  A restarted sequencer reads the blocks after it
+// back from the chain so only the transactions not included yet are loaded.
+var PoolJournalKey = []byte("zk_journal")
+
+// journalVersion leads the journal, one written in another format is ignored as if the pool had none
+const journalVersion byte = 1
+
+// journalHeaderSize is the length of the version and the block leading the journal
+const journalHeaderSize = 1 + 8
+
+// maxJournalReplayBlocks bounds the blocks read back when the pool is loaded, further behind the nonce checks alone
+// keep the mined transactions out
+const maxJournalReplayBlocks = 10_000
+
+// journalRecordSize is the length of a journaled transaction: hash, arrival time, time it moved into its sub pool,
+// yield count, skip count, sub pool, flags and skip reason
+const journalRecordSize = length.Hash + 8 + 8 + 8 + 8 + 1 + 1 + 1
+
+const (
+	journalOverflowed byte = 1 << iota
+	journalEstimateFailed
+)
+
+// the skip reasons are journaled by their index
+var journalSkipReasons = []string{"", skippedNoGasLeft, skippedNoCountersLeft}
+
+type journalRecord struct {
+	created        time.Time
+	subPoolSince   time.Time
+	yieldCount     uint64
+	skipCount      uint64
+	subPool        SubPoolType
+	flags          byte
+	lastSkipReason string
+}
+
+func (p *TxPool) flushJournal(tx kv.RwTx) error {
+	// until the pool is loaded the journal in the db is still the one to load from
+	if !p.started.Load() {
+		return nil
+	}
+	v := make([]byte, 0, journalHeaderSize+len(p.byHash)*journalRecordSize)
+	v = append(v, journalVersion)
+	v = binary.BigEndian.AppendUint64(v, p.journalBlock)
+	for _, mt := range p.byHash {
+		var flags byte
+		if mt.overflowZkCountersDuringExecution {
+			flags |= journalOverflowed
+		}
+		if mt.counterEstimateFailed {
+			flags |= journalEstimateFailed
+		}
+		var skipReason byte
+		for i, reason := range journalSkipReasons {
+			if reason == mt.lastSkipReason {
+				skipReason = byte(i)
+			}
+		}
+		v = append(v, mt.Tx.IDHash[:]...)
+		v = binary.BigEndian.AppendUint64(v, uint64(mt.created.UnixNano()))
+		v = binary.BigEndian.AppendUint64(v, uint64(mt.subPoolSince.UnixNano()))
+		v = binary.BigEndian.AppendUint64(v, mt.yieldCount)
+		v = binary.BigEndian.AppendUint64(v, mt.skipCount)
+		v = append(v, byte(mt.currentSubPool), flags, skipReason)
+	}
+	return tx.Put(kv.PoolInfo, PoolJournalKey, v)
+}
+
+// journalFromDB reads the journal and the hashes of the transactions mined in the canonical blocks after it, the
+// pool may have been stopped before it was told about them
+func (p *TxPool) journalFromDB(tx, coreTx kv.Tx) (records map[string]journalRecord, mined map[string]struct{}, err error) {
+	v, err := tx.GetOne(kv.PoolInfo, PoolJournalKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(v) == 0 {
+		return nil, nil, nil
+	}
+	if v[0] != journalVersion {
+		// the nonce checks keep the mined transactions out without it
+		log.Warn("[txpool] ignoring the pool journal written in another format", "version", v[0], "expected", journalVersion)
+		return nil, nil, nil
+	}
+	if len(v) < journalHeaderSize || (len(v)-journalHeaderSize)%journalRecordSize != 0 {
+		return nil, nil, fmt.Errorf("pool journal: unexpected length %d", len(v))
+	}
+	p.journalBlock = binary.BigEndian.Uint64(v[1:])
+
+	records = make(map[string]journalRecord, (len(v)-journalHeaderSize)/journalRecordSize)
+	for pos := journalHeaderSize; pos < len(v); pos += journalRecordSize {
+		record := v[pos : pos+journalRecordSize]
+		r := journalRecord{
+			created:      time.Unix(0, int64(binary.BigEndian.Uint64(record[length.Hash:]))),
+			subPoolSince: time.Unix(0, int64(binary.BigEndian.Uint64(record[length.Hash+8:]))),
+			yieldCount:   binary.BigEndian.Uint64(record[length.Hash+16:]),
+			skipCount:    binary.BigEndian.Uint64(record[length.Hash+24:]),
+			subPool:      SubPoolType(record[length.Hash+32]),
+			flags:        record[length.Hash+33],
+		}
+		if reason := int(record[length.Hash+34]); reason < len(journalSkipReasons) {
+			r.lastSkipReason = journalSkipReasons[reason]
+		}
+		records[string(record[:length.Hash])] = r
+	}
+
+	mined = map[string]struct{}{}
+	from := p.journalBlock + 1
+	for n := from; n < from+maxJournalReplayBlocks; n++ {
+		hash, err := rawdb.ReadCanonicalHash(coreTx, n)
+		if err != nil {
+			return nil, nil, err
+		}
+		if hash == (libcommon.Hash{}) {
+			break
+		}
+		body := rawdb.ReadCanonicalBodyWithTransactions(coreTx, hash, n)
+		if body == nil {
+			break
+		}
+		for _, txn := range body.Transactions {
+			txHash := txn.Hash()
+			mined[string(txHash[:])] = struct{}{}
+		}
+		if err := p.chargeGaslessBlock(coreTx, n); err != nil {
+			return nil, nil, err
+		}
+		p.journalBlock = n
+	}
+	if p.journalBlock >= from {
+		log.Info("[txpool] replayed blocks sequenced since the last flush", "from", from, "to", p.journalBlock, "mined", len(mined))
+	}
+	return records, mined, nil
+}
+
+// restoreJournal gives the loaded transactions back what the pool knew about them, they keep the time they first
+// arrived, and the time they moved into their sub pool when they are loaded back into the same one, so they expire as
+// they would have.  The ones that overflowed the counters are discarded on the next block as they would have been.
+func (p *TxPool) restoreJournal(records map[string]journalRecord) {
+	for idHash, r := range records {
+		mt, ok := p.byHash[idHash]
+		if !ok {
+			continue
+		}
+		mt.created = r.created
+		if mt.currentSubPool == r.subPool {
+			mt.subPoolSince = r.subPoolSince
+		}
+		if times, ok := p.txTimesLRU.Get(idHash); ok {
+			times.added = r.created
+		}
+		mt.yieldCount = r.yieldCount
+		mt.skipCount = r.skipCount
+		mt.lastSkipReason = r.lastSkipReason
+		mt.overflowZkCountersDuringExecution = r.flags&journalOverflowed != 0
+		mt.counterEstimateFailed = r.flags&journalEstimateFailed != 0
+	}
+}
@@ -0,0 +1,64 @@
+package txpool
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/types"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+var testBalance = *uint256.NewInt(1e18)
+
+func encodeSender(nonce uint64) []byte {
+	v := make([]byte, types.EncodeSenderLengthForStorage(nonce, testBalance))
+	types.EncodeSender(nonce, testBalance, v)
+	return v
+}
+
+func TestJournal_RestoresYieldState(t *testing.T) {
+	p := newTestPool(t, 2)
+	yielded, overflowed := p.sign(0, 2e9, nil), p.sign(1, 1e9, nil)
+	p.add(yielded, overflowed)
+	require.Equal(t, 2, p.yieldBest())
+	p.pool.MarkForDiscardFromPendingBest(overflowed.Hash())
+	p.flush()
+	p.restart()
+
+	mt := p.metaTx(yielded)
+	require.NotNil(t, mt)
+	require.Equal(t, uint64(1), mt.yieldCount)
+
+	// it is discarded on the first block after the restart, as it would have been without it
+	require.Nil(t, p.metaTx(overflowed))
+	require.Equal(t, OverflowZkCounters, p.pool.TxStatus(overflowed.Hash()).DiscardReason)
+}
+
+func TestJournal_OtherVersionIgnored(t *testing.T) {
+	p := newTestPool(t, 1)
+	txn := p.sign(0, 1e9, nil)
+	p.add(txn)
+	p.arrivedAgo(txn, time.Hour)
+	mt := p.metaTx(txn)
+	p.pool.lock.Lock()
+	mt.yieldCount = 3
+	p.pool.lock.Unlock()
+	p.flush()
+
+	// a journal from before it was versioned starts with the block number
+	require.NoError(t, p.poolDB.Update(p.ctx, func(tx kv.RwTx) error {
+		v := make([]byte, 8+journalRecordSize)
+		binary.BigEndian.PutUint64(v, p.head)
+		return tx.Put(kv.PoolInfo, PoolJournalKey, v)
+	}))
+	p.restart()
+
+	// the transaction is still loaded, only what the journal knew about it is lost
+	mt = p.metaTx(txn)
+	require.NotNil(t, mt)
+	require.Zero(t, mt.yieldCount)
+	require.WithinDuration(t, time.Now(), mt.created, time.Minute)
+}
@@ -0,0 +1,62 @@
+package txpool
+
+import (
+	"time"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+)
+
+// txTimes keeps track of when a transaction entered and left the pool
+type txTimes struct {
+	added     time.Time
+	discarded time.Time
+}
+
+// TxStatus is what the pool knows about a single transaction.  A transaction that has left the pool keeps its discard
+// reason and times for as long as it stays in the history LRU.
+type TxStatus struct {
+	Known         bool
+	SubPool       SubPoolType // zero once the transaction has left the pool
+	DiscardReason DiscardReason
+	Added         time.Time
+	Discarded     time.Time
+}
+
+// TxStatus reports the sub pool a transaction is in, or why it left the pool
+func (p *TxPool) TxStatus(hash libcommon.Hash) TxStatus {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var status TxStatus
+	if times, ok := p.txTimesLRU.Get(string(hash[:])); ok {
+		status.Known = true
+		status.Added = times.added
+		status.Discarded = times.discarded
+	}
+	if mt, ok := p.byHash[string(hash[:])]; ok {
+		status.Known = true
+		status.SubPool = mt.currentSubPool
+		return status
+	}
+	if reason, ok := p.discardReasonsLRU.Get(string(hash[:])); ok {
+		status.Known = true
+		status.DiscardReason = reason
+	}
+	return status
+}
+
+func (p *TxPool) trackAdded(mt *metaTx) {
+	if _, ok := p.txTimesLRU.Get(string(mt.Tx.IDHash[:])); ok {
+		return
+	}
+	p.txTimesLRU.Add(string(mt.Tx.IDHash[:]), &txTimes{added: time.Now()})
+}
+
+func (p *TxPool) trackDiscarded(mt *metaTx) {
+	times, ok := p.txTimesLRU.Get(string(mt.Tx.IDHash[:]))
+	if !ok {
+		times = &txTimes{}
+		p.txTimesLRU.Add(string(mt.Tx.IDHash[:]), times)
+	}
+	times.discarded = time.Now()
+}
@@ -0,0 +1,139 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/VictoriaMetrics/metrics"
+	mapset "github.com/deckarep/golang-set/v2"
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/length"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/types"
+)
+
+// PoolPrivateTxsKey keeps the hashes of the private transactions so they are still private when the pool is loaded
+// again
+var PoolPrivateTxsKey = []byte("private_txs")
+
+var privateTxsCounter = metrics.GetOrCreateCounter(`txpool_private_added`)
+
+// AddPrivateTx adds a signed transaction to the private lane of the pool.  It is sequenced like any other local
+// transaction but it is never announced to peers nor returned by the pool to anything but the sequencer, so it can't be
+// seen until it is mined.
+func (p *TxPool) AddPrivateTx(ctx context.Context, tx kv.Tx, rlpTx []byte) (libcommon.Hash, DiscardReason, error) {
+	var slots types.TxSlots
+	parseCtx := types.NewTxParseContext(p.chainID).ChainIDRequired()
+	parseCtx.ValidateRLP(p.ValidateSerializedTxn)
+	slots.Resize(1)
+	slots.Txs[0] = &types.TxSlot{}
+	slots.IsLocal[0] = true
+	if _, err := parseCtx.ParseTransaction(rlpTx, 0, slots.Txs[0], slots.Senders.At(0), false /* hasEnvelope */, func(hash []byte) error {
+		if known, _ := p.IdHashKnown(tx, hash); known {
+			return types.ErrAlreadyKnown
+		}
+		return nil
+	}); err != nil {
+		switch {
+		case errors.Is(err, types.ErrAlreadyKnown):
+			return slots.Txs[0].IDHash, AlreadyKnown, nil
+		case errors.Is(err, types.ErrRlpTooBig):
+			return libcommon.Hash{}, RLPTooLong, nil
+		default:
+			return libcommon.Hash{}, NotSet, fmt.Errorf("parsing transaction: %w", err)
+		}
+	}
+
+	reasons, err := p.addLocalTxs(ctx, slots, tx, true)
+	if err != nil {
+		return libcommon.Hash{}, NotSet, err
+	}
+	if reasons[0] == Success {
+		privateTxsCounter.Inc()
+	}
+	return slots.Txs[0].IDHash, reasons[0], nil
+}
+
+// hiddenLocked tells if a transaction is kept from anything reading the pool but the sequencer
+func (p *TxPool) hiddenLocked(idHash string) bool {
+	if p.hidePending {
+		return true
+	}
+	_, ok := p.privateTxs[idHash]
+	return ok
+}
+
+// IsPrivate tells if the sequencer has to keep a transaction it executed out of the pending blocks
+func (p *TxPool) IsPrivate(hash libcommon.Hash) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.hiddenLocked(string(hash[:]))
+}
+
+// privateSet is the private transactions for best to skip
+func (p *TxPool) privateSet() mapset.Set[[32]byte] {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	set := mapset.NewThreadUnsafeSet[[32]byte]()
+	for idHash := range p.privateTxs {
+		var hash [32]byte
+		copy(hash[:], idHash)
+		set.Add(hash)
+	}
+	return set
+}
+
+// markPrivateLocked puts transactions in the private lane before they are added, so they are never announced.  It
+// returns those that weren't private already.
+func (p *TxPool) markPrivateLocked(txs types.TxSlots) []string {
+	var marked []string
+	for _, txn := range txs.Txs {
+		idHash := string(txn.IDHash[:])
+		if _, ok := p.privateTxs[idHash]; !ok {
+			p.privateTxs[idHash] = struct{}{}
+			marked = append(marked, idHash)
+		}
+	}
+	return marked
+}
+
+// unmarkRejectedLocked takes the transactions the pool didn't take back out of the private lane
+func (p *TxPool) unmarkRejectedLocked(marked []string) {
+	for _, idHash := range marked {
+		if _, ok := p.byHash[idHash]; !ok {
+			delete(p.privateTxs, idHash)
+		}
+	}
+}
+
+func (p *TxPool) flushPrivateTxs(tx kv.RwTx) error {
+	// until the pool is loaded the private transactions in the db are still the ones to load
+	if !p.started.Load() {
+		return nil
+	}
+	v := make([]byte, 0, len(p.privateTxs)*length.Hash)
+	for idHash := range p.privateTxs {
+		// mined or discarded, once mined they are public anyway
+		if _, ok := p.byHash[idHash]; !ok {
+			delete(p.privateTxs, idHash)
+			continue
+		}
+		v = append(v, idHash...)
+	}
+	return tx.Put(kv.PoolInfo, PoolPrivateTxsKey, v)
+}
+
+func (p *TxPool) privateTxsFromDB(tx kv.Tx) error {
+	v, err := tx.GetOne(kv.PoolInfo, PoolPrivateTxsKey)
+	if err != nil {
+		return err
+	}
+	if len(v)%length.Hash != 0 {
+		return fmt.Errorf("private transactions: unexpected length %d", len(v))
+	}
+	for pos := 0; pos < len(v); pos += length.Hash {
+		p.privateTxs[string(v[pos:pos+length.Hash])] = struct{}{}
+	}
+	return nil
+}
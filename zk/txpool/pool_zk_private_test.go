@@ -0,0 +1,133 @@
+package txpool
+
+import (
+	"bytes"
+	"testing"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/types"
+	"github.com/stretchr/testify/require"
+
+	types2 "github.com/ledgerwatch/erigon/core/types"
+)
+
+func (p *testPool) addPrivate(txn types2.Transaction) DiscardReason {
+	var reason DiscardReason
+	p.view(func(tx kv.Tx) (err error) {
+		var hash libcommon.Hash
+		hash, reason, err = p.pool.AddPrivateTx(p.ctx, tx, p.rlp(txn))
+		require.Equal(p.t, txn.Hash(), hash)
+		return err
+	})
+	return reason
+}
+
+// visible tells where a transaction can be seen from outside of the sequencer
+func (p *testPool) visible(txn types2.Transaction) []string {
+	hash, rlpTx := txn.Hash(), p.rlp(txn)
+	var seen []string
+	p.view(func(tx kv.Tx) error {
+		rlp, err := p.pool.GetRlp(tx, hash[:])
+		if err != nil {
+			return err
+		}
+		if rlp != nil {
+			seen = append(seen, "rlp")
+		}
+
+		_, _, hashes := p.pool.AppendAllAnnouncements(nil, nil, nil)
+		for i := 0; i < len(hashes); i += 32 {
+			if bytes.Equal(hashes[i:i+32], hash[:]) {
+				seen = append(seen, "announcements")
+			}
+		}
+
+		p.pool.deprecatedForEach(p.ctx, func(rlp []byte, _ libcommon.Address, _ SubPoolType) {
+			if bytes.Equal(rlp, rlpTx) {
+				seen = append(seen, "content")
+			}
+		}, tx)
+
+		inspected, err := p.pool.Inspect(tx, InspectFilter{Hash: &hash})
+		if err != nil {
+			return err
+		}
+		if len(inspected) > 0 {
+			seen = append(seen, "inspect")
+		}
+
+		var best types.TxsRlp
+		if _, err := p.pool.PeekBest(100, &best, tx, p.head, 30_000_000); err != nil {
+			return err
+		}
+		for _, rlp := range best.Txs {
+			if bytes.Equal(rlp, rlpTx) {
+				seen = append(seen, "pending")
+			}
+		}
+		return nil
+	})
+	// the sequencer keeps private transactions out of the pending blocks it serves
+	if !p.pool.IsPrivate(hash) {
+		seen = append(seen, "pending blocks")
+	}
+	return seen
+}
+
+// yieldable tells if the sequencer gets the transaction
+func (p *testPool) yieldable(txn types2.Transaction) bool {
+	p.pool.ResetYieldedStatus()
+	rlpTx := p.rlp(txn)
+	var best types.TxsRlp
+	p.view(func(tx kv.Tx) error {
+		_, _, err := p.pool.YieldBest(100, &best, tx, p.head, 30_000_000, mapset.NewSet[[32]byte]())
+		return err
+	})
+	for _, rlp := range best.Txs {
+		if bytes.Equal(rlp, rlpTx) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPrivateTx_HiddenUntilMined(t *testing.T) {
+	p := newTestPool(t, 2)
+	private := p.sign(0, 1e9, nil)
+	require.Equal(t, Success, p.addPrivate(private))
+	public := p.sign(1, 1e9, nil)
+	p.add(public)
+
+	require.Empty(t, p.visible(private))
+	require.NotEmpty(t, p.visible(public))
+	require.True(t, p.yieldable(private))
+
+	// sending it again doesn't tell it is there
+	require.Equal(t, AlreadyKnown, p.addPrivate(private))
+
+	p.flush()
+	p.restart()
+	require.Empty(t, p.visible(private))
+	require.True(t, p.yieldable(private))
+
+	p.mine(private)
+	p.flush()
+	p.pool.lock.Lock()
+	require.Empty(t, p.pool.privateTxs)
+	p.pool.lock.Unlock()
+}
+
+func TestPrivateTx_HidePending(t *testing.T) {
+	p := newTestPool(t, 1)
+	p.ethCfg.TxPoolHidePending = true
+	p.restart()
+	txns := []types2.Transaction{p.sign(0, 1e9, nil), p.sign(0, 1e9, nil)}
+	p.add(txns...)
+
+	for _, txn := range txns {
+		require.Empty(t, p.visible(txn))
+		require.True(t, p.yieldable(txn))
+	}
+}
@@ -0,0 +1,145 @@
+package txpool
+
+import (
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/types"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/zk/gasless"
+)
+
+// expireTxsInterval is how often the pool looks for transactions that have outlived their time to live
+const expireTxsInterval = 10 * time.Second
+
+var (
+	expiredTxsCounter          = metrics.GetOrCreateCounter(`txpool_evicted_expired`)
+	senderSlotsRejectedCounter = metrics.GetOrCreateCounter(`txpool_rejected_sender_slots`)
+	senderBytesRejectedCounter = metrics.GetOrCreateCounter(`txpool_rejected_sender_bytes`)
+)
+
+// SenderUsage is how much of the pool a sender takes up
+type SenderUsage struct {
+	Slots    uint64
+	Bytes    uint64
+	Pending  int
+	BaseFee  int
+	Queued   int
+	MaxSlots uint64 // 0 when there is no limit
+	MaxBytes uint64 // 0 when there is no limit
+
+	GaslessGasUsed uint64 // gas of its zero priced transactions mined today
+}
+
+// SenderUsage reports the transactions a sender has in the pool against the per sender limits
+func (p *TxPool) SenderUsage(addr libcommon.Address) SenderUsage {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	usage := SenderUsage{
+		MaxSlots:       p.senderMaxSlots,
+		MaxBytes:       p.senderMaxBytes,
+		GaslessGasUsed: p.gaslessQuotas.Used(gasless.Day(time.Now()), addr),
+	}
+	senderID, ok := p.senders.getID(addr)
+	if !ok {
+		return usage
+	}
+	p.all.ascend(senderID, func(mt *metaTx) bool {
+		usage.Slots++
+		usage.Bytes += uint64(mt.Tx.Size)
+		switch mt.currentSubPool {
+		case PendingSubPool:
+			usage.Pending++
+		case BaseFeeSubPool:
+			usage.BaseFee++
+		case QueuedSubPool:
+			usage.Queued++
+		}
+		return true
+	})
+	return usage
+}
+
+// checkSenderQuota rejects a transaction that takes its sender over the per sender limits, local transactions
+// included.  A transaction replacing one with the same nonce doesn't take a new slot.
+func (p *TxPool) checkSenderQuota(txn *types.TxSlot) DiscardReason {
+	if p.senderMaxSlots == 0 && p.senderMaxBytes == 0 {
+		return Success
+	}
+
+	replaced := p.all.get(txn.SenderID, txn.Nonce)
+	if p.senderMaxSlots > 0 && replaced == nil && uint64(p.all.count(txn.SenderID)) >= p.senderMaxSlots {
+		senderSlotsRejectedCounter.Inc()
+		return SenderSlotsExceeded
+	}
+	if p.senderMaxBytes > 0 {
+		var used uint64
+		p.all.ascend(txn.SenderID, func(mt *metaTx) bool {
+			if mt != replaced {
+				used += uint64(mt.Tx.Size)
+			}
+			return true
+		})
+		if used+uint64(txn.Size) > p.senderMaxBytes {
+			senderBytesRejectedCounter.Inc()
+			return SenderBytesExceeded
+		}
+	}
+	return Success
+}
+
+// expireTxs drops the transactions that have waited in their sub pool for longer than its time to live, along with
+// the later nonces of their senders which can't be sequenced without them.  A transaction moving between sub pools
+// starts waiting again, and the ones yielded to the sequencer are left to the block being built.
+func (p *TxPool) expireTxs() {
+	if p.queuedTTL == 0 && p.pendingTTL == 0 {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := time.Now()
+	var expired []*metaTx
+	isExpired := map[*metaTx]struct{}{}
+	p.all.ascendAll(func(mt *metaTx) bool {
+		if _, ok := isExpired[mt]; ok || mt.alreadyYielded {
+			return true
+		}
+		ttl := p.queuedTTL
+		if mt.currentSubPool == PendingSubPool {
+			ttl = p.pendingTTL
+		}
+		if ttl == 0 || now.Sub(mt.subPoolSince) <= ttl {
+			return true
+		}
+		p.all.ascend(mt.Tx.SenderID, func(later *metaTx) bool {
+			if later.Tx.Nonce >= mt.Tx.Nonce && !later.alreadyYielded {
+				isExpired[later] = struct{}{}
+				expired = append(expired, later)
+			}
+			return true
+		})
+		return true
+	})
+
+	for _, mt := range expired {
+		switch mt.currentSubPool {
+		case PendingSubPool:
+			p.pending.Remove(mt)
+		case BaseFeeSubPool:
+			p.baseFee.Remove(mt)
+		case QueuedSubPool:
+			p.queued.Remove(mt)
+		}
+		p.discardLocked(mt, Expired)
+	}
+
+	if len(expired) > 0 {
+		expiredTxsCounter.Add(len(expired))
+		log.Debug("[txpool] expired transactions", "count", len(expired))
+	}
+}
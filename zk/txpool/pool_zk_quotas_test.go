@@ -0,0 +1,157 @@
+package txpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	types2 "github.com/ledgerwatch/erigon/core/types"
+)
+
+func (p *testPool) arrivedAgo(txn types2.Transaction, d time.Duration) {
+	mt := p.metaTx(txn)
+	require.NotNil(p.t, mt)
+	p.pool.lock.Lock()
+	defer p.pool.lock.Unlock()
+	mt.created = time.Now().Add(-d)
+	mt.subPoolSince = mt.created
+}
+
+func (p *testPool) requireExpired(txn types2.Transaction) {
+	require.Nil(p.t, p.metaTx(txn))
+	hash := txn.Hash()
+	reason, ok := p.pool.discardReasonsLRU.Get(string(hash[:]))
+	require.True(p.t, ok)
+	require.Equal(p.t, Expired, reason)
+}
+
+func TestExpireTxs_LaterNonces(t *testing.T) {
+	p := newTestPool(t, 2)
+	p.pool.pendingTTL = time.Hour
+	first, second, third := p.sign(0, 1e9, nil), p.sign(0, 1e9, nil), p.sign(0, 1e9, nil)
+	other := p.sign(1, 1e9, nil)
+	p.add(first, second, third, other)
+	require.Equal(t, PendingSubPool, p.metaTx(third).currentSubPool)
+
+	p.arrivedAgo(second, 2*time.Hour)
+	p.arrivedAgo(other, 30*time.Minute)
+	p.pool.expireTxs()
+
+	// the third can't be sequenced once the second is gone so it goes with it
+	require.NotNil(t, p.metaTx(first))
+	p.requireExpired(second)
+	p.requireExpired(third)
+	require.NotNil(t, p.metaTx(other))
+}
+
+func TestExpireTxs_Queued(t *testing.T) {
+	p := newTestPool(t, 1)
+	p.pool.queuedTTL = time.Hour
+	pending := p.sign(0, 1e9, nil)
+	gapped := p.signNonce(0, 5, 1e9, nil)
+	p.add(pending, gapped)
+	require.Equal(t, QueuedSubPool, p.metaTx(gapped).currentSubPool)
+
+	// the pending sub pool has no time to live
+	p.arrivedAgo(pending, 2*time.Hour)
+	p.arrivedAgo(gapped, 2*time.Hour)
+	p.pool.expireTxs()
+
+	require.NotNil(t, p.metaTx(pending))
+	p.requireExpired(gapped)
+}
+
+func TestExpireTxs_WaitStartsInSubPool(t *testing.T) {
+	p := newTestPool(t, 1)
+	p.pool.pendingTTL = time.Hour
+	gapped := p.signNonce(0, 1, 1e9, nil)
+	p.add(gapped)
+	require.Equal(t, QueuedSubPool, p.metaTx(gapped).currentSubPool)
+	p.arrivedAgo(gapped, 2*time.Hour)
+
+	// it has only just become pending once the gap is filled
+	p.add(p.signNonce(0, 0, 1e9, nil))
+	require.Equal(t, PendingSubPool, p.metaTx(gapped).currentSubPool)
+	p.pool.expireTxs()
+	require.NotNil(t, p.metaTx(gapped))
+}
+
+func TestExpireTxs_Yielded(t *testing.T) {
+	p := newTestPool(t, 1)
+	p.pool.pendingTTL = time.Hour
+	txn := p.sign(0, 1e9, nil)
+	p.add(txn)
+	p.arrivedAgo(txn, 2*time.Hour)
+	require.Equal(t, 1, p.yieldBest())
+
+	// the block being built decides what happens to it
+	p.pool.expireTxs()
+	require.NotNil(t, p.metaTx(txn))
+
+	p.pool.ResetYieldedStatus()
+	p.pool.expireTxs()
+	p.requireExpired(txn)
+}
+
+func TestExpireTxs_ArrivalSurvivesRestart(t *testing.T) {
+	p := newTestPool(t, 1)
+	p.pool.pendingTTL = time.Hour
+	txn := p.sign(0, 1e9, nil)
+	p.add(txn)
+	p.arrivedAgo(txn, 2*time.Hour)
+	arrived := p.metaTx(txn).created
+	p.flush()
+
+	p.restart()
+	p.pool.pendingTTL = time.Hour
+	require.True(t, arrived.Equal(p.metaTx(txn).created))
+	require.True(t, arrived.Equal(p.metaTx(txn).subPoolSince))
+	require.True(t, arrived.Equal(p.pool.TxStatus(txn.Hash()).Added))
+
+	p.pool.expireTxs()
+	p.requireExpired(txn)
+}
+
+func TestSenderQuota_Slots(t *testing.T) {
+	p := newTestPool(t, 2)
+	p.pool.senderMaxSlots = 2
+	first, second := p.sign(0, 1e9, nil), p.sign(0, 1e9, nil)
+	p.add(first, second)
+
+	require.Equal(t, []DiscardReason{SenderSlotsExceeded}, p.addReasons(p.sign(0, 1e9, nil)))
+	// the limit is per sender
+	p.add(p.sign(1, 1e9, nil))
+
+	// a replacement takes the slot of the transaction it replaces
+	replacement := p.signNonce(0, 1, 2e9, nil)
+	p.add(replacement)
+	require.Nil(t, p.metaTx(second))
+	require.NotNil(t, p.metaTx(replacement))
+
+	usage := p.pool.SenderUsage(p.senders[0])
+	require.Equal(t, uint64(2), usage.Slots)
+	require.Equal(t, 2, usage.Pending)
+}
+
+func TestSenderQuota_Bytes(t *testing.T) {
+	p := newTestPool(t, 1)
+	first := p.sign(0, 1e9, nil)
+	p.add(first)
+	size := uint64(p.metaTx(first).Tx.Size)
+	p.pool.senderMaxBytes = 2*size + 2
+
+	p.add(p.sign(0, 1e9, nil))
+	require.Equal(t, []DiscardReason{SenderBytesExceeded}, p.addReasons(p.sign(0, 1e9, nil)))
+
+	// a replacement only counts the bytes it takes over the one it replaces
+	p.add(p.signNonce(0, 0, 2e9, nil))
+	require.Equal(t, []DiscardReason{SenderBytesExceeded}, p.addReasons(p.signNonce(0, 1, 3e9, make([]byte, 10))))
+
+	// the sizes of the transactions loaded from the db count too
+	p.flush()
+	p.restart()
+	p.pool.senderMaxBytes = 2*size + 2
+	require.Equal(t, 2*size, p.pool.SenderUsage(p.senders[0]).Bytes)
+	require.Equal(t, []DiscardReason{SenderBytesExceeded}, p.addReasons(p.sign(0, 1e9, nil)))
+}
@@ -0,0 +1,159 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/VictoriaMetrics/metrics"
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/u256"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/types"
+	"github.com/holiman/uint256"
+
+	types2 "github.com/ledgerwatch/erigon/core/types"
+)
+
+var replaceYieldedCounter = metrics.GetOrCreateCounter(`txpool_rejected_replace_yielded`)
+
+// the outcomes of ReplaceTx
+const (
+	ReplacementReplaced = "replaced"  // the replacement took the place of the transaction
+	ReplacementRejected = "rejected"  // the transaction is still in the pool, see the reason
+	ReplacementNotFound = "not-found" // the pool has no transaction of the sender with that nonce, it may be mined already
+)
+
+// Replacement is how a transaction sent to replace another one went
+type Replacement struct {
+	Hash     libcommon.Hash
+	Replaced libcommon.Hash // the transaction replaced or that would have been, zero when not found
+	Status   string
+	Reason   DiscardReason
+	// the fee cap and tip the transaction still in the pool would need to be replaced, for its kind of transaction
+	MinFeeCap uint256.Int
+	MinTip    uint256.Int
+}
+
+// effectivePercentage is the share of its gas price a transaction pays, as the sequencer derives it.  The rlp is only
+// needed to tell erc20 transfers from other contract calls.
+func (p *TxPool) effectivePercentage(txn *types.TxSlot) uint8 {
+	if p.zkCfg == nil {
+		return 255
+	}
+	if txn.Creation {
+		return p.zkCfg.EffectiveGasPriceForContractDeployment
+	}
+	if txn.DataLen == 0 {
+		return p.zkCfg.EffectiveGasPriceForEthTransfer
+	}
+	if txn.Rlp != nil {
+		if transaction, err := types2.UnmarshalTransactionFromBinary(txn.Rlp); err == nil {
+			return p.zkCfg.EffectiveGasPricePercentage(transaction.GetTo(), transaction.GetData())
+		}
+	}
+	return p.zkCfg.EffectiveGasPriceForContractInvocation
+}
+
+// replacementThresholdLocked is the lowest price a transaction paying the given percentage needs to replace found,
+// the price found pays effectively bumped by priceBump
+func (p *TxPool) replacementThresholdLocked(found *metaTx, price *uint256.Int, percentage uint8) *uint256.Int {
+	threshold := new(uint256.Int).Mul(price, uint256.NewInt(uint64(found.effectivePercentage)+1))
+	threshold.Mul(threshold, uint256.NewInt(100+p.priceBump))
+	threshold.Div(threshold, u256.N100)
+	// back to the gas price of the replacement, rounded up
+	divisor := uint256.NewInt(uint64(percentage) + 1)
+	rounded := new(uint256.Int).Add(threshold, new(uint256.Int).Sub(divisor, uint256.NewInt(1)))
+	return rounded.Div(rounded, divisor)
+}
+
+// checkReplacementLocked tells if mt can take the place of found, the transaction of the same sender and nonce.  What
+// is compared is the price both effectively pay, and a transaction already yielded to the sequencer may be executing
+// in the open block so it is never replaced until the pool hears about that block.
+func (p *TxPool) checkReplacementLocked(found, mt *metaTx) DiscardReason {
+	if found.alreadyYielded {
+		replaceYieldedCounter.Inc()
+		return ReplaceYielded
+	}
+	if mt.Tx.Tip.Lt(p.replacementThresholdLocked(found, &found.Tx.Tip, mt.effectivePercentage)) ||
+		mt.Tx.FeeCap.Lt(p.replacementThresholdLocked(found, &found.Tx.FeeCap, mt.effectivePercentage)) {
+		return NotReplaced
+	}
+	return NotSet
+}
+
+func (p *TxPool) markYieldedLocked(mt *metaTx) {
+	mt.yieldCount++
+	if !mt.alreadyYielded {
+		mt.alreadyYielded = true
+		p.yielded = append(p.yielded, mt)
+	}
+}
+
+// clearYieldedLocked lets the transactions yielded before the last block be replaced again, those included are gone
+func (p *TxPool) clearYieldedLocked() {
+	for _, mt := range p.yielded {
+		mt.alreadyYielded = false
+	}
+	p.yielded = p.yielded[:0]
+}
+
+// ReplaceTx adds a signed transaction meant to replace one of the same sender and nonce, a cancellation usually, and
+// tells how it went.  Unlike AddLocalTxs nothing is added when there is no transaction to replace.  The replacement of
+// a private transaction is private as well.
+func (p *TxPool) ReplaceTx(ctx context.Context, tx kv.Tx, rlpTx []byte) (*Replacement, error) {
+	var slots types.TxSlots
+	parseCtx := types.NewTxParseContext(p.chainID).ChainIDRequired()
+	parseCtx.ValidateRLP(p.ValidateSerializedTxn)
+	slots.Resize(1)
+	slots.Txs[0] = &types.TxSlot{}
+	slots.IsLocal[0] = true
+	if _, err := parseCtx.ParseTransaction(rlpTx, 0, slots.Txs[0], slots.Senders.At(0), false /* hasEnvelope */, nil); err != nil {
+		if errors.Is(err, types.ErrRlpTooBig) {
+			return &Replacement{Status: ReplacementRejected, Reason: RLPTooLong}, nil
+		}
+		return nil, fmt.Errorf("parsing transaction: %w", err)
+	}
+	txn := slots.Txs[0]
+	replacement := &Replacement{Hash: txn.IDHash, Status: ReplacementNotFound}
+
+	var private bool
+	p.lock.Lock()
+	if senderID, ok := p.senders.getID(slots.Senders.AddressAt(0)); ok {
+		if found := p.all.get(senderID, txn.Nonce); found != nil {
+			replacement.Replaced = found.Tx.IDHash
+			_, private = p.privateTxs[string(found.Tx.IDHash[:])]
+		}
+	}
+	p.lock.Unlock()
+	if replacement.Replaced == (libcommon.Hash{}) {
+		return replacement, nil
+	}
+	if replacement.Replaced == replacement.Hash {
+		replacement.Status, replacement.Reason = ReplacementRejected, AlreadyKnown
+		return replacement, nil
+	}
+
+	reasons, err := p.addLocalTxs(ctx, slots, tx, private)
+	if err != nil {
+		return nil, err
+	}
+	replacement.Reason = reasons[0]
+	if replacement.Reason == Success {
+		replacement.Status = ReplacementReplaced
+		return replacement, nil
+	}
+	replacement.Status = ReplacementRejected
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if found, ok := p.byHash[string(replacement.Replaced[:])]; ok {
+		percentage := p.effectivePercentage(txn)
+		replacement.MinFeeCap = *p.replacementThresholdLocked(found, &found.Tx.FeeCap, percentage)
+		replacement.MinTip = *p.replacementThresholdLocked(found, &found.Tx.Tip, percentage)
+	} else {
+		// gone meanwhile, mined most likely
+		replacement.Status = ReplacementNotFound
+	}
+	return replacement, nil
+}
@@ -0,0 +1,110 @@
+package txpool
+
+import (
+	"testing"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/types"
+	"github.com/stretchr/testify/require"
+
+	types2 "github.com/ledgerwatch/erigon/core/types"
+)
+
+func (p *testPool) replace(txn types2.Transaction) *Replacement {
+	var replacement *Replacement
+	p.view(func(tx kv.Tx) (err error) {
+		replacement, err = p.pool.ReplaceTx(p.ctx, tx, p.rlp(txn))
+		return err
+	})
+	return replacement
+}
+
+func (p *testPool) yieldBest() int {
+	var count int
+	p.view(func(tx kv.Tx) (err error) {
+		var slots types.TxsRlp
+		_, count, err = p.pool.YieldBest(10, &slots, tx, p.head, 30_000_000, mapset.NewSet[[32]byte]())
+		return err
+	})
+	return count
+}
+
+func TestReplaceTx_EffectivePrice(t *testing.T) {
+	p := newTestPool(t, 1)
+	p.ethCfg.Zk.EffectiveGasPriceForEthTransfer = 127
+	p.ethCfg.Zk.EffectiveGasPriceForContractInvocation = 255
+	p.restart()
+	p.pool.priceBump = 10
+
+	call := p.sign(0, 1e9, []byte{0x01, 0x02, 0x03, 0x04})
+	p.add(call)
+
+	// a transfer pays half of its gas price, 10% above what the call pays effectively is 2.2 gwei
+	replacement := p.replace(p.signNonce(0, 0, 2e9, nil))
+	require.Equal(t, ReplacementRejected, replacement.Status)
+	require.Equal(t, NotReplaced, replacement.Reason)
+	require.Equal(t, call.Hash(), replacement.Replaced)
+	require.Equal(t, uint64(2_200_000_000), replacement.MinFeeCap.Uint64())
+
+	cancel := p.signNonce(0, 0, 2_200_000_000, nil)
+	replacement = p.replace(cancel)
+	require.Equal(t, ReplacementReplaced, replacement.Status, replacement.Reason.String())
+	require.Equal(t, cancel.Hash(), replacement.Hash)
+	require.Nil(t, p.metaTx(call))
+	require.NotNil(t, p.metaTx(cancel))
+}
+
+func TestReplaceTx_Yielded(t *testing.T) {
+	p := newTestPool(t, 1)
+	original := p.sign(0, 1e9, nil)
+	p.add(original)
+
+	// peeking at the pending transactions doesn't stop them from being replaced
+	p.view(func(tx kv.Tx) error {
+		var slots types.TxsRlp
+		_, err := p.pool.PeekBest(10, &slots, tx, p.head, 30_000_000)
+		return err
+	})
+	require.False(t, p.metaTx(original).alreadyYielded)
+
+	require.Equal(t, 1, p.yieldBest())
+	replacement := p.replace(p.signNonce(0, 0, 2e9, nil))
+	require.Equal(t, ReplacementRejected, replacement.Status)
+	require.Equal(t, ReplaceYielded, replacement.Reason)
+
+	// the block it was yielded for came without it
+	p.mine()
+	replacement = p.replace(p.signNonce(0, 0, 2e9, nil))
+	require.Equal(t, ReplacementReplaced, replacement.Status, replacement.Reason.String())
+}
+
+func TestReplaceTx_ResetYieldedStatus(t *testing.T) {
+	p := newTestPool(t, 1)
+	original := p.sign(0, 1e9, nil)
+	p.add(original)
+	require.Equal(t, 1, p.yieldBest())
+
+	// the sequencer gave up on the block so the transaction can be replaced again
+	p.pool.ResetYieldedStatus()
+	p.pool.lock.Lock()
+	require.Empty(t, p.pool.yielded)
+	p.pool.lock.Unlock()
+	require.False(t, p.metaTx(original).alreadyYielded)
+
+	replacement := p.replace(p.signNonce(0, 0, 2e9, nil))
+	require.Equal(t, ReplacementReplaced, replacement.Status, replacement.Reason.String())
+}
+
+func TestReplaceTx_NotFound(t *testing.T) {
+	p := newTestPool(t, 1)
+	txn := p.sign(0, 1e9, nil)
+	replacement := p.replace(txn)
+	require.Equal(t, ReplacementNotFound, replacement.Status)
+
+	p.view(func(tx kv.Tx) error {
+		known, err := p.pool.IdHashKnown(tx, txn.Hash().Bytes())
+		require.False(t, known)
+		return err
+	})
+}
@@ -0,0 +1,212 @@
+package txpool
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/gointerfaces"
+	"github.com/gateway-fm/cdk-erigon-lib/gointerfaces/remote"
+	"github.com/gateway-fm/cdk-erigon-lib/kv"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/kvcache"
+	"github.com/gateway-fm/cdk-erigon-lib/kv/memdb"
+	"github.com/gateway-fm/cdk-erigon-lib/txpool/txpoolcfg"
+	"github.com/gateway-fm/cdk-erigon-lib/types"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	types2 "github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/eth/ethconfig"
+)
+
+// testPool is a started pool on top of a chain whose senders have a balance, it signs the transactions of the
+// senders in nonce order and mines them one block at a time
+type testPool struct {
+	t       *testing.T
+	ctx     context.Context
+	coreDB  kv.RwDB
+	poolDB  kv.RwDB
+	pool    *TxPool
+	ethCfg  *ethconfig.Config
+	chainID *big.Int
+	head    uint64
+
+	keys    []*ecdsa.PrivateKey
+	senders []libcommon.Address
+	signed  map[libcommon.Address]uint64 // the next nonce to sign
+	mined   map[libcommon.Address]uint64 // the nonce in the state
+}
+
+func newTestPool(t *testing.T, senders int) *testPool {
+	p := &testPool{
+		t:       t,
+		ctx:     context.Background(),
+		coreDB:  memdb.NewTestDB(t),
+		poolDB:  memdb.NewTestPoolDB(t),
+		ethCfg:  &ethconfig.Config{Zk: &ethconfig.Zk{}},
+		chainID: big.NewInt(1),
+		signed:  map[libcommon.Address]uint64{},
+		mined:   map[libcommon.Address]uint64{},
+	}
+	for i := 0; i < senders; i++ {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		p.keys = append(p.keys, key)
+		p.senders = append(p.senders, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	p.writeBlock(types2.NewBlock(&types2.Header{Number: big.NewInt(0)}, nil, nil, nil, nil))
+	p.restart()
+	return p
+}
+
+// restart loads a new pool from the pool db
+func (p *testPool) restart() {
+	pool, err := New(make(chan types.Announcements, 100), p.coreDB, txpoolcfg.DefaultConfig, p.ethCfg, kvcache.NewDummy(), *uint256.MustFromBig(p.chainID), big.NewInt(0), big.NewInt(0))
+	require.NoError(p.t, err)
+	p.pool = pool
+	p.pool.ForceUpdateLatestBlock(p.head)
+	p.onNewBlock(&remote.StateChange{BlockHeight: p.head}, types.TxSlots{})
+}
+
+func (p *testPool) onNewBlock(change *remote.StateChange, minedTxs types.TxSlots) {
+	batch := &remote.StateChangeBatch{BlockGasLimit: 30_000_000, ChangeBatch: []*remote.StateChange{change}}
+	p.view(func(tx kv.Tx) error {
+		return p.pool.OnNewBlock(p.ctx, batch, types.TxSlots{}, minedTxs, tx)
+	})
+}
+
+func (p *testPool) view(f func(tx kv.Tx) error) {
+	require.NoError(p.t, p.poolDB.View(p.ctx, f))
+}
+
+func (p *testPool) flush() {
+	_, err := p.pool.flush(p.ctx, p.poolDB)
+	require.NoError(p.t, err)
+}
+
+// sign signs the next transaction of a sender, data makes it a contract call
+func (p *testPool) sign(k int, gasPrice uint64, data []byte) types2.Transaction {
+	nonce := p.signed[p.senders[k]]
+	p.signed[p.senders[k]]++
+	return p.signNonce(k, nonce, gasPrice, data)
+}
+
+// signNonce signs a transaction of a sender with a nonce already used, to replace the one sent with it
+func (p *testPool) signNonce(k int, nonce, gasPrice uint64, data []byte) types2.Transaction {
+	gas := uint64(21_000)
+	if len(data) > 0 {
+		gas = 100_000
+	}
+	txn, err := types2.SignTx(types2.NewTransaction(nonce, libcommon.HexToAddress("0xaa"), uint256.NewInt(1), gas, uint256.NewInt(gasPrice), data), *types2.LatestSignerForChainID(p.chainID), p.keys[k])
+	require.NoError(p.t, err)
+	return txn
+}
+
+func (p *testPool) rlp(txn types2.Transaction) []byte {
+	rlp, err := types2.MarshalTransactionsBinary(types2.Transactions{txn})
+	require.NoError(p.t, err)
+	return rlp[0]
+}
+
+// slots parses transactions as the pool does
+func (p *testPool) slots(txns ...types2.Transaction) types.TxSlots {
+	var slots types.TxSlots
+	parseCtx := types.NewTxParseContext(*uint256.MustFromBig(p.chainID))
+	for _, txn := range txns {
+		slot := &types.TxSlot{}
+		sender := make([]byte, 20)
+		_, err := parseCtx.ParseTransaction(p.rlp(txn), 0, slot, sender, false /* hasEnvelope */, nil)
+		require.NoError(p.t, err)
+		slots.Append(slot, sender, true)
+	}
+	return slots
+}
+
+// add sends transactions to the pool and checks they are accepted
+func (p *testPool) add(txns ...types2.Transaction) {
+	for _, reason := range p.addReasons(txns...) {
+		require.Equal(p.t, Success, reason, reason.String())
+	}
+}
+
+func (p *testPool) addReasons(txns ...types2.Transaction) (reasons []DiscardReason) {
+	p.view(func(tx kv.Tx) (err error) {
+		reasons, err = p.pool.AddLocalTxs(p.ctx, p.slots(txns...), tx)
+		return err
+	})
+	return reasons
+}
+
+func (p *testPool) metaTx(txn types2.Transaction) *metaTx {
+	hash := txn.Hash()
+	p.pool.lock.Lock()
+	defer p.pool.lock.Unlock()
+	return p.pool.byHash[string(hash[:])]
+}
+
+// mine writes the next block with the transactions and tells the pool about it
+func (p *testPool) mine(txns ...types2.Transaction) {
+	parent, err := p.readCanonicalHash(p.head)
+	require.NoError(p.t, err)
+	p.mineBlock(types2.NewBlock(&types2.Header{Number: new(big.Int).SetUint64(p.head + 1), ParentHash: parent}, txns, nil, nil, nil))
+}
+
+// mineBlock writes a block built on the head and tells the pool about it
+func (p *testPool) mineBlock(block *types2.Block) {
+	txns := block.Transactions()
+	p.writeBlock(block)
+
+	change := &remote.StateChange{BlockHeight: block.NumberU64(), BlockHash: gointerfaces.ConvertHashToH256(block.Hash())}
+	for _, sender := range p.senders {
+		change.Changes = append(change.Changes, &remote.AccountChange{
+			Action:  remote.Action_UPSERT,
+			Address: gointerfaces.ConvertAddressToH160(sender),
+			Data:    encodeSender(p.mined[sender]),
+		})
+	}
+	minedTxs := p.slots(txns...)
+	for i := range minedTxs.IsLocal {
+		minedTxs.IsLocal[i] = false
+	}
+	p.onNewBlock(change, minedTxs)
+}
+
+func (p *testPool) readCanonicalHash(number uint64) (hash libcommon.Hash, err error) {
+	err = p.coreDB.View(p.ctx, func(tx kv.Tx) error {
+		hash, err = rawdb.ReadCanonicalHash(tx, number)
+		return err
+	})
+	return hash, err
+}
+
+func (p *testPool) writeBlock(block *types2.Block) {
+	var senders []libcommon.Address
+	for _, txn := range block.Transactions() {
+		sender, err := txn.Sender(*types2.LatestSignerForChainID(p.chainID))
+		require.NoError(p.t, err)
+		p.mined[sender]++
+		senders = append(senders, sender)
+	}
+	require.NoError(p.t, p.coreDB.Update(p.ctx, func(tx kv.RwTx) error {
+		if err := rawdb.WriteBlock(tx, block); err != nil {
+			return err
+		}
+		if err := rawdb.WriteSenders(tx, block.Hash(), block.NumberU64(), senders); err != nil {
+			return err
+		}
+		if err := rawdb.WriteCanonicalHash(tx, block.Hash(), block.NumberU64()); err != nil {
+			return err
+		}
+		for _, sender := range p.senders {
+			if err := tx.Put(kv.PlainState, sender[:], encodeSender(p.mined[sender])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	p.head = block.NumberU64()
+}
@@ -0,0 +1,50 @@
+package txpool
+
+import (
+	"github.com/gateway-fm/cdk-erigon-lib/common/fixedgas"
+	"github.com/holiman/uint256"
+
+	types2 "github.com/ledgerwatch/erigon/core/types"
+)
+
+// ValidateTxStateless runs the checks of validateTx that need neither the pool nor the state, for the nodes checking a
+// transaction before it reaches the pool of the sequencer
+func ValidateTxStateless(txn types2.Transaction, isShanghai, isLondon bool) DiscardReason {
+	data := txn.GetData()
+	if isShanghai && len(data) > fixedgas.MaxInitCodeSize {
+		return InitCodeTooLarge
+	}
+	if !isLondon && txn.Type() == types2.DynamicFeeTxType {
+		return UnsupportedTx
+	}
+
+	nonZero := 0
+	for _, b := range data {
+		if b != 0 {
+			nonZero++
+		}
+	}
+	gas, reason := CalcIntrinsicGas(uint64(len(data)), uint64(nonZero), nil, txn.GetTo() == nil, true, true, isShanghai)
+	if reason != Success {
+		return reason
+	}
+	if gas > txn.GetGas() {
+		return IntrinsicGas
+	}
+	return Success
+}
+
+// ValidateTxFunds checks a transaction against the nonce and balance of its sender as validateTx does
+func ValidateTxFunds(txn types2.Transaction, nonce uint64, balance *uint256.Int) DiscardReason {
+	if nonce > txn.GetNonce() {
+		return NonceTooLow
+	}
+	// Transactor should have enough funds to cover the costs
+	total := uint256.NewInt(txn.GetGas())
+	total.Mul(total, txn.GetFeeCap())
+	total.Add(total, txn.GetValue())
+	if balance.Cmp(total) < 0 {
+		return InsufficientFunds
+	}
+	return Success
+}
@@ -0,0 +1,44 @@
+package txpool
+
+import (
+	"testing"
+
+	libcommon "github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/gateway-fm/cdk-erigon-lib/common/fixedgas"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	types2 "github.com/ledgerwatch/erigon/core/types"
+)
+
+func TestValidateTxStateless(t *testing.T) {
+	to := libcommon.HexToAddress("0xaa")
+	dynamicFee := &types2.DynamicFeeTransaction{CommonTx: types2.CommonTx{To: &to, Gas: 21_000, Value: uint256.NewInt(0)}, Tip: uint256.NewInt(1), FeeCap: uint256.NewInt(1)}
+	tests := []struct {
+		name                 string
+		txn                  types2.Transaction
+		isShanghai, isLondon bool
+		reason               DiscardReason
+	}{
+		{"transfer", types2.NewTransaction(0, to, uint256.NewInt(1), 21_000, uint256.NewInt(1), nil), true, true, Success},
+		{"intrinsic gas", types2.NewTransaction(0, to, uint256.NewInt(1), 20_999, uint256.NewInt(1), nil), true, true, IntrinsicGas},
+		{"call data gas", types2.NewTransaction(0, to, uint256.NewInt(1), 21_000, uint256.NewInt(1), []byte{0x01}), true, true, IntrinsicGas},
+		{"init code too large", types2.NewContractCreation(0, uint256.NewInt(0), 30_000_000, uint256.NewInt(1), make([]byte, fixedgas.MaxInitCodeSize+1)), true, true, InitCodeTooLarge},
+		{"init code before shanghai", types2.NewContractCreation(0, uint256.NewInt(0), 30_000_000, uint256.NewInt(1), make([]byte, fixedgas.MaxInitCodeSize+1)), false, true, Success},
+		{"dynamic fee before london", dynamicFee, true, false, UnsupportedTx},
+		{"dynamic fee", dynamicFee, true, true, Success},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.reason, ValidateTxStateless(tt.txn, tt.isShanghai, tt.isLondon))
+		})
+	}
+}
+
+func TestValidateTxFunds(t *testing.T) {
+	txn := types2.NewTransaction(1, libcommon.HexToAddress("0xaa"), uint256.NewInt(1), 21_000, uint256.NewInt(2), nil)
+	require.Equal(t, Success, ValidateTxFunds(txn, 1, uint256.NewInt(42_001)))
+	require.Equal(t, NonceTooLow, ValidateTxFunds(txn, 2, uint256.NewInt(42_001)))
+	// gas x fee cap + value
+	require.Equal(t, InsufficientFunds, ValidateTxFunds(txn, 1, uint256.NewInt(42_000)))
+}
@@ -236,10 +236,12 @@ func mapDiscardReasonToProto(reason DiscardReason) txpool_proto.ImportResult {
 		return txpool_proto.ImportResult_SUCCESS
 	case AlreadyKnown:
 		return txpool_proto.ImportResult_ALREADY_EXISTS
-	case UnderPriced, ReplaceUnderpriced, FeeTooLow:
+	case UnderPriced, ReplaceUnderpriced, NotReplaced, FeeTooLow, NotSponsored, GaslessQuotaReached:
 		return txpool_proto.ImportResult_FEE_TOO_LOW
-	case InvalidSender, NegativeValue, OversizedData, InitCodeTooLarge, RLPTooLong, UnsupportedTx:
+	case InvalidSender, NegativeValue, OversizedData, InitCodeTooLarge, RLPTooLong, UnsupportedTx, SenderSlotsExceeded, SenderBytesExceeded:
 		return txpool_proto.ImportResult_INVALID
+	case Expired, ReplaceYielded:
+		return txpool_proto.ImportResult_STALE
 	default:
 		return txpool_proto.ImportResult_INTERNAL_ERROR
 	}
@@ -113,3 +113,32 @@ func (ib *L1InjectedBatch) Unmarshall(input []byte) error {
 	ib.Transaction = append([]byte{}, input[132:]...)
 	return nil
 }
+
+// BlockInfo holds the values the block info tree of a block is built from, the tree can be rebuilt from it to prove
+// its leaves
+type BlockInfo struct {
+	ParentHash  common.Hash
+	Coinbase    common.Address
+	BlockNumber uint64
+	GasLimit    uint64
+	Timestamp   uint64
+	Ger         common.Hash
+	L1BlockHash common.Hash
+	GasUsed     uint64
+	Txs         []BlockInfoTx
+}
+
+type BlockInfoTx struct {
+	Index               int
+	L2TxHash            common.Hash
+	Status              uint64
+	CumulativeGasUsed   uint64
+	EffectivePercentage uint8
+	Logs                []BlockInfoLog
+}
+
+// BlockInfoLog is the leaf of a log, the hash of its data and topics
+type BlockInfoLog struct {
+	Index int64
+	Hash  common.Hash
+}
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"encoding/binary"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/iden3/go-iden3-crypto/keccak256"
+)
+
+// CalculateEtrogAccInputHash chains a batch onto the accumulated input hash the same way the etrog rollup contract does
+// when a sequence is submitted.  For a regular batch globalExitRoot is the l1 info root of the sequence, timestamp the
+// max sequence timestamp, or the time of the L1 block before elderberry, and forcedBlockHashL1 is zero, forced batches
+// use their own values from the L1.
+func CalculateEtrogAccInputHash(
+	oldAccInputHash common.Hash,
+	batchL2Data []byte,
+	globalExitRoot common.Hash,
+	timestamp uint64,
+	sequencer common.Address,
+	forcedBlockHashL1 common.Hash,
+) common.Hash {
+	packed := make([]byte, 0, 32+32+32+8+20+32)
+	packed = append(packed, oldAccInputHash[:]...)
+	packed = append(packed, keccak256.Hash(batchL2Data)...)
+	packed = append(packed, globalExitRoot[:]...)
+	packed = binary.BigEndian.AppendUint64(packed, timestamp)
+	packed = append(packed, sequencer[:]...)
+	packed = append(packed, forcedBlockHashL1[:]...)
+
+	return common.BytesToHash(keccak256.Hash(packed))
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/gateway-fm/cdk-erigon-lib/common"
+	"github.com/iden3/go-iden3-crypto/keccak256"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateEtrogAccInputHash(t *testing.T) {
+	oldAccInputHash := common.HexToHash("0x01")
+	batchL2Data := []byte{0x0b, 0x00, 0x00, 0x00, 0x01}
+	l1InfoRoot := common.HexToHash("0x02")
+	sequencer := common.HexToAddress("0x03")
+
+	// abi.encodePacked(bytes32, bytes32, bytes32, uint64, address, bytes32)
+	packed := common.FromHex(
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+			hex.EncodeToString(keccak256.Hash(batchL2Data)) +
+			"0000000000000000000000000000000000000000000000000000000000000002" +
+			"0000000000000004" +
+			"0000000000000000000000000000000000000003" +
+			"0000000000000000000000000000000000000000000000000000000000000000",
+	)
+	expected := common.BytesToHash(keccak256.Hash(packed))
+
+	assert.Equal(t, expected, CalculateEtrogAccInputHash(oldAccInputHash, batchL2Data, l1InfoRoot, 4, sequencer, common.Hash{}))
+
+	// every input is part of the chain
+	assert.NotEqual(t, expected, CalculateEtrogAccInputHash(oldAccInputHash, batchL2Data, l1InfoRoot, 5, sequencer, common.Hash{}))
+	assert.NotEqual(t, expected, CalculateEtrogAccInputHash(oldAccInputHash, batchL2Data, l1InfoRoot, 4, sequencer, common.HexToHash("0x04")))
+}